@@ -6,11 +6,15 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/your-team/taskmanager-chat/backend/internal/adapters/rest"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
 	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/blob"
 	mongodbstorage "github.com/your-team/taskmanager-chat/backend/internal/storage/mongodb"
 	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
 	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
 	"github.com/your-team/taskmanager-chat/backend/internal/websocket"
+	"github.com/your-team/taskmanager-chat/backend/pkg/auth"
+	"github.com/your-team/taskmanager-chat/backend/pkg/captcha"
 	mongodbclient "github.com/your-team/taskmanager-chat/backend/pkg/client-database/mongodb"
 	postgresqlclient "github.com/your-team/taskmanager-chat/backend/pkg/client-database/postgresql"
 	"github.com/your-team/taskmanager-chat/backend/pkg/clinet/postgresql"
@@ -54,22 +58,85 @@ func main() {
 	defer mongoClient.Disconnect(context.TODO())
 
 	messageStorage := mongodbstorage.NewMessageStorage(mongoClient, cfg.MongoConfig.Database)
+	if err := messageStorage.EnsureIndexes(context.TODO()); err != nil {
+		logger.Fatalf("Failed to create MongoDB indexes: %v", err)
+	}
+
+	tokenManager, err := auth.NewManager(auth.Options{
+		KeysDir:     cfg.JWTKeysDir,
+		ActiveKeyID: cfg.JWTActiveKeyID,
+		Issuer:      cfg.JWTIssuer,
+		Audience:    cfg.JWTAudience,
+	})
+	if err != nil {
+		logger.Fatalf("Failed to load JWT signing keys: %v", err)
+	}
+
+	var captchaVerifier service.CaptchaVerifier
+	if cfg.CaptchaSecret != "" {
+		captchaVerifier = captcha.NewVerifier(cfg.CaptchaVerifyURL, cfg.CaptchaSecret)
+	}
+	loginProtection := service.NewLoginProtection(cfg.LoginProtectionConfig, captchaVerifier)
 
-	jwtSecret := "my-secret-key"
-	logger.Infof("secret %s", jwtSecret)
+	wsHub := websocket.NewHub(messageStorage, logger.Logger)
+	go wsHub.Run()
 
-	userService := service.NewUser(storage, storage, jwtSecret)
+	auditService := service.NewAuditService(storage, logger)
+	userService := service.NewUser(storage, storage, tokenManager, loginProtection, auditService, logger)
 	notificationService := service.NewNotificationService(storage, logger)
+	accessTokenService := service.NewAccessTokenService(storage, auditService)
+	profileService := service.NewProfileService(storage, cfg.ProfileConfig)
+	adminService := service.NewAdminService(storage, tokenManager, auditService)
+	accountService := service.NewAccountService(storage, messageStorage, profileService, auditService, cfg.AccountConfig, logger)
+	workspaceService := service.NewWorkspaceService(storage, storage, auditService, cfg.WorkspaceConfig, logger)
+	boardService := service.NewBoardService(storage, storage, auditService, cfg.WorkspaceConfig, logger)
+	automationService := service.NewAutomationService(storage, wsHub, wsHub, logger)
+	wsHub.UseMessageListener(automationService)
+	taskService := service.NewTaskService(storage, wsHub, automationService, cfg.TaskConfig, logger)
+	commentService := service.NewCommentService(storage, storage, wsHub, automationService, logger)
+	fieldService := service.NewFieldService(storage, wsHub, logger)
+	assigneeService := service.NewAssigneeService(storage, storage, wsHub, automationService, logger)
+	checklistService := service.NewChecklistService(storage, wsHub, logger)
+	dependencyService := service.NewDependencyService(storage, wsHub, logger)
+	attachmentService := service.NewAttachmentService(storage, newBlobStore(cfg.AttachmentConfig, logger), wsHub, automationService, cfg.AttachmentConfig, logger)
+	wsHub.UseAttachments(attachmentService)
+	activityService := service.NewActivityService(storage, logger)
+	searchService := service.NewSearchService(storage, messageStorage, logger)
+	viewService := service.NewViewService(storage, logger)
+	recurrenceService := service.NewRecurrenceService(storage, wsHub, logger)
+	templateService := service.NewTemplateService(storage, taskService, wsHub, logger)
 
 	userHandler := rest.NewUsersHandler(userService, logger)
 	notificationHandler := rest.NewNotificationHandler(notificationService, logger)
+	accessTokenHandler := rest.NewAccessTokenHandler(accessTokenService, logger)
+	profileHandler := rest.NewProfileHandler(profileService, logger, cfg.AvatarMaxBytes)
+	accountHandler := rest.NewAccountHandler(accountService, logger)
+	adminHandler := rest.NewAdminHandler(adminService, logger)
+	auditHandler := rest.NewAuditHandler(auditService, logger)
+	workspaceHandler := rest.NewWorkspaceHandler(workspaceService, logger)
+	boardHandler := rest.NewBoardHandler(boardService, logger)
+	taskHandler := rest.NewTaskHandler(taskService, logger)
+	commentHandler := rest.NewCommentHandler(commentService, logger)
+	fieldHandler := rest.NewFieldHandler(fieldService, logger)
+	assigneeHandler := rest.NewAssigneeHandler(assigneeService, logger)
+	checklistHandler := rest.NewChecklistHandler(checklistService, logger)
+	dependencyHandler := rest.NewDependencyHandler(dependencyService, logger)
+	attachmentHandler := rest.NewAttachmentHandler(attachmentService, logger, cfg.AttachmentMaxBytes)
+	activityHandler := rest.NewActivityHandler(activityService, logger)
+	searchHandler := rest.NewSearchHandler(searchService, logger)
+	viewHandler := rest.NewViewHandler(viewService, logger)
+	automationHandler := rest.NewAutomationHandler(automationService, logger)
+	recurrenceHandler := rest.NewRecurrenceHandler(recurrenceService, logger)
+	templateHandler := rest.NewTemplateHandler(templateService, logger)
 
 	go notificationService.StartDeadlineChecker(context.Background())
+	go accountService.StartDeletionWorker(context.Background())
+	go taskService.StartRebalancer(context.Background())
+	go attachmentService.StartCleanup(context.Background())
+	go automationService.Start(context.Background())
+	go recurrenceService.StartScheduler(context.Background())
 
-	wsHub := websocket.NewHub(messageStorage, logger.Logger)
-	go wsHub.Run()
-
-	wsHandler := websocket.NewHandler(wsHub, logger.Logger)
+	wsHandler := websocket.NewHandler(wsHub, boardService, logger.Logger)
 
 	serverCfg := server.Config{
 		Port:         "8888",
@@ -85,22 +152,59 @@ func main() {
 	srv.RegisterRoutes(func(engine *gin.Engine) {
 		api := engine.Group("/api")
 		{
-			auth := api.Group("/auth")
-			userHandler.RegisterRoutes(auth, jwtSecret)
+			authMiddleware := middleware.JWTAuthMiddleware(tokenManager, accessTokenService)
+
+			authGroup := api.Group("/auth")
+			userHandler.RegisterRoutes(authGroup, authMiddleware)
 
 			protected := api.Group("/")
-			protected.Use(middleware.JWTAuthMiddleware(jwtSecret))
+			protected.Use(authMiddleware)
 			{
 				notificationHandler.RegisterRoutes(protected)
+				accessTokenHandler.RegisterRoutes(protected)
+				profileHandler.RegisterRoutes(protected)
+				accountHandler.RegisterRoutes(protected)
+				workspaceHandler.RegisterRoutes(protected)
+				boardHandler.RegisterRoutes(protected)
+				taskHandler.RegisterRoutes(protected)
+				commentHandler.RegisterRoutes(protected)
+				fieldHandler.RegisterRoutes(protected)
+				assigneeHandler.RegisterRoutes(protected)
+				checklistHandler.RegisterRoutes(protected)
+				dependencyHandler.RegisterRoutes(protected)
+				attachmentHandler.RegisterRoutes(protected)
+				activityHandler.RegisterRoutes(protected)
+				searchHandler.RegisterRoutes(protected)
+				viewHandler.RegisterRoutes(protected)
+				automationHandler.RegisterRoutes(protected)
+				recurrenceHandler.RegisterRoutes(protected)
+				templateHandler.RegisterRoutes(protected)
+			}
+
+			attachmentHandler.RegisterPublicRoutes(api)
+
+			admin := api.Group("/admin")
+			admin.Use(middleware.RequireAdmin(cfg.AdminAPIToken, tokenManager, accessTokenService, storage))
+			{
+				userHandler.RegisterAdminRoutes(admin)
+				adminHandler.RegisterRoutes(admin)
+				auditHandler.RegisterRoutes(admin)
 			}
 
 			ws := api.Group("/ws")
-			ws.Use(middleware.JWTAuthMiddleware(jwtSecret))
+			ws.Use(authMiddleware, middleware.RequireScope(domain.ScopeChatWrite))
 			{
 				ws.GET("/chat", wsHandler.HandleWebSocket)
 			}
 		}
 
+		engine.Static(cfg.AvatarURLPath, cfg.AvatarDir)
+
+		engine.GET("/.well-known/jwks.json", func(c *gin.Context) {
+			c.Header("Cache-Control", "public, max-age=300")
+			c.JSON(200, tokenManager.JWKS())
+		})
+
 		engine.GET("/health", func(c *gin.Context) {
 			c.JSON(200, gin.H{
 				"status": "ok",
@@ -114,6 +218,26 @@ func main() {
 	}
 }
 
+// newBlobStore opens the store attachments are kept in.
+func newBlobStore(cfg config.AttachmentConfig, logger *logging.Logger) service.BlobStore {
+	if cfg.AttachmentStore != "s3" {
+		return blob.NewLocalStore(cfg.AttachmentDir)
+	}
+
+	store, err := blob.NewS3Store(blob.S3Config{
+		Endpoint:  cfg.AttachmentS3Endpoint,
+		Region:    cfg.AttachmentS3Region,
+		Bucket:    cfg.AttachmentS3Bucket,
+		AccessKey: cfg.AttachmentS3AccessKey,
+		SecretKey: cfg.AttachmentS3SecretKey,
+		PathStyle: cfg.AttachmentS3PathStyle,
+	})
+	if err != nil {
+		logger.Fatalf("Failed to configure the attachment store: %v", err)
+	}
+	return store
+}
+
 func getDSN(cfg *config.Config) string {
 	return "postgresql://" + cfg.Username + ":" + cfg.Password + "@" + cfg.Host + ":" + cfg.Port + "/" + cfg.Database + "?sslmode=disable&pool_max_conns=20"
 }
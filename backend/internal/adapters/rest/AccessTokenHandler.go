@@ -0,0 +1,118 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type AccessTokenHandler struct {
+	service *service.AccessTokenService
+	logger  *logging.Logger
+}
+
+func NewAccessTokenHandler(service *service.AccessTokenService, logger *logging.Logger) *AccessTokenHandler {
+	return &AccessTokenHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *AccessTokenHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	tokens := rg.Group("/tokens")
+	tokens.Use(middleware.RequireSession())
+	{
+		tokens.GET("", h.List)
+		tokens.POST("", h.Create)
+		tokens.DELETE("", h.RevokeAll)
+		tokens.DELETE("/:id", h.Revoke)
+	}
+}
+
+func (h *AccessTokenHandler) List(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	tokens, err := h.service.List(uid)
+	if err != nil {
+		h.logger.Errorf("Failed to list access tokens for user %d: %v", uid, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list access tokens"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (h *AccessTokenHandler) Create(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req domain.AccessTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	token, err := h.service.Create(uid, req, clientInfo(c))
+	if err != nil {
+		h.logger.Errorf("Failed to create access token for user %d: %v", uid, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+func (h *AccessTokenHandler) Revoke(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid token id"})
+		return
+	}
+
+	if err := h.service.Revoke(uid, id, clientInfo(c)); err != nil {
+		if errors.Is(err, service.ErrAccessTokenNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "access token not found"})
+			return
+		}
+		h.logger.Errorf("Failed to revoke access token %d: %v", id, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke access token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (h *AccessTokenHandler) RevokeAll(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.service.RevokeAll(uid, clientInfo(c)); err != nil {
+		h.logger.Errorf("Failed to revoke access tokens for user %d: %v", uid, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to revoke access tokens"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
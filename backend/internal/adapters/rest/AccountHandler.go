@@ -0,0 +1,113 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type AccountHandler struct {
+	service *service.AccountService
+	logger  *logging.Logger
+}
+
+func NewAccountHandler(service *service.AccountService, logger *logging.Logger) *AccountHandler {
+	return &AccountHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *AccountHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	me := rg.Group("/users/me")
+	me.Use(middleware.RequireSession())
+	{
+		me.GET("/export", h.Export)
+		me.DELETE("", h.Delete)
+		me.DELETE("/deletion", h.CancelDeletion)
+	}
+}
+
+func (h *AccountHandler) Export(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	export, err := h.service.Export(c.Request.Context(), uid, clientInfo(c))
+	if err != nil {
+		h.logger.Errorf("Failed to export data of user %d: %v", uid, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to export account data"})
+		return
+	}
+
+	if c.Query("format") == "json" {
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="account-%d.json"`, uid))
+		c.JSON(http.StatusOK, export)
+		return
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="account-%d.zip"`, uid))
+	c.Status(http.StatusOK)
+	if err := h.service.WriteExportZip(c.Writer, export); err != nil {
+		h.logger.Errorf("Failed to write export archive for user %d: %v", uid, err)
+	}
+}
+
+func (h *AccountHandler) Delete(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req domain.AccountDeletionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	deletion, err := h.service.RequestDeletion(uid, req.Password, clientInfo(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidPassword):
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrUserNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			h.logger.Errorf("Failed to schedule deletion of user %d: %v", uid, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to delete account"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusAccepted, deletion)
+}
+
+func (h *AccountHandler) CancelDeletion(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.service.CancelDeletion(uid, clientInfo(c)); err != nil {
+		if errors.Is(err, service.ErrNoDeletionScheduled) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		h.logger.Errorf("Failed to cancel deletion of user %d: %v", uid, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to cancel account deletion"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
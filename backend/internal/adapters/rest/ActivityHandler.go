@@ -0,0 +1,75 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type ActivityHandler struct {
+	service *service.ActivityService
+	logger  *logging.Logger
+}
+
+func NewActivityHandler(service *service.ActivityService, logger *logging.Logger) *ActivityHandler {
+	return &ActivityHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *ActivityHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+
+	rg.GET("/tasks/:id/activity", read, h.List)
+}
+
+// List returns the task's timeline newest first, paged by before_id and
+// limit.
+func (h *ActivityHandler) List(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	var beforeID int64
+	if value := c.Query("before_id"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid before_id"})
+			return
+		}
+		beforeID = parsed
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.service.List(uid, taskID, beforeID, limit)
+	if err != nil {
+		h.respondError(c, taskID, "list activity of task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *ActivityHandler) respondError(c *gin.Context, id int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrTaskNotFound),
+		errors.Is(err, service.ErrBoardNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,209 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+type AdminHandler struct {
+	service *service.AdminService
+	logger  *logging.Logger
+}
+
+func NewAdminHandler(service *service.AdminService, logger *logging.Logger) *AdminHandler {
+	return &AdminHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *AdminHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	users := rg.Group("/users")
+	{
+		users.GET("", h.ListUsers)
+		users.GET("/:id", h.GetUser)
+		users.POST("/:id/lock", h.Lock)
+		users.POST("/:id/unlock", h.Unlock)
+		users.POST("/:id/force-password-reset", h.ForcePasswordReset)
+		users.POST("/:id/disable-2fa", h.DisableTwoFA)
+		users.PUT("/:id/role", h.SetRole)
+		users.POST("/:id/impersonate", h.Impersonate)
+		users.GET("/:id/login-attempts", h.LoginAttempts)
+	}
+}
+
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	offset, _ := strconv.Atoi(c.Query("offset"))
+
+	users, err := h.service.ListUsers(c.Query("q"), limit, offset)
+	if err != nil {
+		h.logger.Errorf("Failed to list users: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list users"})
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.service.GetUser(id)
+	if err != nil {
+		h.respondError(c, id, "load user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *AdminHandler) Lock(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req domain.LockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.service.Lock(adminActor(c), id, req.Minutes); err != nil {
+		h.respondError(c, id, "lock user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (h *AdminHandler) Unlock(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Unlock(adminActor(c), id); err != nil {
+		h.respondError(c, id, "unlock user", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (h *AdminHandler) ForcePasswordReset(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.ForcePasswordReset(adminActor(c), id); err != nil {
+		h.respondError(c, id, "force password reset", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (h *AdminHandler) DisableTwoFA(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DisableTwoFA(adminActor(c), id); err != nil {
+		h.respondError(c, id, "disable 2FA", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (h *AdminHandler) SetRole(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req domain.RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.service.SetRole(adminActor(c), id, req.Role); err != nil {
+		h.respondError(c, id, "change role", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (h *AdminHandler) Impersonate(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ImpersonationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	token, err := h.service.Impersonate(adminActor(c), id, req.Reason)
+	if err != nil {
+		h.respondError(c, id, "impersonate user", err)
+		return
+	}
+
+	h.logger.Infof("Admin %d impersonates user %d", c.GetInt64("userID"), id)
+	c.JSON(http.StatusOK, token)
+}
+
+func (h *AdminHandler) LoginAttempts(c *gin.Context) {
+	id, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	attempts, err := h.service.LoginAttempts(id, limit)
+	if err != nil {
+		h.respondError(c, id, "list login attempts", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attempts)
+}
+
+func (h *AdminHandler) respondError(c *gin.Context, userID int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrImpersonationNotAllowed), errors.Is(err, service.ErrOperatorCannotImpersonate):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, userID, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+func userIDParam(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,189 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type AssigneeHandler struct {
+	service *service.AssigneeService
+	logger  *logging.Logger
+}
+
+func NewAssigneeHandler(service *service.AssigneeService, logger *logging.Logger) *AssigneeHandler {
+	return &AssigneeHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *AssigneeHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+	write := middleware.RequireScope(domain.ScopeTasksWrite)
+
+	rg.GET("/tasks/:id/assignees", read, h.Assignees)
+	rg.PUT("/tasks/:id/assignees/:userID", write, h.Assign)
+	rg.DELETE("/tasks/:id/assignees/:userID", write, h.Unassign)
+
+	rg.GET("/tasks/:id/watchers", read, h.Watchers)
+	rg.PUT("/tasks/:id/watchers/:userID", write, h.Watch)
+	rg.DELETE("/tasks/:id/watchers/:userID", write, h.Unwatch)
+}
+
+func (h *AssigneeHandler) Assignees(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	assignees, err := h.service.Assignees(uid, taskID)
+	if err != nil {
+		h.respondError(c, taskID, "list assignees of task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assignees)
+}
+
+func (h *AssigneeHandler) Assign(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+	assigneeID, ok := idParam(c, "userID", "user")
+	if !ok {
+		return
+	}
+
+	assignees, err := h.service.Assign(uid, taskID, assigneeID)
+	if err != nil {
+		h.respondError(c, taskID, "assign user to task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assignees)
+}
+
+func (h *AssigneeHandler) Unassign(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+	assigneeID, ok := idParam(c, "userID", "user")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Unassign(uid, taskID, assigneeID); err != nil {
+		h.respondError(c, taskID, "unassign user from task", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AssigneeHandler) Watchers(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	watchers, err := h.service.Watchers(uid, taskID)
+	if err != nil {
+		h.respondError(c, taskID, "list watchers of task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, watchers)
+}
+
+func (h *AssigneeHandler) Watch(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+	watcherID, ok := idParam(c, "userID", "user")
+	if !ok {
+		return
+	}
+
+	watchers, err := h.service.Watch(uid, taskID, watcherID)
+	if err != nil {
+		h.respondError(c, taskID, "add watcher to task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, watchers)
+}
+
+func (h *AssigneeHandler) Unwatch(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+	watcherID, ok := idParam(c, "userID", "user")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Unwatch(uid, taskID, watcherID); err != nil {
+		h.respondError(c, taskID, "remove watcher from task", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AssigneeHandler) respondError(c *gin.Context, id int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrTaskNotFound),
+		errors.Is(err, service.ErrBoardNotFound),
+		errors.Is(err, service.ErrAssigneeNotFound),
+		errors.Is(err, service.ErrWatcherNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrBoardForbidden),
+		errors.Is(err, service.ErrWatcherForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNotBoardMember):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
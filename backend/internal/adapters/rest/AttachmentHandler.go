@@ -0,0 +1,239 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type AttachmentHandler struct {
+	service  *service.AttachmentService
+	logger   *logging.Logger
+	maxBytes int64
+}
+
+func NewAttachmentHandler(service *service.AttachmentService, logger *logging.Logger, maxBytes int64) *AttachmentHandler {
+	return &AttachmentHandler{
+		service:  service,
+		logger:   logger,
+		maxBytes: maxBytes,
+	}
+}
+
+func (h *AttachmentHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+	write := middleware.RequireScope(domain.ScopeTasksWrite)
+	chat := middleware.RequireScope(domain.ScopeChatWrite)
+
+	rg.GET("/tasks/:id/attachments", read, h.ListTask)
+	rg.POST("/tasks/:id/attachments", write, h.UploadToTask)
+	rg.POST("/boards/:id/attachments", chat, h.UploadToBoard)
+	rg.GET("/attachments/:id", read, h.Get)
+	rg.DELETE("/attachments/:id", write, h.Delete)
+}
+
+// RegisterPublicRoutes serves downloads, which are authorized by the signed
+// link instead of a token.
+func (h *AttachmentHandler) RegisterPublicRoutes(rg *gin.RouterGroup) {
+	rg.GET("/attachments/:id/content", h.Download)
+	rg.GET("/attachments/:id/thumbnail", h.Thumbnail)
+}
+
+func (h *AttachmentHandler) ListTask(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	attachments, err := h.service.ListTask(uid, taskID)
+	if err != nil {
+		h.respondError(c, taskID, "list attachments of task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+func (h *AttachmentHandler) UploadToTask(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	h.upload(c, taskID, "attach file to task", func(filename string, r io.Reader) (domain.Attachment, error) {
+		return h.service.UploadToTask(uid, taskID, filename, r)
+	})
+}
+
+func (h *AttachmentHandler) UploadToBoard(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	h.upload(c, boardID, "upload file to board", func(filename string, r io.Reader) (domain.Attachment, error) {
+		return h.service.UploadToBoard(uid, boardID, filename, r)
+	})
+}
+
+func (h *AttachmentHandler) Get(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	attachmentID, ok := idParam(c, "id", "attachment")
+	if !ok {
+		return
+	}
+
+	attachment, err := h.service.Get(uid, attachmentID)
+	if err != nil {
+		h.respondError(c, attachmentID, "load attachment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attachment)
+}
+
+func (h *AttachmentHandler) Delete(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	attachmentID, ok := idParam(c, "id", "attachment")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(uid, attachmentID); err != nil {
+		h.respondError(c, attachmentID, "delete attachment", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AttachmentHandler) Download(c *gin.Context) {
+	h.serve(c, service.AttachmentContent)
+}
+
+func (h *AttachmentHandler) Thumbnail(c *gin.Context) {
+	h.serve(c, service.AttachmentThumbnail)
+}
+
+// upload reads the multipart "file" field and hands it to store.
+func (h *AttachmentHandler) upload(c *gin.Context, id int64, action string, store func(filename string, r io.Reader) (domain.Attachment, error)) {
+	// Leave room for the multipart envelope around the file itself.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBytes+64*1024)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": service.ErrAttachmentTooBig.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		h.logger.Errorf("Failed to open uploaded file for %d: %v", id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read file"})
+		return
+	}
+	defer src.Close()
+
+	attachment, err := store(file.Filename, src)
+	if err != nil {
+		h.respondError(c, id, action, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// serve streams a variant of the attachment behind a signed link. Contents
+// are always offered as downloads, so an uploaded HTML or SVG file never runs
+// in the app's origin; thumbnails are our own PNGs and shown inline.
+func (h *AttachmentHandler) serve(c *gin.Context, variant string) {
+	attachmentID, ok := idParam(c, "id", "attachment")
+	if !ok {
+		return
+	}
+	expires, err := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": service.ErrAttachmentLinkInvalid.Error()})
+		return
+	}
+
+	attachment, content, err := h.service.Open(attachmentID, variant, expires, c.Query("signature"))
+	if err != nil {
+		h.respondError(c, attachmentID, "download attachment", err)
+		return
+	}
+	defer content.Close()
+
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, max-age=300")
+	if variant == service.AttachmentThumbnail {
+		c.DataFromReader(http.StatusOK, -1, "image/png", content, nil)
+		return
+	}
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition": disposition,
+	})
+}
+
+func (h *AttachmentHandler) respondError(c *gin.Context, id int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrTaskNotFound),
+		errors.Is(err, service.ErrBoardNotFound),
+		errors.Is(err, service.ErrAttachmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrBoardForbidden),
+		errors.Is(err, service.ErrAttachmentForbidden),
+		errors.Is(err, service.ErrAttachmentLinkInvalid):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrAttachmentTooBig):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrAttachmentType):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrAttachmentEmpty):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
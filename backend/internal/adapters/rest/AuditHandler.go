@@ -0,0 +1,124 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+type AuditHandler struct {
+	service *service.AuditService
+	logger  *logging.Logger
+}
+
+func NewAuditHandler(service *service.AuditService, logger *logging.Logger) *AuditHandler {
+	return &AuditHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *AuditHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/audit-events", h.List)
+	rg.GET("/audit-events/export", h.Export)
+}
+
+func (h *AuditHandler) List(c *gin.Context) {
+	filter, err := auditFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.service.List(filter)
+	if err != nil {
+		h.logger.Errorf("Failed to list audit events: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list audit events"})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// Export streams the matching events as JSON Lines. limit is ignored: the
+// export always covers the whole filtered range.
+func (h *AuditHandler) Export(c *gin.Context) {
+	filter, err := auditFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="audit-events-%s.jsonl"`, time.Now().UTC().Format("20060102T150405Z")))
+	c.Status(http.StatusOK)
+	if err := h.service.Export(c.Writer, filter); err != nil {
+		h.logger.Errorf("Failed to export audit events: %v", err)
+	}
+}
+
+// auditFilter reads the filter from the query string: type (comma separated
+// or repeated), actor_id, target_user_id, ip, since and until (RFC 3339),
+// before_id and limit.
+func auditFilter(c *gin.Context) (domain.AuditFilter, error) {
+	var filter domain.AuditFilter
+
+	for _, value := range c.QueryArray("type") {
+		for _, t := range strings.Split(value, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				filter.Types = append(filter.Types, t)
+			}
+		}
+	}
+
+	ids := []struct {
+		name string
+		dst  *int64
+	}{
+		{"actor_id", &filter.ActorID},
+		{"target_user_id", &filter.TargetUserID},
+		{"before_id", &filter.BeforeID},
+	}
+	for _, id := range ids {
+		value := c.Query(id.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed <= 0 {
+			return domain.AuditFilter{}, fmt.Errorf("invalid %s", id.name)
+		}
+		*id.dst = parsed
+	}
+
+	times := []struct {
+		name string
+		dst  *time.Time
+	}{
+		{"since", &filter.Since},
+		{"until", &filter.Until},
+	}
+	for _, t := range times {
+		value := c.Query(t.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return domain.AuditFilter{}, fmt.Errorf("invalid %s, expected RFC 3339", t.name)
+		}
+		*t.dst = parsed
+	}
+
+	filter.IP = strings.TrimSpace(c.Query("ip"))
+	filter.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	return filter, nil
+}
@@ -0,0 +1,194 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type AutomationHandler struct {
+	service *service.AutomationService
+	logger  *logging.Logger
+}
+
+func NewAutomationHandler(service *service.AutomationService, logger *logging.Logger) *AutomationHandler {
+	return &AutomationHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *AutomationHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+	write := middleware.RequireScope(domain.ScopeTasksWrite)
+
+	rg.GET("/boards/:id/automations", read, h.List)
+	rg.POST("/boards/:id/automations", write, h.Create)
+	rg.GET("/automations/:id", read, h.Get)
+	rg.PATCH("/automations/:id", write, h.Update)
+	rg.DELETE("/automations/:id", write, h.Delete)
+	rg.GET("/automations/:id/executions", read, h.Executions)
+}
+
+func (h *AutomationHandler) List(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	rules, err := h.service.List(uid, boardID)
+	if err != nil {
+		h.respondError(c, boardID, "list automation rules of board", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rules)
+}
+
+func (h *AutomationHandler) Create(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	var req domain.AutomationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	rule, err := h.service.Create(uid, boardID, req)
+	if err != nil {
+		h.respondError(c, boardID, "create automation rule on board", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (h *AutomationHandler) Get(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	ruleID, ok := idParam(c, "id", "automation rule")
+	if !ok {
+		return
+	}
+
+	rule, err := h.service.Get(uid, ruleID)
+	if err != nil {
+		h.respondError(c, ruleID, "get automation rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *AutomationHandler) Update(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	ruleID, ok := idParam(c, "id", "automation rule")
+	if !ok {
+		return
+	}
+
+	var req domain.AutomationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	rule, err := h.service.Update(uid, ruleID, req)
+	if err != nil {
+		h.respondError(c, ruleID, "update automation rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *AutomationHandler) Delete(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	ruleID, ok := idParam(c, "id", "automation rule")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(uid, ruleID); err != nil {
+		h.respondError(c, ruleID, "delete automation rule", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Executions returns the rule's log newest first, paged by before_id and
+// limit.
+func (h *AutomationHandler) Executions(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	ruleID, ok := idParam(c, "id", "automation rule")
+	if !ok {
+		return
+	}
+
+	var beforeID int64
+	if value := c.Query("before_id"); value != "" {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid before_id"})
+			return
+		}
+		beforeID = parsed
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.service.Executions(uid, ruleID, beforeID, limit)
+	if err != nil {
+		h.respondError(c, ruleID, "list executions of automation rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+func (h *AutomationHandler) respondError(c *gin.Context, id int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrBoardNotFound),
+		errors.Is(err, service.ErrAutomationRuleNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrBoardForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,263 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type BoardHandler struct {
+	service *service.BoardService
+	logger  *logging.Logger
+}
+
+func NewBoardHandler(service *service.BoardService, logger *logging.Logger) *BoardHandler {
+	return &BoardHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *BoardHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+	rg.GET("/workspaces/:id/boards", read, h.List)
+	rg.GET("/boards/:id", read, h.Get)
+
+	rg.POST("/workspaces/:id/boards", middleware.RequireSession(), h.Create)
+	rg.PATCH("/boards/:id", middleware.RequireSession(), h.Update)
+	rg.DELETE("/boards/:id", middleware.RequireSession(), h.Delete)
+
+	rg.GET("/boards/:id/invites", middleware.RequireSession(), h.Invites)
+	rg.POST("/boards/:id/invites", middleware.RequireSession(), h.CreateInvite)
+	rg.DELETE("/boards/:id/invites/:inviteID", middleware.RequireSession(), h.RevokeInvite)
+	rg.POST("/invites/:token/accept", middleware.RequireSession(), h.AcceptInvite)
+}
+
+func (h *BoardHandler) Create(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	workspaceID, ok := idParam(c, "id", "workspace")
+	if !ok {
+		return
+	}
+
+	var req domain.BoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	board, err := h.service.Create(uid, workspaceID, req)
+	if err != nil {
+		h.respondError(c, workspaceID, "create board in workspace", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, board)
+}
+
+func (h *BoardHandler) List(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	workspaceID, ok := idParam(c, "id", "workspace")
+	if !ok {
+		return
+	}
+
+	boards, err := h.service.List(uid, workspaceID)
+	if err != nil {
+		h.respondError(c, workspaceID, "list boards of workspace", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, boards)
+}
+
+func (h *BoardHandler) Get(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	board, err := h.service.Get(uid, boardID)
+	if err != nil {
+		h.respondError(c, boardID, "load board", err)
+		return
+	}
+
+	setETag(c, board.Version)
+	c.JSON(http.StatusOK, board)
+}
+
+func (h *BoardHandler) Update(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	var req domain.BoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	board, err := h.service.Update(uid, boardID, version, req)
+	if err != nil {
+		h.respondError(c, boardID, "update board", err)
+		return
+	}
+
+	setETag(c, board.Version)
+	c.JSON(http.StatusOK, board)
+}
+
+func (h *BoardHandler) Delete(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(uid, boardID); err != nil {
+		h.respondError(c, boardID, "delete board", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *BoardHandler) CreateInvite(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	var req domain.BoardInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	invite, err := h.service.CreateInvite(uid, boardID, req)
+	if err != nil {
+		h.respondError(c, boardID, "create invite for board", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invite)
+}
+
+func (h *BoardHandler) Invites(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	invites, err := h.service.Invites(uid, boardID)
+	if err != nil {
+		h.respondError(c, boardID, "list invites of board", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invites)
+}
+
+func (h *BoardHandler) RevokeInvite(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+	inviteID, ok := idParam(c, "inviteID", "invite")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeInvite(uid, boardID, inviteID); err != nil {
+		h.respondError(c, boardID, "revoke invite of board", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *BoardHandler) AcceptInvite(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	board, err := h.service.AcceptInvite(uid, c.Param("token"), clientInfo(c))
+	if err != nil {
+		h.respondError(c, 0, "accept board invite", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+func (h *BoardHandler) respondError(c *gin.Context, id int64, action string, err error) {
+	var conflict *service.VersionConflictError
+	switch {
+	case errors.As(err, &conflict):
+		respondConflict(c, conflict)
+	case errors.Is(err, service.ErrBoardNotFound),
+		errors.Is(err, service.ErrWorkspaceNotFound),
+		errors.Is(err, service.ErrBoardInviteNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrBoardForbidden),
+		errors.Is(err, service.ErrWorkspaceForbidden),
+		errors.Is(err, service.ErrBoardInviteForeignTo):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrBoardInviteInvalid):
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
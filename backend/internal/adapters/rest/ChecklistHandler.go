@@ -0,0 +1,170 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type ChecklistHandler struct {
+	service *service.ChecklistService
+	logger  *logging.Logger
+}
+
+func NewChecklistHandler(service *service.ChecklistService, logger *logging.Logger) *ChecklistHandler {
+	return &ChecklistHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *ChecklistHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+	write := middleware.RequireScope(domain.ScopeTasksWrite)
+
+	rg.GET("/tasks/:id/checklist", read, h.List)
+	rg.POST("/tasks/:id/checklist", write, h.Create)
+	rg.PATCH("/checklist-items/:id", write, h.Update)
+	rg.DELETE("/checklist-items/:id", write, h.Delete)
+	rg.POST("/checklist-items/:id/move", write, h.Move)
+}
+
+func (h *ChecklistHandler) List(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	items, err := h.service.List(uid, taskID)
+	if err != nil {
+		h.respondError(c, taskID, "list checklist of task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+func (h *ChecklistHandler) Create(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	var req domain.ChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	item, err := h.service.Create(uid, taskID, req)
+	if err != nil {
+		h.respondError(c, taskID, "add checklist item to task", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+func (h *ChecklistHandler) Update(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	itemID, ok := idParam(c, "id", "checklist item")
+	if !ok {
+		return
+	}
+
+	var req domain.ChecklistItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	item, err := h.service.Update(uid, itemID, req)
+	if err != nil {
+		h.respondError(c, itemID, "update checklist item", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func (h *ChecklistHandler) Delete(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	itemID, ok := idParam(c, "id", "checklist item")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(uid, itemID); err != nil {
+		h.respondError(c, itemID, "delete checklist item", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ChecklistHandler) Move(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	itemID, ok := idParam(c, "id", "checklist item")
+	if !ok {
+		return
+	}
+
+	var req domain.ChecklistMoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	item, err := h.service.Move(uid, itemID, req)
+	if err != nil {
+		h.respondError(c, itemID, "move checklist item", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+func (h *ChecklistHandler) respondError(c *gin.Context, id int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrTaskNotFound),
+		errors.Is(err, service.ErrBoardNotFound),
+		errors.Is(err, service.ErrChecklistItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrBoardForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrChecklistMoveConflict):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrNotBoardMember):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
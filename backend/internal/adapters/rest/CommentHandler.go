@@ -0,0 +1,162 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type CommentHandler struct {
+	service *service.CommentService
+	logger  *logging.Logger
+}
+
+func NewCommentHandler(service *service.CommentService, logger *logging.Logger) *CommentHandler {
+	return &CommentHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *CommentHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+	write := middleware.RequireScope(domain.ScopeTasksWrite)
+
+	rg.GET("/tasks/:id/comments", read, h.List)
+	rg.POST("/tasks/:id/comments", write, h.Create)
+
+	rg.PATCH("/comments/:id", write, h.Update)
+	rg.DELETE("/comments/:id", write, h.Delete)
+	rg.GET("/comments/:id/history", read, h.History)
+}
+
+func (h *CommentHandler) List(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	comments, err := h.service.List(uid, taskID)
+	if err != nil {
+		h.respondError(c, taskID, "list comments of task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+func (h *CommentHandler) Create(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	var req domain.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	comment, err := h.service.Create(uid, taskID, req)
+	if err != nil {
+		h.respondError(c, taskID, "comment on task", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, comment)
+}
+
+func (h *CommentHandler) Update(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	commentID, ok := idParam(c, "id", "comment")
+	if !ok {
+		return
+	}
+
+	var req domain.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	comment, err := h.service.Update(uid, commentID, req)
+	if err != nil {
+		h.respondError(c, commentID, "update comment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, comment)
+}
+
+func (h *CommentHandler) Delete(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	commentID, ok := idParam(c, "id", "comment")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(uid, commentID); err != nil {
+		h.respondError(c, commentID, "delete comment", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CommentHandler) History(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	commentID, ok := idParam(c, "id", "comment")
+	if !ok {
+		return
+	}
+
+	revisions, err := h.service.History(uid, commentID)
+	if err != nil {
+		h.respondError(c, commentID, "load history of comment", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, revisions)
+}
+
+func (h *CommentHandler) respondError(c *gin.Context, id int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrCommentNotFound),
+		errors.Is(err, service.ErrTaskNotFound),
+		errors.Is(err, service.ErrBoardNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrCommentForbidden),
+		errors.Is(err, service.ErrBoardForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
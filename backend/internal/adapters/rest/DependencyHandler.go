@@ -0,0 +1,118 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type DependencyHandler struct {
+	service *service.DependencyService
+	logger  *logging.Logger
+}
+
+func NewDependencyHandler(service *service.DependencyService, logger *logging.Logger) *DependencyHandler {
+	return &DependencyHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *DependencyHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+	write := middleware.RequireScope(domain.ScopeTasksWrite)
+
+	rg.GET("/tasks/:id/dependencies", read, h.Dependencies)
+	rg.PUT("/tasks/:id/blockers/:blockerID", write, h.AddBlocker)
+	rg.DELETE("/tasks/:id/blockers/:blockerID", write, h.RemoveBlocker)
+}
+
+func (h *DependencyHandler) Dependencies(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	dependencies, err := h.service.Dependencies(uid, taskID)
+	if err != nil {
+		h.respondError(c, taskID, "list dependencies of task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dependencies)
+}
+
+func (h *DependencyHandler) AddBlocker(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+	blockerID, ok := idParam(c, "blockerID", "task")
+	if !ok {
+		return
+	}
+
+	dependencies, err := h.service.AddBlocker(uid, taskID, blockerID)
+	if err != nil {
+		h.respondError(c, taskID, "add blocker to task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dependencies)
+}
+
+func (h *DependencyHandler) RemoveBlocker(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+	blockerID, ok := idParam(c, "blockerID", "task")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveBlocker(uid, taskID, blockerID); err != nil {
+		h.respondError(c, taskID, "remove blocker from task", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *DependencyHandler) respondError(c *gin.Context, id int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrTaskNotFound),
+		errors.Is(err, service.ErrBoardNotFound),
+		errors.Is(err, service.ErrDependencyNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrBoardForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDependencyCycle):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvalidBlocker):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
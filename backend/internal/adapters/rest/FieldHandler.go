@@ -0,0 +1,238 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type FieldHandler struct {
+	service *service.FieldService
+	logger  *logging.Logger
+}
+
+func NewFieldHandler(service *service.FieldService, logger *logging.Logger) *FieldHandler {
+	return &FieldHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *FieldHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+	write := middleware.RequireScope(domain.ScopeTasksWrite)
+
+	rg.GET("/boards/:id/labels", read, h.Labels)
+	rg.POST("/boards/:id/labels", write, h.CreateLabel)
+	rg.PATCH("/labels/:id", write, h.UpdateLabel)
+	rg.DELETE("/labels/:id", write, h.DeleteLabel)
+
+	rg.GET("/boards/:id/fields", read, h.Fields)
+	rg.POST("/boards/:id/fields", write, h.CreateField)
+	rg.PATCH("/fields/:id", write, h.UpdateField)
+	rg.DELETE("/fields/:id", write, h.DeleteField)
+}
+
+func (h *FieldHandler) Labels(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	labels, err := h.service.Labels(uid, boardID)
+	if err != nil {
+		h.respondError(c, boardID, "list labels of board", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, labels)
+}
+
+func (h *FieldHandler) CreateLabel(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	var req domain.LabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	label, err := h.service.CreateLabel(uid, boardID, req)
+	if err != nil {
+		h.respondError(c, boardID, "create label on board", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, label)
+}
+
+func (h *FieldHandler) UpdateLabel(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	labelID, ok := idParam(c, "id", "label")
+	if !ok {
+		return
+	}
+
+	var req domain.LabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	label, err := h.service.UpdateLabel(uid, labelID, req)
+	if err != nil {
+		h.respondError(c, labelID, "update label", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, label)
+}
+
+func (h *FieldHandler) DeleteLabel(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	labelID, ok := idParam(c, "id", "label")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteLabel(uid, labelID); err != nil {
+		h.respondError(c, labelID, "delete label", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *FieldHandler) Fields(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	fields, err := h.service.Fields(uid, boardID)
+	if err != nil {
+		h.respondError(c, boardID, "list custom fields of board", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, fields)
+}
+
+func (h *FieldHandler) CreateField(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	var req domain.CustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	field, err := h.service.CreateField(uid, boardID, req)
+	if err != nil {
+		h.respondError(c, boardID, "create custom field on board", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, field)
+}
+
+func (h *FieldHandler) UpdateField(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	fieldID, ok := idParam(c, "id", "field")
+	if !ok {
+		return
+	}
+
+	var req domain.CustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	field, err := h.service.UpdateField(uid, fieldID, req)
+	if err != nil {
+		h.respondError(c, fieldID, "update custom field", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, field)
+}
+
+func (h *FieldHandler) DeleteField(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	fieldID, ok := idParam(c, "id", "field")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteField(uid, fieldID); err != nil {
+		h.respondError(c, fieldID, "delete custom field", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *FieldHandler) respondError(c *gin.Context, id int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrLabelNotFound),
+		errors.Is(err, service.ErrFieldNotFound),
+		errors.Is(err, service.ErrBoardNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrBoardForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrLabelExists),
+		errors.Is(err, service.ErrFieldExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
 	"github.com/your-team/taskmanager-chat/backend/internal/service"
 	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
 )
 
 type NotificationHandler struct {
@@ -22,9 +24,10 @@ func NewNotificationHandler(service *service.NotificationService, logger *loggin
 }
 
 func (h *NotificationHandler) RegisterRoutes(rg *gin.RouterGroup) {
-	rg.GET("/notifications", h.GetNotifications)
-	rg.POST("/notifications/:id/read", h.MarkAsRead)
-	rg.GET("/notifications/unread-count", h.GetUnreadCount)
+	read := middleware.RequireScope(domain.ScopeNotificationsRead)
+	rg.GET("/notifications", read, h.GetNotifications)
+	rg.POST("/notifications/:id/read", read, h.MarkAsRead)
+	rg.GET("/notifications/unread-count", read, h.GetUnreadCount)
 }
 
 func (h *NotificationHandler) GetNotifications(c *gin.Context) {
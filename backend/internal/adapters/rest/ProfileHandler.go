@@ -0,0 +1,188 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type ProfileHandler struct {
+	service        *service.ProfileService
+	logger         *logging.Logger
+	avatarMaxBytes int64
+}
+
+func NewProfileHandler(service *service.ProfileService, logger *logging.Logger, avatarMaxBytes int64) *ProfileHandler {
+	return &ProfileHandler{
+		service:        service,
+		logger:         logger,
+		avatarMaxBytes: avatarMaxBytes,
+	}
+}
+
+func (h *ProfileHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeUsersRead)
+	users := rg.Group("/users")
+	{
+		users.GET("/me", read, h.GetMe)
+		users.PATCH("/me", middleware.RequireSession(), h.UpdateMe)
+		users.PUT("/me/avatar", middleware.RequireSession(), h.UploadAvatar)
+		users.DELETE("/me/avatar", middleware.RequireSession(), h.DeleteAvatar)
+		users.GET("/search", read, h.Search)
+		users.GET("/:id", read, h.Get)
+	}
+}
+
+func (h *ProfileHandler) GetMe(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	profile, err := h.service.Get(uid)
+	if err != nil {
+		h.respondError(c, uid, "load profile", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *ProfileHandler) UpdateMe(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req domain.ProfileUpdate
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	profile, err := h.service.Update(uid, req)
+	if err != nil {
+		h.respondError(c, uid, "update profile", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *ProfileHandler) UploadAvatar(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	// Leave room for the multipart envelope around the file itself.
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.avatarMaxBytes+64*1024)
+
+	file, err := c.FormFile("avatar")
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": service.ErrAvatarTooBig.Error()})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "avatar file is required"})
+		return
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		h.logger.Errorf("Failed to open uploaded avatar for user %d: %v", uid, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read avatar"})
+		return
+	}
+	defer src.Close()
+
+	profile, err := h.service.UploadAvatar(uid, src)
+	if err != nil {
+		h.respondError(c, uid, "upload avatar", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+func (h *ProfileHandler) DeleteAvatar(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	if err := h.service.DeleteAvatar(uid); err != nil {
+		h.respondError(c, uid, "delete avatar", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+func (h *ProfileHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	profile, err := h.service.GetPublic(id)
+	if err != nil {
+		h.respondError(c, id, "load public profile", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// Search looks up members of the workspace given by workspace_id.
+func (h *ProfileHandler) Search(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	workspaceID, err := strconv.ParseInt(c.Query("workspace_id"), 10, 64)
+	if err != nil || workspaceID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "workspace_id is required"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	users, err := h.service.Search(uid, workspaceID, c.Query("q"), limit)
+	if err != nil {
+		h.logger.Errorf("Failed to search users in workspace %d: %v", workspaceID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to search users"})
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+func (h *ProfileHandler) respondError(c *gin.Context, userID int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrUsernameTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrAvatarTooBig):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrAvatarInvalid):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s for user %d: %v", action, userID, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
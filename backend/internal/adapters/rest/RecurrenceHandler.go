@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type RecurrenceHandler struct {
+	service *service.RecurrenceService
+	logger  *logging.Logger
+}
+
+func NewRecurrenceHandler(service *service.RecurrenceService, logger *logging.Logger) *RecurrenceHandler {
+	return &RecurrenceHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *RecurrenceHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+	write := middleware.RequireScope(domain.ScopeTasksWrite)
+
+	rg.GET("/tasks/:id/recurrence", read, h.Get)
+	rg.PUT("/tasks/:id/recurrence", write, h.Set)
+	rg.DELETE("/tasks/:id/recurrence", write, h.Delete)
+}
+
+func (h *RecurrenceHandler) Get(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	recurrence, err := h.service.Get(uid, taskID)
+	if err != nil {
+		h.respondError(c, taskID, "get recurrence of task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurrence)
+}
+
+func (h *RecurrenceHandler) Set(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	var req domain.TaskRecurrenceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	recurrence, err := h.service.Set(uid, taskID, req)
+	if err != nil {
+		h.respondError(c, taskID, "set recurrence of task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recurrence)
+}
+
+func (h *RecurrenceHandler) Delete(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(uid, taskID); err != nil {
+		h.respondError(c, taskID, "delete recurrence of task", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *RecurrenceHandler) respondError(c *gin.Context, id int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrTaskNotFound),
+		errors.Is(err, service.ErrRecurrenceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrBoardForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,69 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type SearchHandler struct {
+	service *service.SearchService
+	logger  *logging.Logger
+}
+
+func NewSearchHandler(service *service.SearchService, logger *logging.Logger) *SearchHandler {
+	return &SearchHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *SearchHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+
+	rg.GET("/search", read, h.Search)
+}
+
+// Search takes the query in q, in web search syntax ("quoted phrases",
+// -excluded words), an optional board_id and limit.
+func (h *SearchHandler) Search(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	req := domain.SearchRequest{Query: c.Query("q")}
+	if value := c.Query("board_id"); value != "" {
+		boardID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || boardID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid board_id"})
+			return
+		}
+		req.BoardID = boardID
+	}
+	req.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	results, err := h.service.Search(c.Request.Context(), uid, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSearchQueryRequired),
+			errors.Is(err, service.ErrSearchQueryTooLong):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrBoardNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			h.logger.Errorf("Failed to search for user %d: %v", uid, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "search failed"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,456 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type TaskHandler struct {
+	service *service.TaskService
+	logger  *logging.Logger
+}
+
+func NewTaskHandler(service *service.TaskService, logger *logging.Logger) *TaskHandler {
+	return &TaskHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *TaskHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+	write := middleware.RequireScope(domain.ScopeTasksWrite)
+
+	rg.GET("/boards/:id/tasks", read, h.Board)
+	rg.POST("/boards/:id/columns", write, h.CreateColumn)
+	rg.PATCH("/columns/:id", write, h.UpdateColumn)
+
+	rg.POST("/tasks", write, h.Create)
+	rg.GET("/tasks/:id", read, h.Get)
+	rg.PATCH("/tasks/:id", write, h.Update)
+	rg.DELETE("/tasks/:id", write, h.Delete)
+	rg.POST("/tasks/:id/move", write, h.Move)
+	rg.GET("/tasks/:id/subtasks", read, h.Subtasks)
+}
+
+func (h *TaskHandler) Board(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	viewID, query, ok := boardQuery(c)
+	if !ok {
+		return
+	}
+
+	board, err := h.service.Board(uid, boardID, viewID, query)
+	if err != nil {
+		h.respondError(c, boardID, "load tasks of board", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, board)
+}
+
+func (h *TaskHandler) CreateColumn(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	var req domain.ColumnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	column, err := h.service.CreateColumn(uid, boardID, req)
+	if err != nil {
+		h.respondError(c, boardID, "create column on board", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, column)
+}
+
+func (h *TaskHandler) UpdateColumn(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	columnID, ok := idParam(c, "id", "column")
+	if !ok {
+		return
+	}
+
+	var req domain.ColumnUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	column, err := h.service.UpdateColumn(uid, columnID, req)
+	if err != nil {
+		h.respondError(c, columnID, "update column", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, column)
+}
+
+func (h *TaskHandler) Create(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req domain.TaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	task, err := h.service.Create(uid, req)
+	if err != nil {
+		h.respondError(c, req.ColumnID, "create task in column", err)
+		return
+	}
+
+	setETag(c, task.Version)
+	c.JSON(http.StatusCreated, task)
+}
+
+func (h *TaskHandler) Get(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	task, err := h.service.Get(uid, taskID)
+	if err != nil {
+		h.respondError(c, taskID, "load task", err)
+		return
+	}
+
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) Update(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	var req domain.TaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	task, err := h.service.Update(uid, taskID, version, req)
+	if err != nil {
+		h.respondError(c, taskID, "update task", err)
+		return
+	}
+
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, task)
+}
+
+func (h *TaskHandler) Delete(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(uid, taskID, version); err != nil {
+		h.respondError(c, taskID, "delete task", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TaskHandler) Move(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	version, ok := ifMatch(c)
+	if !ok {
+		return
+	}
+
+	var req domain.TaskMoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	result, err := h.service.Move(uid, taskID, version, req)
+	if err != nil {
+		h.respondError(c, taskID, "move task", err)
+		return
+	}
+
+	setETag(c, result.Version)
+	c.JSON(http.StatusOK, result)
+}
+
+func (h *TaskHandler) Subtasks(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	taskID, ok := idParam(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	subtasks, err := h.service.Subtasks(uid, taskID)
+	if err != nil {
+		h.respondError(c, taskID, "list subtasks of task", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subtasks)
+}
+
+func (h *TaskHandler) respondError(c *gin.Context, id int64, action string, err error) {
+	var conflict *service.VersionConflictError
+	switch {
+	case errors.As(err, &conflict):
+		respondConflict(c, conflict)
+	case errors.Is(err, service.ErrTaskNotFound),
+		errors.Is(err, service.ErrColumnNotFound),
+		errors.Is(err, service.ErrBoardNotFound),
+		errors.Is(err, service.ErrViewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrBoardForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTaskMoveConflict),
+		errors.Is(err, service.ErrWIPLimitReached):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// boardQuery reads how to list a board from the query. view=<id> applies a
+// saved view. priority=high,urgent, label=1,2, assignee=3,me and status=<column
+// ids> match any of the values; done=true|false picks by the column's kind;
+// due_from and due_to take RFC 3339 times or dates, a due_to date counting in
+// full; q matches the title and description; field.<id>=v, field.<id>.gte=v
+// and field.<id>.lte=v compare a custom field. sort=deadline sorts ascending,
+// sort=-deadline descending, and group_by picks the grouping.
+func boardQuery(c *gin.Context) (int64, domain.BoardQuery, bool) {
+	var viewID int64
+	var query domain.BoardQuery
+	filter := &query.Filter
+	invalid := func(what string) (int64, domain.BoardQuery, bool) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + what})
+		return 0, domain.BoardQuery{}, false
+	}
+
+	for key, values := range c.Request.URL.Query() {
+		value := values[len(values)-1]
+		switch {
+		case key == "view":
+			id, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || id < 1 {
+				return invalid("view id")
+			}
+			viewID = id
+		case key == "priority":
+			for _, value := range values {
+				filter.Priorities = append(filter.Priorities, splitList(value)...)
+			}
+		case key == "label":
+			ids, ok := idList(values)
+			if !ok {
+				return invalid("label filter")
+			}
+			filter.LabelIDs = append(filter.LabelIDs, ids...)
+		case key == "assignee":
+			var rest []string
+			for _, value := range values {
+				for _, item := range splitList(value) {
+					if item == "me" {
+						filter.AssignedToMe = true
+						continue
+					}
+					rest = append(rest, item)
+				}
+			}
+			ids, ok := idList(rest)
+			if !ok {
+				return invalid("assignee filter")
+			}
+			filter.AssigneeIDs = append(filter.AssigneeIDs, ids...)
+		case key == "status":
+			ids, ok := idList(values)
+			if !ok {
+				return invalid("status filter")
+			}
+			filter.ColumnIDs = append(filter.ColumnIDs, ids...)
+		case key == "done":
+			done, err := strconv.ParseBool(value)
+			if err != nil {
+				return invalid("done filter")
+			}
+			filter.Done = &done
+		case key == "due_from" || key == "due_to":
+			due, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				if due, err = time.Parse(time.DateOnly, value); err != nil {
+					return invalid(key + " filter")
+				}
+				if key == "due_to" {
+					due = due.AddDate(0, 0, 1)
+				}
+			}
+			if key == "due_from" {
+				filter.DueFrom = &due
+			} else {
+				filter.DueTo = &due
+			}
+		case key == "q":
+			filter.Text = strings.TrimSpace(value)
+		case key == "sort":
+			query.SortBy = strings.TrimPrefix(value, "-")
+			query.SortDesc = strings.HasPrefix(value, "-")
+		case key == "group_by":
+			query.GroupBy = value
+		case strings.HasPrefix(key, "field."):
+			parts := strings.Split(strings.TrimPrefix(key, "field."), ".")
+			id, err := strconv.ParseInt(parts[0], 10, 64)
+			if err != nil || len(parts) > 2 {
+				return invalid("filter " + key)
+			}
+			op := ""
+			if len(parts) == 2 {
+				op = parts[1]
+			}
+			for _, value := range values {
+				filter.Fields = append(filter.Fields, domain.FieldFilter{FieldID: id, Op: op, Value: value})
+			}
+		}
+	}
+	return viewID, query, true
+}
+
+// idList parses comma-separated ids from every value of a query parameter.
+func idList(values []string) ([]int64, bool) {
+	var ids []int64
+	for _, value := range values {
+		for _, item := range splitList(value) {
+			id, err := strconv.ParseInt(item, 10, 64)
+			if err != nil {
+				return nil, false
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, true
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ifMatch reads the version a write is conditional on from If-Match. Without
+// the header, or with "*", the write is unconditional.
+func ifMatch(c *gin.Context) (int64, bool) {
+	header := c.GetHeader("If-Match")
+	if header == "" || header == "*" {
+		return 0, true
+	}
+
+	unquoted, err := strconv.Unquote(header)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid If-Match header"})
+		return 0, false
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid If-Match header"})
+		return 0, false
+	}
+	return version, true
+}
+
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// respondConflict answers a stale If-Match with the current state, so the
+// client can merge and retry against the new ETag.
+func respondConflict(c *gin.Context, conflict *service.VersionConflictError) {
+	setETag(c, conflict.Version)
+	c.JSON(http.StatusPreconditionFailed, gin.H{
+		"error":   conflict.Error(),
+		"current": conflict.Current,
+	})
+}
@@ -0,0 +1,290 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type TemplateHandler struct {
+	service *service.TemplateService
+	logger  *logging.Logger
+}
+
+func NewTemplateHandler(service *service.TemplateService, logger *logging.Logger) *TemplateHandler {
+	return &TemplateHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *TemplateHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+	write := middleware.RequireScope(domain.ScopeTasksWrite)
+
+	rg.GET("/workspaces/:id/templates", read, h.BoardTemplates)
+	rg.POST("/boards/:id/templates", middleware.RequireSession(), h.SaveBoard)
+	rg.GET("/templates/:id", read, h.BoardTemplate)
+	rg.DELETE("/templates/:id", middleware.RequireSession(), h.DeleteBoardTemplate)
+	rg.POST("/templates/:id/boards", middleware.RequireSession(), h.CreateBoard)
+
+	rg.GET("/boards/:id/task-templates", read, h.TaskTemplates)
+	rg.POST("/boards/:id/task-templates", write, h.CreateTaskTemplate)
+	rg.PATCH("/task-templates/:id", write, h.UpdateTaskTemplate)
+	rg.DELETE("/task-templates/:id", write, h.DeleteTaskTemplate)
+	rg.POST("/task-templates/:id/tasks", write, h.CreateTask)
+}
+
+func (h *TemplateHandler) BoardTemplates(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	workspaceID, ok := idParam(c, "id", "workspace")
+	if !ok {
+		return
+	}
+
+	templates, err := h.service.BoardTemplates(uid, workspaceID)
+	if err != nil {
+		h.respondError(c, workspaceID, "list templates of workspace", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+func (h *TemplateHandler) SaveBoard(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	var req domain.BoardTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	template, err := h.service.SaveBoard(uid, boardID, req)
+	if err != nil {
+		h.respondError(c, boardID, "save template of board", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+func (h *TemplateHandler) BoardTemplate(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	templateID, ok := idParam(c, "id", "template")
+	if !ok {
+		return
+	}
+
+	template, err := h.service.BoardTemplate(uid, templateID)
+	if err != nil {
+		h.respondError(c, templateID, "get template", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+func (h *TemplateHandler) DeleteBoardTemplate(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	templateID, ok := idParam(c, "id", "template")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteBoardTemplate(uid, templateID); err != nil {
+		h.respondError(c, templateID, "delete template", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TemplateHandler) CreateBoard(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	templateID, ok := idParam(c, "id", "template")
+	if !ok {
+		return
+	}
+
+	var req domain.BoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	board, err := h.service.CreateBoard(uid, templateID, req)
+	if err != nil {
+		h.respondError(c, templateID, "create board from template", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, board)
+}
+
+func (h *TemplateHandler) TaskTemplates(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	templates, err := h.service.TaskTemplates(uid, boardID)
+	if err != nil {
+		h.respondError(c, boardID, "list task templates of board", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, templates)
+}
+
+func (h *TemplateHandler) CreateTaskTemplate(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	var req domain.TaskTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	template, err := h.service.CreateTaskTemplate(uid, boardID, req)
+	if err != nil {
+		h.respondError(c, boardID, "create task template on board", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, template)
+}
+
+func (h *TemplateHandler) UpdateTaskTemplate(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	templateID, ok := idParam(c, "id", "task template")
+	if !ok {
+		return
+	}
+
+	var req domain.TaskTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	template, err := h.service.UpdateTaskTemplate(uid, templateID, req)
+	if err != nil {
+		h.respondError(c, templateID, "update task template", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+func (h *TemplateHandler) DeleteTaskTemplate(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	templateID, ok := idParam(c, "id", "task template")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteTaskTemplate(uid, templateID); err != nil {
+		h.respondError(c, templateID, "delete task template", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TemplateHandler) CreateTask(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	templateID, ok := idParam(c, "id", "task template")
+	if !ok {
+		return
+	}
+
+	var req domain.TaskFromTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	task, err := h.service.CreateTask(uid, templateID, req)
+	if err != nil {
+		h.respondError(c, templateID, "create task from template", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, task)
+}
+
+func (h *TemplateHandler) respondError(c *gin.Context, id int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrTemplateNotFound),
+		errors.Is(err, service.ErrWorkspaceNotFound),
+		errors.Is(err, service.ErrBoardNotFound),
+		errors.Is(err, service.ErrColumnNotFound),
+		errors.Is(err, service.ErrTaskNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTemplateForbidden),
+		errors.Is(err, service.ErrWorkspaceForbidden),
+		errors.Is(err, service.ErrBoardForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTemplateExists),
+		errors.Is(err, service.ErrWIPLimitReached):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -1,23 +1,29 @@
 package rest
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
 	"github.com/your-team/taskmanager-chat/backend/pkg/apperror"
 	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
 )
 
 type UserService interface {
 	UserRegister(users domain.User) (domain.User, error)
-	UserLogin(users domain.User) (domain.TokenResponse, domain.TwoFaCodes, error)
+	UserLogin(users domain.User, client domain.ClientInfo) (domain.TokenResponse, domain.TwoFaCodes, error)
 	UserRefresh(token string) (domain.TokenResponse, error)
 	UserSendEmailCode(tempToken string) error
-	VerifyCode(code domain.Code) (domain.TokenResponse, error)
-	EnableTwoFA(userID int64) error
-	DisableTwoFA(userID int64, passqord string) error
+	VerifyCode(code domain.Code, client domain.ClientInfo) (domain.TokenResponse, error)
+	EnableTwoFA(userID int64, client domain.ClientInfo) error
+	DisableTwoFA(userID int64, passqord string, client domain.ClientInfo) error
+	UnlockAccount(actor domain.AdminActor, email, ip string) error
+	ResetPassword(req domain.PasswordResetRequest, client domain.ClientInfo) error
 }
 
 type UsersHandler struct {
@@ -32,7 +38,7 @@ func NewUsersHandler(s UserService, l *logging.Logger) *UsersHandler {
 	}
 }
 
-func (h *UsersHandler) RegisterRoutes(router *gin.RouterGroup, jwtSecret string) {
+func (h *UsersHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
 	auth := router.Group("/auth")
 	{
 		auth.POST("/register", h.signUp)
@@ -40,11 +46,16 @@ func (h *UsersHandler) RegisterRoutes(router *gin.RouterGroup, jwtSecret string)
 		auth.POST("/refresh", h.refresh)
 		auth.POST("/send-code", h.sendEmailToken)
 		auth.POST("/verify-code", h.verifyCode)
-		auth.POST("/enable-2fa", h.enableTwoFA)
-		auth.POST("/disable-2fa", h.disableTwoFA)
+		auth.POST("/reset-password", h.resetPassword)
+		auth.POST("/enable-2fa", authMiddleware, h.enableTwoFA)
+		auth.POST("/disable-2fa", authMiddleware, h.disableTwoFA)
 	}
 }
 
+func (h *UsersHandler) RegisterAdminRoutes(router *gin.RouterGroup) {
+	router.POST("/users/unlock", h.unlock)
+}
+
 func (h *UsersHandler) signUp(c *gin.Context) {
 	var user domain.User
 	if err := c.ShouldBindJSON(&user); err != nil {
@@ -82,11 +93,32 @@ func (h *UsersHandler) signIn(c *gin.Context) {
 		return
 	}
 	
-	accessToken, tempToken, err := h.service.UserLogin(user)
+	accessToken, tempToken, err := h.service.UserLogin(user, clientInfo(c))
 	if err != nil {
 		h.logger.Error("Failed to login user: " + err.Error())
+		var loginErr *service.LoginError
 		appErr, ok := err.(*apperror.AppError)
-		if ok {
+		if errors.As(err, &loginErr) {
+			if loginErr.ResetToken != "" {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error":                   loginErr.Message,
+					"password_reset_required": true,
+					"reset_token":             loginErr.ResetToken,
+				})
+			} else if loginErr.RetryAfter > 0 {
+				retryAfter := int(math.Ceil(loginErr.RetryAfter.Seconds()))
+				c.Header("Retry-After", strconv.Itoa(retryAfter))
+				c.JSON(http.StatusTooManyRequests, gin.H{
+					"error":       loginErr.Message,
+					"retry_after": retryAfter,
+				})
+			} else {
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error":            loginErr.Message,
+					"captcha_required": loginErr.CaptchaRequired,
+				})
+			}
+		} else if ok {
 			c.JSON(http.StatusUnauthorized, appErr)
 		} else {
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -165,8 +197,17 @@ func (h *UsersHandler) verifyCode(c *gin.Context) {
 		return
 	}
 	
-	tokenRes, err := h.service.VerifyCode(code)
+	tokenRes, err := h.service.VerifyCode(code, clientInfo(c))
 	if err != nil {
+		var loginErr *service.LoginError
+		if errors.As(err, &loginErr) && loginErr.ResetToken != "" {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":                   loginErr.Message,
+				"password_reset_required": true,
+				"reset_token":             loginErr.ResetToken,
+			})
+			return
+		}
 		h.logger.Error("Failed to verify code: " + err.Error())
 		c.JSON(http.StatusBadRequest, gin.H{
 				"error": "Verification failed",
@@ -186,7 +227,7 @@ func (h *UsersHandler) enableTwoFA(c *gin.Context) {
 		return
 	}
 	
-	err := h.service.EnableTwoFA(userID.(int64))
+	err := h.service.EnableTwoFA(userID.(int64), clientInfo(c))
 	if err != nil {
 		h.logger.Error("Failed to enable 2FA: " + err.Error())
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -218,7 +259,7 @@ func (h *UsersHandler) disableTwoFA(c *gin.Context) {
 		return
 	}
 	
-	err := h.service.DisableTwoFA(userID.(int64), req.Password)
+	err := h.service.DisableTwoFA(userID.(int64), req.Password, clientInfo(c))
 	if err != nil {
 		h.logger.Error("Failed to enable 2FA: " + err.Error())
 		c.JSON(http.StatusBadRequest, gin.H{
@@ -230,4 +271,50 @@ func (h *UsersHandler) disableTwoFA(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 	})
-}
\ No newline at end of file
+}
+
+func (h *UsersHandler) resetPassword(c *gin.Context) {
+	var req domain.PasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON: " + err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+	
+	if err := h.service.ResetPassword(req, clientInfo(c)); err != nil {
+		h.logger.Error("Failed to reset password: " + err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+	})
+}
+
+func (h *UsersHandler) unlock(c *gin.Context) {
+	var req domain.UnlockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Failed to bind JSON: " + err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request body",
+		})
+		return
+	}
+	
+	if err := h.service.UnlockAccount(adminActor(c), req.Email, req.IP); err != nil {
+		h.logger.Error("Failed to unlock account: " + err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to unlock account",
+		})
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+	})
+}
@@ -0,0 +1,141 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type ViewHandler struct {
+	service *service.ViewService
+	logger  *logging.Logger
+}
+
+func NewViewHandler(service *service.ViewService, logger *logging.Logger) *ViewHandler {
+	return &ViewHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *ViewHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	read := middleware.RequireScope(domain.ScopeBoardsRead)
+	write := middleware.RequireScope(domain.ScopeTasksWrite)
+
+	rg.GET("/boards/:id/views", read, h.List)
+	rg.POST("/boards/:id/views", write, h.Create)
+	rg.PATCH("/views/:id", write, h.Update)
+	rg.DELETE("/views/:id", write, h.Delete)
+}
+
+func (h *ViewHandler) List(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	views, err := h.service.List(uid, boardID)
+	if err != nil {
+		h.respondError(c, boardID, "list views of board", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, views)
+}
+
+func (h *ViewHandler) Create(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	boardID, ok := idParam(c, "id", "board")
+	if !ok {
+		return
+	}
+
+	var req domain.BoardViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	view, err := h.service.Create(uid, boardID, req)
+	if err != nil {
+		h.respondError(c, boardID, "create view on board", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, view)
+}
+
+func (h *ViewHandler) Update(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	viewID, ok := idParam(c, "id", "view")
+	if !ok {
+		return
+	}
+
+	var req domain.BoardViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	view, err := h.service.Update(uid, viewID, req)
+	if err != nil {
+		h.respondError(c, viewID, "update view", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+func (h *ViewHandler) Delete(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	viewID, ok := idParam(c, "id", "view")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(uid, viewID); err != nil {
+		h.respondError(c, viewID, "delete view", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ViewHandler) respondError(c *gin.Context, id int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrBoardNotFound),
+		errors.Is(err, service.ErrViewNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrBoardForbidden),
+		errors.Is(err, service.ErrViewForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrViewExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, id, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,340 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/service"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/middleware"
+)
+
+type WorkspaceHandler struct {
+	service *service.WorkspaceService
+	logger  *logging.Logger
+}
+
+func NewWorkspaceHandler(service *service.WorkspaceService, logger *logging.Logger) *WorkspaceHandler {
+	return &WorkspaceHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+func (h *WorkspaceHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	workspaces := rg.Group("/workspaces")
+	{
+		workspaces.GET("", middleware.RequireScope(domain.ScopeBoardsRead), h.List)
+		workspaces.GET("/:id", middleware.RequireScope(domain.ScopeBoardsRead), h.Get)
+		workspaces.GET("/:id/members", middleware.RequireScope(domain.ScopeBoardsRead), h.Members)
+	}
+
+	manage := rg.Group("/workspaces")
+	manage.Use(middleware.RequireSession())
+	{
+		manage.POST("", h.Create)
+		manage.PATCH("/:id", h.Rename)
+		manage.DELETE("/:id", h.Delete)
+		manage.PATCH("/:id/members/:userID", h.UpdateMemberRole)
+		manage.DELETE("/:id/members/:userID", h.RemoveMember)
+		manage.GET("/:id/invitations", h.Invitations)
+		manage.POST("/:id/invitations", h.Invite)
+		manage.DELETE("/:id/invitations/:invitationID", h.RevokeInvitation)
+	}
+
+	rg.POST("/workspace-invitations/:token/accept", middleware.RequireSession(), h.AcceptInvitation)
+}
+
+func (h *WorkspaceHandler) Create(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	var req domain.WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	workspace, err := h.service.Create(uid, req)
+	if err != nil {
+		h.respondError(c, 0, "create workspace", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, workspace)
+}
+
+func (h *WorkspaceHandler) List(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	workspaces, err := h.service.List(uid)
+	if err != nil {
+		h.logger.Errorf("Failed to list workspaces for user %d: %v", uid, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to list workspaces"})
+		return
+	}
+
+	c.JSON(http.StatusOK, workspaces)
+}
+
+func (h *WorkspaceHandler) Get(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	workspaceID, ok := idParam(c, "id", "workspace")
+	if !ok {
+		return
+	}
+
+	workspace, err := h.service.Get(uid, workspaceID)
+	if err != nil {
+		h.respondError(c, workspaceID, "load workspace", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workspace)
+}
+
+func (h *WorkspaceHandler) Rename(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	workspaceID, ok := idParam(c, "id", "workspace")
+	if !ok {
+		return
+	}
+
+	var req domain.WorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	workspace, err := h.service.Rename(uid, workspaceID, req)
+	if err != nil {
+		h.respondError(c, workspaceID, "rename workspace", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workspace)
+}
+
+func (h *WorkspaceHandler) Delete(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	workspaceID, ok := idParam(c, "id", "workspace")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(uid, workspaceID); err != nil {
+		h.respondError(c, workspaceID, "delete workspace", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WorkspaceHandler) Members(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	workspaceID, ok := idParam(c, "id", "workspace")
+	if !ok {
+		return
+	}
+
+	members, err := h.service.Members(uid, workspaceID)
+	if err != nil {
+		h.respondError(c, workspaceID, "list members of workspace", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+func (h *WorkspaceHandler) UpdateMemberRole(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	workspaceID, ok := idParam(c, "id", "workspace")
+	if !ok {
+		return
+	}
+	memberID, ok := idParam(c, "userID", "user")
+	if !ok {
+		return
+	}
+
+	var req domain.WorkspaceMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.service.SetMemberRole(uid, workspaceID, memberID, req.Role, clientInfo(c)); err != nil {
+		h.respondError(c, workspaceID, "change member role in workspace", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveMember removes a member; passing your own id leaves the workspace.
+func (h *WorkspaceHandler) RemoveMember(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	workspaceID, ok := idParam(c, "id", "workspace")
+	if !ok {
+		return
+	}
+	memberID, ok := idParam(c, "userID", "user")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveMember(uid, workspaceID, memberID, clientInfo(c)); err != nil {
+		h.respondError(c, workspaceID, "remove member from workspace", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WorkspaceHandler) Invite(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	workspaceID, ok := idParam(c, "id", "workspace")
+	if !ok {
+		return
+	}
+
+	var req domain.WorkspaceInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	invitation, err := h.service.Invite(uid, workspaceID, req)
+	if err != nil {
+		h.respondError(c, workspaceID, "invite to workspace", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+func (h *WorkspaceHandler) Invitations(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	workspaceID, ok := idParam(c, "id", "workspace")
+	if !ok {
+		return
+	}
+
+	invitations, err := h.service.Invitations(uid, workspaceID)
+	if err != nil {
+		h.respondError(c, workspaceID, "list invitations of workspace", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitations)
+}
+
+func (h *WorkspaceHandler) RevokeInvitation(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+	workspaceID, ok := idParam(c, "id", "workspace")
+	if !ok {
+		return
+	}
+	invitationID, ok := idParam(c, "invitationID", "invitation")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeInvitation(uid, workspaceID, invitationID); err != nil {
+		h.respondError(c, workspaceID, "revoke invitation of workspace", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WorkspaceHandler) AcceptInvitation(c *gin.Context) {
+	uid, ok := currentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		return
+	}
+
+	workspace, err := h.service.AcceptInvitation(uid, c.Param("token"), clientInfo(c))
+	if err != nil {
+		h.respondError(c, 0, "accept workspace invitation", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, workspace)
+}
+
+func (h *WorkspaceHandler) respondError(c *gin.Context, workspaceID int64, action string, err error) {
+	switch {
+	case errors.Is(err, service.ErrWorkspaceNotFound),
+		errors.Is(err, service.ErrMemberNotFound),
+		errors.Is(err, service.ErrInvitationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrWorkspaceForbidden), errors.Is(err, service.ErrInvitationForeignTo):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrLastWorkspaceOwner):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrInvitationInvalid):
+		c.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Failed to %s %d: %v", action, workspaceID, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	}
+}
+
+// idParam parses the named path parameter as an id and answers 400 when it is
+// not one.
+func idParam(c *gin.Context, name, what string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + what + " id"})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,33 @@
+package rest
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+)
+
+func currentUserID(c *gin.Context) (int64, bool) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		return 0, false
+	}
+
+	uid, ok := userID.(int64)
+	return uid, ok
+}
+
+func clientInfo(c *gin.Context) domain.ClientInfo {
+	return domain.ClientInfo{
+		IP:             c.ClientIP(),
+		UserAgent:      c.Request.UserAgent(),
+		ImpersonatorID: c.GetInt64("impersonatorID"),
+	}
+}
+
+// adminActor names who is calling the admin API. Requests made with the
+// operator token carry no user.
+func adminActor(c *gin.Context) domain.AdminActor {
+	return domain.AdminActor{
+		UserID: c.GetInt64("userID"),
+		Client: clientInfo(c),
+	}
+}
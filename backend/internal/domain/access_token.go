@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+const (
+	ScopeBoardsRead        = "boards:read"
+	ScopeTasksWrite        = "tasks:write"
+	ScopeChatWrite         = "chat:write"
+	ScopeNotificationsRead = "notifications:read"
+	ScopeUsersRead         = "users:read"
+)
+
+var AccessTokenScopes = []string{ScopeBoardsRead, ScopeTasksWrite, ScopeChatWrite, ScopeNotificationsRead, ScopeUsersRead}
+
+type PersonalAccessToken struct {
+	ID         int64     `json:"id"`
+	UserID     int64     `json:"user_id"`
+	Name       string    `json:"name"`
+	Prefix     string    `json:"prefix"`
+	TokenHash  string    `json:"-"`
+	Scopes     []string  `json:"scopes"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	LastUsedIP string    `json:"last_used_ip"`
+	RevokedAt  time.Time `json:"revoked_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AccessTokenRequest struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+type CreatedAccessToken struct {
+	PersonalAccessToken
+	Token string `json:"token"`
+}
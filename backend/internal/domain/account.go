@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+type AccountDeletionRequest struct {
+	Password string `json:"password"`
+}
+
+type AccountDeletion struct {
+	DeletionScheduledAt time.Time `json:"deletion_scheduled_at"`
+}
+
+// AccountExport is everything stored about a user, as handed out by the
+// personal data export.
+type AccountExport struct {
+	ExportedAt    time.Time             `json:"exported_at"`
+	Profile       UserProfile           `json:"profile"`
+	Tasks         []Task                `json:"tasks"`
+	Comments      []Comment             `json:"comments"`
+	Notifications []Notification        `json:"notifications"`
+	AccessTokens  []PersonalAccessToken `json:"access_tokens"`
+	Messages      []Message             `json:"messages"`
+}
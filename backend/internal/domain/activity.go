@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	ActivityTaskCreated       = "task.created"
+	ActivityFieldChanged      = "field.changed"
+	ActivityCommentCreated    = "comment.created"
+	ActivityCommentUpdated    = "comment.updated"
+	ActivityCommentDeleted    = "comment.deleted"
+	ActivityAttachmentAdded   = "attachment.added"
+	ActivityAttachmentRemoved = "attachment.removed"
+)
+
+// Fields named by field.changed entries. Custom fields are named
+// "fields.<id>".
+const (
+	ActivityFieldTitle       = "title"
+	ActivityFieldDescription = "description"
+	ActivityFieldStatus      = "status"
+	ActivityFieldDeadline    = "deadline"
+	ActivityFieldPriority    = "priority"
+	ActivityFieldLabels      = "labels"
+	ActivityFieldAssignees   = "assignees"
+	ActivityFieldParent      = "parent"
+)
+
+// TaskActivity is one entry of a task's timeline. Before and After hold the
+// JSON values around the change: the old and new value of a field, or the
+// comment or attachment concerned. ActorID is 0 for background jobs.
+type TaskActivity struct {
+	ID            int64           `json:"id"`
+	TaskID        int64           `json:"task_id"`
+	BoardID       int64           `json:"board_id"`
+	ActorID       int64           `json:"actor_id,omitempty"`
+	ActorUsername string          `json:"actor_username,omitempty"`
+	Type          string          `json:"type"`
+	Field         string          `json:"field,omitempty"`
+	Before        json.RawMessage `json:"before,omitempty"`
+	After         json.RawMessage `json:"after,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// TaskStatusValue is the before and after value of a status change.
+type TaskStatusValue struct {
+	ColumnID int64  `json:"column_id"`
+	Status   string `json:"status"`
+}
+
+// ActivityComment is the value of comment entries, with the start of the
+// comment's body.
+type ActivityComment struct {
+	CommentID int64  `json:"comment_id"`
+	Excerpt   string `json:"excerpt"`
+}
+
+// ActivityAttachment is the value of attachment entries.
+type ActivityAttachment struct {
+	AttachmentID int64  `json:"attachment_id"`
+	Filename     string `json:"filename"`
+}
+
+type TaskActivityPage struct {
+	Activity     []TaskActivity `json:"activity"`
+	NextBeforeID int64          `json:"next_before_id,omitempty"`
+}
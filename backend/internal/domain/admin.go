@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+type AdminUser struct {
+	ID                    int64      `json:"id"`
+	Username              string     `json:"username"`
+	Firstname             string     `json:"firstname"`
+	Lastname              string     `json:"lastname"`
+	Email                 string     `json:"email"`
+	Role                  string     `json:"role"`
+	TwoFAEnabled          bool       `json:"two_fa_enabled"`
+	BlockedUntil          *time.Time `json:"blocked_until,omitempty"`
+	PasswordResetRequired bool       `json:"password_reset_required"`
+	DeletionScheduledAt   *time.Time `json:"deletion_scheduled_at,omitempty"`
+	DeletedAt             *time.Time `json:"deleted_at,omitempty"`
+	CreatedAt             time.Time  `json:"created_at"`
+}
+
+type AdminUserList struct {
+	Users []AdminUser `json:"users"`
+	Total int64       `json:"total"`
+}
+
+type LoginAttempt struct {
+	ID          int64     `json:"id"`
+	Email       string    `json:"email"`
+	Success     bool      `json:"success"`
+	IP          string    `json:"ip"`
+	UserAgent   string    `json:"user_agent"`
+	Cleared     bool      `json:"cleared"`
+	AttemptedAt time.Time `json:"attempted_at"`
+}
+
+type LockRequest struct {
+	Minutes int `json:"minutes"`
+}
+
+type RoleRequest struct {
+	Role string `json:"role"`
+}
+
+type ImpersonationRequest struct {
+	Reason string `json:"reason"`
+}
+
+type ImpersonationToken struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+type PasswordResetRequest struct {
+	ResetToken  string `json:"reset_token"`
+	NewPassword string `json:"new_password"`
+}
+
+// AdminActor is who performs an admin action. UserID is 0 for the operator
+// token.
+type AdminActor struct {
+	UserID int64
+	Client ClientInfo
+}
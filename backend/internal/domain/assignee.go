@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// TaskAssignee is a board member responsible for a task. AssignedBy is nil
+// once the assigning user is deleted.
+type TaskAssignee struct {
+	UserID     int64     `json:"user_id"`
+	Username   string    `json:"username"`
+	AssignedBy *int64    `json:"assigned_by,omitempty"`
+	AssignedAt time.Time `json:"assigned_at"`
+}
+
+// TaskWatcher is a board member following a task. Creators and commenters
+// start watching automatically.
+type TaskWatcher struct {
+	UserID   int64     `json:"user_id"`
+	Username string    `json:"username"`
+	Since    time.Time `json:"since"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// Attachment is a file uploaded to a board and referenced by a task or a
+// chat message. URL and ThumbnailURL are signed download links that expire;
+// ThumbnailURL is set for images only.
+type Attachment struct {
+	ID           int64     `json:"id"`
+	BoardID      int64     `json:"board_id"`
+	TaskID       *int64    `json:"task_id"`
+	MessageID    string    `json:"message_id,omitempty"`
+	Filename     string    `json:"filename"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	SHA256       string    `json:"sha256"`
+	UploadedBy   *int64    `json:"uploaded_by"`
+	CreatedAt    time.Time `json:"created_at"`
+	HasThumbnail bool      `json:"-"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
+}
+
+// AttachmentRef is the copy of an attachment kept on a chat message; links
+// to it are fetched through the attachment's id.
+type AttachmentRef struct {
+	ID          int64  `json:"id" bson:"id"`
+	Filename    string `json:"filename" bson:"filename"`
+	ContentType string `json:"content_type" bson:"content_type"`
+	Size        int64  `json:"size" bson:"size"`
+}
+
+// AttachmentBlob is stored content, kept once per SHA-256.
+type AttachmentBlob struct {
+	SHA256       string
+	HasThumbnail bool
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditLoginSucceeded    = "login.succeeded"
+	AuditLoginFailed       = "login.failed"
+	AuditAccountLocked     = "login.account_locked"
+	AuditPasswordReset     = "password.reset"
+	AuditTwoFAEnabled      = "2fa.enabled"
+	AuditTwoFADisabled     = "2fa.disabled"
+	AuditTokenCreated      = "token.created"
+	AuditTokenRevoked      = "token.revoked"
+	AuditTokensRevoked     = "token.revoked_all"
+	AuditRoleChanged       = "permission.role_changed"
+	AuditWorkspaceRole     = "permission.workspace_role_changed"
+	AuditWorkspaceJoined   = "workspace.member_joined"
+	AuditWorkspaceRemoved  = "workspace.member_removed"
+	AuditBoardJoined       = "board.member_joined"
+	AuditAccountExported   = "account.exported"
+	AuditDeletionRequested = "account.deletion_requested"
+	AuditDeletionCancelled = "account.deletion_cancelled"
+	AuditAccountDeleted    = "account.deleted"
+	AuditAdminLock         = "admin.lock"
+	AuditAdminUnlock       = "admin.unlock"
+	AuditAdminForceReset   = "admin.force_password_reset"
+	AuditAdminDisableTwoFA = "admin.disable_2fa"
+	AuditAdminImpersonate  = "admin.impersonate"
+	AuditAdminUnlockLogin  = "admin.unlock_login"
+)
+
+// AuditEvent is one row of the append-only security log. ActorID is 0 for
+// anonymous callers (failed logins), the operator token and background jobs.
+type AuditEvent struct {
+	ID           int64           `json:"id"`
+	Type         string          `json:"type"`
+	ActorID      int64           `json:"actor_id,omitempty"`
+	TargetUserID int64           `json:"target_user_id,omitempty"`
+	IP           string          `json:"ip,omitempty"`
+	UserAgent    string          `json:"user_agent,omitempty"`
+	Details      json.RawMessage `json:"details"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
+
+// AuditFilter narrows the audit log. Zero values mean "any"; results are
+// returned newest first and BeforeID continues a previous page.
+type AuditFilter struct {
+	Types        []string
+	ActorID      int64
+	TargetUserID int64
+	IP           string
+	Since        time.Time
+	Until        time.Time
+	BeforeID     int64
+	Limit        int
+}
+
+type AuditEventPage struct {
+	Events       []AuditEvent `json:"events"`
+	NextBeforeID int64        `json:"next_before_id,omitempty"`
+}
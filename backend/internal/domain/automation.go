@@ -0,0 +1,126 @@
+package domain
+
+import "time"
+
+// Triggers of automation rules.
+const (
+	AutomationTriggerTaskMoved      = "task.moved"
+	AutomationTriggerDeadlinePassed = "deadline.passed"
+	AutomationTriggerLabelAdded     = "label.added"
+	AutomationTriggerMessagePosted  = "message.posted"
+)
+
+// Actions of automation rules.
+const (
+	AutomationActionAssign      = "assign"
+	AutomationActionAddLabel    = "add_label"
+	AutomationActionMove        = "move"
+	AutomationActionPostMessage = "post_message"
+	AutomationActionNotify      = "notify"
+	AutomationActionWebhook     = "webhook"
+)
+
+const (
+	AutomationSucceeded = "succeeded"
+	AutomationSkipped   = "skipped"
+	AutomationFailed    = "failed"
+)
+
+// AutomationRule runs its actions on a task of the board when the trigger
+// fires for that task and the conditions hold. The actions run with the
+// access of CreatedBy.
+type AutomationRule struct {
+	ID         int64                `json:"id"`
+	BoardID    int64                `json:"board_id"`
+	CreatedBy  int64                `json:"created_by"`
+	Name       string               `json:"name"`
+	Enabled    bool                 `json:"enabled"`
+	Trigger    AutomationTrigger    `json:"trigger"`
+	Conditions AutomationConditions `json:"conditions"`
+	Actions    []AutomationAction   `json:"actions"`
+	CreatedAt  time.Time            `json:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+}
+
+// AutomationTrigger is what starts a rule. task.moved fires when a task
+// enters ColumnID, or any column if it is 0; label.added when LabelID, or any
+// label, is added to a task; deadline.passed when an open task is overdue;
+// message.posted when a chat message names a task of the board as #<id>.
+type AutomationTrigger struct {
+	Type     string `json:"type"`
+	ColumnID int64  `json:"column_id,omitempty"`
+	LabelID  int64  `json:"label_id,omitempty"`
+}
+
+// AutomationConditions must all hold for the task. Lists match any of their
+// values; empty lists and nil values match every task.
+type AutomationConditions struct {
+	Priorities    []string `json:"priorities,omitempty"`
+	LabelIDs      []int64  `json:"label_ids,omitempty"`
+	AssigneeIDs   []int64  `json:"assignee_ids,omitempty"`
+	Unassigned    bool     `json:"unassigned,omitempty"`
+	ColumnIDs     []int64  `json:"column_ids,omitempty"`
+	Done          *bool    `json:"done,omitempty"`
+	TitleContains string   `json:"title_contains,omitempty"`
+}
+
+// AutomationAction is one step of a rule. assign takes UserID, add_label
+// LabelID and move ColumnID. post_message and notify take Message, in which
+// {task.id}, {task.title} and {task.status} are replaced; notify goes to
+// UserID, or the task's assignees and watchers if it is 0. webhook posts the
+// rule, trigger and task as JSON to URL.
+type AutomationAction struct {
+	Type     string `json:"type"`
+	UserID   int64  `json:"user_id,omitempty"`
+	LabelID  int64  `json:"label_id,omitempty"`
+	ColumnID int64  `json:"column_id,omitempty"`
+	Message  string `json:"message,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+type AutomationRuleRequest struct {
+	Name       *string               `json:"name"`
+	Enabled    *bool                 `json:"enabled"`
+	Trigger    *AutomationTrigger    `json:"trigger"`
+	Conditions *AutomationConditions `json:"conditions"`
+	Actions    *[]AutomationAction   `json:"actions"`
+}
+
+// AutomationExecution is an entry of a rule's log. Depth counts the rules
+// that ran before this one in a chain started by a user; Results has one line
+// per action.
+type AutomationExecution struct {
+	ID          int64     `json:"id"`
+	RuleID      int64     `json:"rule_id"`
+	BoardID     int64     `json:"board_id"`
+	TaskID      int64     `json:"task_id,omitempty"`
+	TriggerType string    `json:"trigger_type"`
+	Status      string    `json:"status"`
+	Depth       int       `json:"depth"`
+	Results     []string  `json:"results"`
+	Error       string    `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type AutomationExecutionPage struct {
+	Executions   []AutomationExecution `json:"executions"`
+	NextBeforeID int64                 `json:"next_before_id,omitempty"`
+}
+
+// AutomationDeadline is an overdue task a deadline rule has not handled yet.
+type AutomationDeadline struct {
+	RuleID  int64
+	TaskID  int64
+	BoardID int64
+}
+
+// AutomationWebhook is the body of webhook calls. Message is the chat
+// message that fired a message.posted rule.
+type AutomationWebhook struct {
+	RuleID   int64  `json:"rule_id"`
+	RuleName string `json:"rule_name"`
+	Trigger  string `json:"trigger"`
+	BoardID  int64  `json:"board_id"`
+	Task     Task   `json:"task"`
+	Message  string `json:"message,omitempty"`
+}
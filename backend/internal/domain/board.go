@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+const (
+	BoardRoleAdmin  = "admin"
+	BoardRoleEditor = "editor"
+	BoardRoleViewer = "viewer"
+)
+
+// Board belongs to exactly one workspace. WorkspaceRole is the role of the
+// user the board was loaded for in that workspace, Role their effective role
+// on the board itself. Revision is the revision of the last event published
+// to the board's room, Version counts the writes to the board itself.
+type Board struct {
+	ID            int64     `json:"id"`
+	WorkspaceID   int64     `json:"workspace_id"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	CreatedBy     int64     `json:"created_by,omitempty"`
+	WorkspaceRole string    `json:"workspace_role,omitempty"`
+	Role          string    `json:"role,omitempty"`
+	Revision      int64     `json:"revision"`
+	Version       int64     `json:"version"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type BoardRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+// BoardInviteRequest creates an invite link. Without Email the link is open
+// to anyone who has it; MaxUses 0 means unlimited and a zero ExpiresAt uses
+// the configured default lifetime.
+type BoardInviteRequest struct {
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	MaxUses   int       `json:"max_uses"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type BoardInvite struct {
+	ID          int64      `json:"id"`
+	BoardID     int64      `json:"board_id"`
+	BoardName   string     `json:"board_name,omitempty"`
+	WorkspaceID int64      `json:"workspace_id,omitempty"`
+	Email       string     `json:"email,omitempty"`
+	Role        string     `json:"role"`
+	MaxUses     int        `json:"max_uses,omitempty"`
+	UseCount    int        `json:"use_count"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	CreatedBy   int64      `json:"created_by,omitempty"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// CreatedBoardInvite is only returned once, right after creation; the token
+// cannot be recovered later.
+type CreatedBoardInvite struct {
+	BoardInvite
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+const (
+	BoardEventTaskCreated   = "task.created"
+	BoardEventTaskUpdated   = "task.updated"
+	BoardEventTaskMoved     = "task.moved"
+	BoardEventTaskDeleted   = "task.deleted"
+	BoardEventColumnCreated = "column.created"
+	BoardEventColumnUpdated = "column.updated"
+)
+
+// BoardEvent is the envelope pushed to everyone watching a board. Revisions
+// of a board increase by one per event, so a client that sees a jump has
+// missed events and should reload the board.
+type BoardEvent struct {
+	Type        string      `json:"type"`
+	WorkspaceID int64       `json:"workspace_id"`
+	BoardID     int64       `json:"board_id"`
+	Revision    int64       `json:"revision"`
+	ActorID     int64       `json:"actor_id"`
+	Data        interface{} `json:"data"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// TaskMovedEvent is the data of a task.moved event.
+type TaskMovedEvent struct {
+	Task         Task  `json:"task"`
+	FromColumnID int64 `json:"from_column_id"`
+}
+
+// TaskDeletedEvent is the data of a task.deleted event.
+type TaskDeletedEvent struct {
+	ID       int64 `json:"id"`
+	ColumnID int64 `json:"column_id"`
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+const (
+	BoardEventChecklistItemCreated = "checklist_item.created"
+	BoardEventChecklistItemUpdated = "checklist_item.updated"
+	BoardEventChecklistItemMoved   = "checklist_item.moved"
+	BoardEventChecklistItemDeleted = "checklist_item.deleted"
+)
+
+// ChecklistItem is a step inside a task. Position is its lexorank key in the
+// checklist.
+type ChecklistItem struct {
+	ID         int64     `json:"id"`
+	TaskID     int64     `json:"task_id"`
+	Text       string    `json:"text"`
+	Done       bool      `json:"done"`
+	AssigneeID *int64    `json:"assignee_id"`
+	Position   string    `json:"position"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type ChecklistItemRequest struct {
+	Text          *string `json:"text"`
+	Done          *bool   `json:"done"`
+	AssigneeID    *int64  `json:"assignee_id"`
+	ClearAssignee bool    `json:"clear_assignee"`
+}
+
+// ChecklistMoveRequest puts an item right after AfterID, or first without it.
+type ChecklistMoveRequest struct {
+	AfterID *int64 `json:"after_id"`
+}
+
+// ChecklistEvent carries a changed checklist item together with the new
+// progress of its task, so clients can update the card without a reload.
+type ChecklistEvent struct {
+	Item     ChecklistItem `json:"item"`
+	Progress TaskProgress  `json:"progress"`
+}
+
+// TaskRef is the short form of a task in a dependency list.
+type TaskRef struct {
+	ID       int64  `json:"id"`
+	Title    string `json:"title"`
+	ColumnID int64  `json:"column_id"`
+	Status   string `json:"status"`
+	Done     bool   `json:"done"`
+}
+
+// TaskDependencies are the tasks blocking a task and the tasks it blocks.
+type TaskDependencies struct {
+	BlockedBy []TaskRef `json:"blocked_by"`
+	Blocks    []TaskRef `json:"blocks"`
+}
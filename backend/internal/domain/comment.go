@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+const (
+	BoardEventCommentCreated = "comment.created"
+	BoardEventCommentUpdated = "comment.updated"
+	BoardEventCommentDeleted = "comment.deleted"
+)
+
+// Comment is a markdown note on a task. Body is sanitized on the way in;
+// Edited tells whether the body was changed after posting.
+type Comment struct {
+	ID        int64      `json:"id"`
+	TaskID    int64      `json:"task_id"`
+	BoardID   int64      `json:"board_id"`
+	UserID    int64      `json:"user_id"`
+	Username  string     `json:"username"`
+	Body      string     `json:"body"`
+	Edited    bool       `json:"edited"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type CommentRequest struct {
+	Body string `json:"body"`
+}
+
+// CommentRevision is a body a comment had before an edit or its deletion.
+type CommentRevision struct {
+	ID               int64     `json:"id"`
+	CommentID        int64     `json:"comment_id"`
+	Body             string    `json:"body"`
+	EditedBy         int64     `json:"edited_by,omitempty"`
+	EditedByUsername string    `json:"edited_by_username,omitempty"`
+	EditedAt         time.Time `json:"edited_at"`
+}
+
+// CommentDeletedEvent is the data of a comment.deleted event.
+type CommentDeletedEvent struct {
+	ID     int64 `json:"id"`
+	TaskID int64 `json:"task_id"`
+}
@@ -0,0 +1,112 @@
+package domain
+
+import "time"
+
+const (
+	PriorityNone   = "none"
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+const (
+	FieldTypeText        = "text"
+	FieldTypeNumber      = "number"
+	FieldTypeDate        = "date"
+	FieldTypeSelect      = "select"
+	FieldTypeMultiSelect = "multi_select"
+	FieldTypeUser        = "user"
+)
+
+const (
+	BoardEventLabelCreated = "label.created"
+	BoardEventLabelUpdated = "label.updated"
+	BoardEventLabelDeleted = "label.deleted"
+	BoardEventFieldCreated = "field.created"
+	BoardEventFieldUpdated = "field.updated"
+	BoardEventFieldDeleted = "field.deleted"
+)
+
+// Label is a colored tag defined per board. Color is "#rrggbb".
+type Label struct {
+	ID        int64     `json:"id"`
+	BoardID   int64     `json:"board_id"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type LabelRequest struct {
+	Name  *string `json:"name"`
+	Color *string `json:"color"`
+}
+
+// CustomField is a typed attribute a board admin adds to all tasks of the
+// board. Options lists the choices of select and multi_select fields.
+type CustomField struct {
+	ID        int64     `json:"id"`
+	BoardID   int64     `json:"board_id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Options   []string  `json:"options"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CustomFieldRequest creates or changes a field. Type is only read on
+// creation; a field cannot change its type.
+type CustomFieldRequest struct {
+	Name    *string   `json:"name"`
+	Type    string    `json:"type"`
+	Options *[]string `json:"options"`
+}
+
+// FieldValue is a validated custom field value of a task. At most one member
+// is set, matching the field's type; none clears the value.
+type FieldValue struct {
+	FieldID int64
+	Text    *string
+	Number  *float64
+	Date    *time.Time
+	UserID  *int64
+	Options []string
+}
+
+// TaskFilter narrows the tasks of a board. A task must match any of the
+// priorities, carry any of the labels, have any of the assignees, sit in any
+// of the columns, be due in [DueFrom, DueTo), contain Text and meet every
+// field condition. AssignedToMe adds the reading user to AssigneeIDs, so a
+// shared view works for everyone.
+type TaskFilter struct {
+	Priorities   []string      `json:"priorities,omitempty"`
+	LabelIDs     []int64       `json:"label_ids,omitempty"`
+	AssigneeIDs  []int64       `json:"assignee_ids,omitempty"`
+	AssignedToMe bool          `json:"assigned_to_me,omitempty"`
+	ColumnIDs    []int64       `json:"column_ids,omitempty"`
+	Done         *bool         `json:"done,omitempty"`
+	DueFrom      *time.Time    `json:"due_from,omitempty"`
+	DueTo        *time.Time    `json:"due_to,omitempty"`
+	Text         string        `json:"text,omitempty"`
+	Fields       []FieldFilter `json:"fields,omitempty"`
+}
+
+// FieldFilter compares a custom field with Value using Op: "eq" for every
+// type (for multi_select: has the option), "gte" and "lte" for number and
+// date fields. Typed is Value parsed for the field's type.
+type FieldFilter struct {
+	FieldID int64      `json:"field_id"`
+	Op      string     `json:"op"`
+	Value   string     `json:"value"`
+	Typed   FieldValue `json:"-"`
+}
+
+// LabelDeletedEvent is the data of a label.deleted event.
+type LabelDeletedEvent struct {
+	ID int64 `json:"id"`
+}
+
+// FieldDeletedEvent is the data of a field.deleted event.
+type FieldDeletedEvent struct {
+	ID int64 `json:"id"`
+}
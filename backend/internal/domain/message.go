@@ -3,25 +3,36 @@ package domain
 import "time"
 
 type Message struct {
-	ID        string    `json:"id" bson:"_id"`
-	BoardID   int64     `json:"board_id" bson:"board_id"`
-	UserID    int64     `json:"user_id" bson:"user_id"`
-	Username  string    `json:"username" bson:"username"`
-	Content   string    `json:"content" bson:"content"`
-	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	ID          string          `json:"id" bson:"_id"`
+	WorkspaceID int64           `json:"workspace_id" bson:"workspace_id"`
+	BoardID     int64           `json:"board_id" bson:"board_id"`
+	UserID      int64           `json:"user_id" bson:"user_id"`
+	Username    string          `json:"username" bson:"username"`
+	Content     string          `json:"content" bson:"content"`
+	Attachments []AttachmentRef `json:"attachments,omitempty" bson:"attachments,omitempty"`
+	CreatedAt   time.Time       `json:"created_at" bson:"created_at"`
 }
 
+// MessageRequest may reference files the author uploaded to the board
+// beforehand.
 type MessageRequest struct {
-	BoardID int64  `json:"board_id"`
-	Content string `json:"content"`
+	BoardID       int64   `json:"board_id"`
+	Content       string  `json:"content"`
+	AttachmentIDs []int64 `json:"attachment_ids"`
 }
 
+// MessageTypeChat tells chat messages apart from board events on the same
+// socket.
+const MessageTypeChat = "chat.message"
+
 type MessageResponse struct {
-	ID        string    `json:"id"`
-	BoardID   int64     `json:"board_id"`
-	UserID    int64     `json:"user_id"`
-	Username  string    `json:"username"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
+	Type        string          `json:"type"`
+	ID          string          `json:"id"`
+	WorkspaceID int64           `json:"workspace_id"`
+	BoardID     int64           `json:"board_id"`
+	UserID      int64           `json:"user_id"`
+	Username    string          `json:"username"`
+	Content     string          `json:"content"`
+	Attachments []AttachmentRef `json:"attachments,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
 }
-
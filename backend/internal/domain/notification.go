@@ -2,25 +2,17 @@ package domain
 
 import "time"
 
+// Notification is a message to one user. Activity is the task change that
+// caused it, when there is one.
 type Notification struct {
-	ID        int64     `json:"id"`
-	UserID    int64     `json:"user_id"`
-	TaskID    int64     `json:"task_id"`
-	Title     string    `json:"title"`
-	Message   string    `json:"message"`
-	IsRead    bool      `json:"is_read"`
-	Type      string    `json:"type"`
-	CreatedAt time.Time `json:"created_at"`
-	ExpiresAt time.Time `json:"expires_at"`
-}
-
-type Task struct {
-	ID          int64     `json:"id"`
-	UserID      int64     `json:"user_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	Deadline    time.Time `json:"deadline"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID        int64         `json:"id"`
+	UserID    int64         `json:"user_id"`
+	TaskID    int64         `json:"task_id"`
+	Title     string        `json:"title"`
+	Message   string        `json:"message"`
+	IsRead    bool          `json:"is_read"`
+	Type      string        `json:"type"`
+	CreatedAt time.Time     `json:"created_at"`
+	ExpiresAt time.Time     `json:"expires_at"`
+	Activity  *TaskActivity `json:"activity,omitempty"`
 }
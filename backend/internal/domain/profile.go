@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+type UserProfile struct {
+	ID                  int64      `json:"id"`
+	Username            string     `json:"username"`
+	Firstname           string     `json:"firstname"`
+	Lastname            string     `json:"lastname"`
+	Email               string     `json:"email"`
+	TimeZone            string     `json:"time_zone"`
+	Locale              string     `json:"locale"`
+	AvatarURL           string     `json:"avatar_url"`
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at,omitempty"`
+	CreatedAt           time.Time  `json:"created_at"`
+}
+
+type PublicProfile struct {
+	ID        int64  `json:"id"`
+	Username  string `json:"username"`
+	Firstname string `json:"firstname"`
+	Lastname  string `json:"lastname"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// ProfileUpdate is a partial update: nil fields are left unchanged.
+type ProfileUpdate struct {
+	Username  *string `json:"username"`
+	Firstname *string `json:"firstname"`
+	Lastname  *string `json:"lastname"`
+	TimeZone  *string `json:"time_zone"`
+	Locale    *string `json:"locale"`
+}
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+const (
+	RecurrenceOnCompletion = "on_completion"
+	RecurrenceOnSchedule   = "on_schedule"
+)
+
+// TaskRecurrence repeats a task by its RRULE, an RFC 5545 recurrence rule
+// such as "FREQ=WEEKLY;BYDAY=MO". TaskID is the latest instance and
+// Occurrence its deadline; the next instance is created when that one is
+// completed, or in on_schedule mode when Occurrence passes. Occurrences keep
+// the wall-clock time of Start in TimeZone, across DST changes. Upcoming
+// previews the next deadlines. When creating the next instance keeps failing,
+// Failures counts the attempts and NextAttemptAt is when it is tried again.
+type TaskRecurrence struct {
+	ID            int64       `json:"id"`
+	BoardID       int64       `json:"board_id"`
+	TaskID        int64       `json:"task_id"`
+	CreatedBy     int64       `json:"created_by"`
+	ColumnID      *int64      `json:"column_id,omitempty"`
+	RRule         string      `json:"rrule"`
+	TimeZone      string      `json:"time_zone"`
+	Mode          string      `json:"mode"`
+	Start         time.Time   `json:"dtstart"`
+	Occurrence    time.Time   `json:"occurrence"`
+	Upcoming      []time.Time `json:"upcoming"`
+	Failures      int         `json:"failures,omitempty"`
+	NextAttemptAt *time.Time  `json:"next_attempt_at,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// TaskRecurrenceRequest sets a task's recurrence, starting from its
+// deadline. TimeZone defaults to the user's profile and Mode to
+// on_completion.
+type TaskRecurrenceRequest struct {
+	RRule    string `json:"rrule"`
+	TimeZone string `json:"time_zone"`
+	Mode     string `json:"mode"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+const (
+	SearchResultTask    = "task"
+	SearchResultComment = "comment"
+	SearchResultMessage = "message"
+)
+
+// SearchRequest searches the boards the user can open, or only BoardID when
+// it is set.
+type SearchRequest struct {
+	Query   string
+	BoardID int64
+	Limit   int
+}
+
+// SearchResult is one match. Title is the title of the task the match
+// belongs to; chat messages have none. Snippet is HTML-escaped text around
+// the match with the matched words wrapped in <mark>. Score is in [0, 1),
+// comparable across result types.
+type SearchResult struct {
+	Type      string    `json:"type"`
+	BoardID   int64     `json:"board_id"`
+	TaskID    int64     `json:"task_id,omitempty"`
+	CommentID int64     `json:"comment_id,omitempty"`
+	MessageID string    `json:"message_id,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Username  string    `json:"username,omitempty"`
+	Snippet   string    `json:"snippet"`
+	Score     float64   `json:"score"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SearchResults struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+}
+
+// MessageMatch is a chat message found by a text search, with the raw
+// relevance score of the message store.
+type MessageMatch struct {
+	Message
+	Score float64
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Task is a card on a board, created by UserID. Status is the title of the
+// column it is in, and Done tells whether that is a done column; Position is
+// its lexorank key inside that column. Version goes up with every edit or move
+// and is the task's ETag. Fields maps custom field ids to their values: a
+// string, number, "YYYY-MM-DD" date, user id or list of options. BlockedBy
+// lists the tasks that have to be done first. CompletedAt is when the task
+// last entered a done column, unset while it is outside one.
+type Task struct {
+	ID            int64                 `json:"id"`
+	BoardID       int64                 `json:"board_id"`
+	ColumnID      int64                 `json:"column_id"`
+	ParentID      *int64                `json:"parent_id"`
+	UserID        int64                 `json:"user_id"`
+	Title         string                `json:"title"`
+	Description   string                `json:"description"`
+	Status        string                `json:"status"`
+	Done          bool                  `json:"done"`
+	Deadline      *time.Time            `json:"deadline,omitempty"`
+	Position      string                `json:"position"`
+	Priority      string                `json:"priority"`
+	LabelIDs      []int64               `json:"label_ids"`
+	AssigneeIDs   []int64               `json:"assignee_ids"`
+	AttachmentIDs []int64               `json:"attachment_ids"`
+	BlockedBy     []int64               `json:"blocked_by"`
+	Fields        map[int64]interface{} `json:"fields"`
+	Progress      TaskProgress          `json:"progress"`
+	Version       int64                 `json:"version"`
+	CompletedAt   *time.Time            `json:"completed_at,omitempty"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+// TaskProgress rolls up a task's checklist and the subtasks that are done.
+type TaskProgress struct {
+	ChecklistDone  int64 `json:"checklist_done"`
+	ChecklistTotal int64 `json:"checklist_total"`
+	SubtasksDone   int64 `json:"subtasks_done"`
+	SubtasksTotal  int64 `json:"subtasks_total"`
+}
+
+type TaskRequest struct {
+	ColumnID      int64      `json:"column_id"`
+	Title         *string    `json:"title"`
+	Description   *string    `json:"description"`
+	Deadline      *time.Time `json:"deadline"`
+	ClearDeadline bool       `json:"clear_deadline"`
+	ParentID      *int64     `json:"parent_id"`
+	ClearParent   bool       `json:"clear_parent"`
+	Priority      *string    `json:"priority"`
+	LabelIDs      *[]int64   `json:"label_ids"`
+	// Fields sets custom field values by field id; null clears a value.
+	Fields map[int64]json.RawMessage `json:"fields"`
+}
+
+// TaskMoveRequest places a task in ColumnID between AfterID and BeforeID.
+// Either neighbour may be omitted; without both the task goes to the end.
+type TaskMoveRequest struct {
+	ColumnID int64  `json:"column_id"`
+	AfterID  *int64 `json:"after_id"`
+	BeforeID *int64 `json:"before_id"`
+}
+
+// TaskMoveResult is a moved task with warnings about the move, such as
+// completing a task whose blockers are still open or going past a column's
+// work-in-progress limit.
+type TaskMoveResult struct {
+	Task
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+const (
+	ColumnKindBacklog    = "backlog"
+	ColumnKindInProgress = "in_progress"
+	ColumnKindDone       = "done"
+)
+
+const (
+	WIPPolicyWarn    = "warn"
+	WIPPolicyEnforce = "enforce"
+)
+
+// Column is a stage of a board. Kind tells whether its tasks are not started,
+// in progress or done; Done is true for done columns. WIPLimit caps the tasks
+// in the column, and WIPPolicy says whether moving past it is only warned
+// about or refused.
+type Column struct {
+	ID        int64     `json:"id"`
+	BoardID   int64     `json:"board_id"`
+	Title     string    `json:"title"`
+	Position  string    `json:"position"`
+	Kind      string    `json:"kind"`
+	Done      bool      `json:"done"`
+	WIPLimit  *int      `json:"wip_limit"`
+	WIPPolicy string    `json:"wip_policy"`
+	Tasks     []Task    `json:"tasks"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ColumnRequest creates a column, in progress unless Kind says otherwise.
+// Done is the older way to ask for a done column.
+type ColumnRequest struct {
+	Title     string `json:"title"`
+	Kind      string `json:"kind"`
+	Done      bool   `json:"done"`
+	WIPLimit  *int   `json:"wip_limit"`
+	WIPPolicy string `json:"wip_policy"`
+}
+
+// ColumnUpdateRequest changes the given settings of a column.
+type ColumnUpdateRequest struct {
+	Title         *string `json:"title"`
+	Kind          *string `json:"kind"`
+	WIPLimit      *int    `json:"wip_limit"`
+	ClearWIPLimit bool    `json:"clear_wip_limit"`
+	WIPPolicy     *string `json:"wip_policy"`
+}
+
+// BoardTasks is a board's columns in order, each with its tasks in order.
+// Revision is read before the columns, so every event with a higher revision
+// may still be missing from the snapshot. Query is the listing applied,
+// taken from ViewID when a view was named; unless it groups by status, the
+// columns come without tasks and the tasks are in Groups instead.
+type BoardTasks struct {
+	BoardID  int64         `json:"board_id"`
+	Revision int64         `json:"revision"`
+	ViewID   int64         `json:"view_id,omitempty"`
+	Query    BoardQuery    `json:"query"`
+	Columns  []Column      `json:"columns"`
+	Groups   []TaskGroup   `json:"groups,omitempty"`
+	Labels   []Label       `json:"labels"`
+	Fields   []CustomField `json:"fields"`
+}
@@ -0,0 +1,110 @@
+package domain
+
+import "time"
+
+// BoardTemplate is a board's setup saved in its workspace, to start new
+// boards from.
+type BoardTemplate struct {
+	ID          int64                `json:"id"`
+	WorkspaceID int64                `json:"workspace_id"`
+	CreatedBy   *int64               `json:"created_by"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Content     BoardTemplateContent `json:"content"`
+	CreatedAt   time.Time            `json:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at"`
+}
+
+// BoardTemplateContent is the snapshot of a board. Columns, labels and
+// fields keep the ids they had on the board the template was saved from;
+// rules and tasks refer to them by those ids, and boards made from the
+// template get new ones. Columns are in board order.
+type BoardTemplateContent struct {
+	Columns     []TemplateColumn     `json:"columns"`
+	Labels      []TemplateLabel      `json:"labels"`
+	Fields      []TemplateField      `json:"fields"`
+	Automations []TemplateAutomation `json:"automations"`
+	Tasks       []TemplateTask       `json:"tasks"`
+}
+
+type TemplateColumn struct {
+	ID        int64  `json:"id"`
+	Title     string `json:"title"`
+	Kind      string `json:"kind"`
+	WIPLimit  *int   `json:"wip_limit,omitempty"`
+	WIPPolicy string `json:"wip_policy"`
+}
+
+type TemplateLabel struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+type TemplateField struct {
+	ID      int64    `json:"id"`
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Options []string `json:"options"`
+}
+
+type TemplateAutomation struct {
+	Name       string               `json:"name"`
+	Enabled    bool                 `json:"enabled"`
+	Trigger    AutomationTrigger    `json:"trigger"`
+	Conditions AutomationConditions `json:"conditions"`
+	Actions    []AutomationAction   `json:"actions"`
+}
+
+// TemplateTask is a seed task, created at the end of its column. Subtasks,
+// assignees and field values are not kept.
+type TemplateTask struct {
+	ColumnID    int64    `json:"column_id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Priority    string   `json:"priority"`
+	LabelIDs    []int64  `json:"label_ids"`
+	Checklist   []string `json:"checklist"`
+}
+
+// BoardTemplateRequest saves a board as a template; IncludeTasks keeps its
+// top-level tasks as seed tasks.
+type BoardTemplateRequest struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	IncludeTasks bool   `json:"include_tasks"`
+}
+
+// TaskTemplate prefills tasks created on its board. LabelIDs that have been
+// deleted from the board since are left out.
+type TaskTemplate struct {
+	ID          int64     `json:"id"`
+	BoardID     int64     `json:"board_id"`
+	CreatedBy   *int64    `json:"created_by"`
+	Name        string    `json:"name"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	Priority    string    `json:"priority"`
+	LabelIDs    []int64   `json:"label_ids"`
+	Checklist   []string  `json:"checklist"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type TaskTemplateRequest struct {
+	Name        *string   `json:"name"`
+	Title       *string   `json:"title"`
+	Description *string   `json:"description"`
+	Priority    *string   `json:"priority"`
+	LabelIDs    *[]int64  `json:"label_ids"`
+	Checklist   *[]string `json:"checklist"`
+}
+
+// TaskFromTemplateRequest creates a task from a template in ColumnID. Title
+// and Deadline override or add to the template.
+type TaskFromTemplateRequest struct {
+	ColumnID int64      `json:"column_id"`
+	Title    *string    `json:"title"`
+	Deadline *time.Time `json:"deadline"`
+	ParentID *int64     `json:"parent_id"`
+}
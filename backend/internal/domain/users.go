@@ -12,6 +12,22 @@ type User struct {
 	PasswordHash string    `json:"-"`
 	CreatedAt    time.Time `json:"created_at"`
 	TwoFAEnabled bool      `json:"two_fa_enabled"`
+	CaptchaToken string    `json:"captcha_token,omitempty"`
+
+	PasswordResetRequired bool `json:"-"`
+}
+
+// ClientInfo describes where a request came from. ImpersonatorID is set
+// when an admin made it with an impersonation token.
+type ClientInfo struct {
+	IP             string
+	UserAgent      string
+	ImpersonatorID int64
+}
+
+type UnlockRequest struct {
+	Email string `json:"email"`
+	IP    string `json:"ip"`
 }
 
 type TwoFaCodes struct {
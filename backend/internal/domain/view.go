@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+const (
+	TaskSortPosition = "position"
+	TaskSortDeadline = "deadline"
+	TaskSortPriority = "priority"
+	TaskSortCreated  = "created_at"
+	TaskSortUpdated  = "updated_at"
+	TaskSortTitle    = "title"
+)
+
+const (
+	TaskGroupStatus   = "status"
+	TaskGroupAssignee = "assignee"
+	TaskGroupPriority = "priority"
+	TaskGroupLabel    = "label"
+	TaskGroupNone     = "none"
+)
+
+// BoardQuery is how a board is listed: which tasks, in which order and
+// grouped by what. The default sorts by position, ascending, and groups by
+// status, i.e. into the board's columns.
+type BoardQuery struct {
+	Filter   TaskFilter `json:"filter"`
+	SortBy   string     `json:"sort_by,omitempty"`
+	SortDesc bool       `json:"sort_desc,omitempty"`
+	GroupBy  string     `json:"group_by,omitempty"`
+}
+
+// TaskGroup holds the tasks sharing a value of the grouping: the priority,
+// or the label or assignee id. Key is empty for tasks without one. A task
+// with several labels or assignees is in each of their groups.
+type TaskGroup struct {
+	Key   string `json:"key"`
+	Tasks []Task `json:"tasks"`
+}
+
+// BoardView is a named BoardQuery saved by UserID. Private views are only
+// seen by their owner; shared views by every member of the board.
+type BoardView struct {
+	ID        int64      `json:"id"`
+	BoardID   int64      `json:"board_id"`
+	UserID    int64      `json:"user_id"`
+	Name      string     `json:"name"`
+	Shared    bool       `json:"shared"`
+	Query     BoardQuery `json:"query"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type BoardViewRequest struct {
+	Name   *string     `json:"name"`
+	Shared *bool       `json:"shared"`
+	Query  *BoardQuery `json:"query"`
+}
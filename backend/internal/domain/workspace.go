@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+	// WorkspaceRoleGuest only sees the boards it was invited to.
+	WorkspaceRoleGuest = "guest"
+)
+
+// Workspace is a tenant: it owns boards and has its own members. Role is the
+// role of the user the workspace was loaded for.
+type Workspace struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	CreatedBy   int64     `json:"created_by,omitempty"`
+	Role        string    `json:"role"`
+	MemberCount int64     `json:"member_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type WorkspaceRequest struct {
+	Name string `json:"name"`
+}
+
+type WorkspaceMember struct {
+	UserID    int64     `json:"user_id"`
+	Username  string    `json:"username"`
+	Firstname string    `json:"firstname"`
+	Lastname  string    `json:"lastname"`
+	AvatarURL string    `json:"avatar_url,omitempty"`
+	Role      string    `json:"role"`
+	JoinedAt  time.Time `json:"joined_at"`
+}
+
+type WorkspaceMemberRoleRequest struct {
+	Role string `json:"role"`
+}
+
+type WorkspaceInvitationRequest struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type WorkspaceInvitation struct {
+	ID            int64      `json:"id"`
+	WorkspaceID   int64      `json:"workspace_id"`
+	WorkspaceName string     `json:"workspace_name,omitempty"`
+	Email         string     `json:"email"`
+	Role          string     `json:"role"`
+	InvitedBy     int64      `json:"invited_by,omitempty"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	AcceptedAt    *time.Time `json:"accepted_at,omitempty"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// CreatedWorkspaceInvitation is only returned when the invitation is made:
+// the token is not stored and cannot be shown again.
+type CreatedWorkspaceInvitation struct {
+	WorkspaceInvitation
+	Token string `json:"token"`
+	URL   string `json:"url"`
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/auth"
+	"github.com/your-team/taskmanager-chat/backend/pkg/hash"
+	"github.com/your-team/taskmanager-chat/backend/pkg/utils"
+)
+
+const (
+	accessTokenLength        = 40
+	defaultAccessTokenExpiry = 90
+	maxAccessTokenExpiry     = 365
+)
+
+var (
+	ErrAccessTokenNotFound = errors.New("access token not found")
+	ErrAccessTokenInvalid  = errors.New("invalid access token")
+)
+
+type AccessTokenStorage interface {
+	InsertAccessToken(t domain.PersonalAccessToken) (domain.PersonalAccessToken, error)
+	SelectAccessTokenByHash(tokenHash string) (domain.PersonalAccessToken, error)
+	SelectAccessTokens(userID int64) ([]domain.PersonalAccessToken, error)
+	TouchAccessToken(id int64, ip string) error
+	RevokeAccessToken(id, userID int64) (bool, error)
+	RevokeAllAccessTokens(userID int64) error
+	SelectUserForAdmin(userID int64) (domain.AdminUser, error)
+}
+
+type AccessTokenService struct {
+	storage AccessTokenStorage
+	audit   AuditRecorder
+}
+
+func NewAccessTokenService(storage AccessTokenStorage, audit AuditRecorder) *AccessTokenService {
+	return &AccessTokenService{storage: storage, audit: audit}
+}
+
+func (s *AccessTokenService) Create(userID int64, req domain.AccessTokenRequest, client domain.ClientInfo) (domain.CreatedAccessToken, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return domain.CreatedAccessToken{}, errors.New("token name is required and must be at most 100 characters")
+	}
+
+	if len(req.Scopes) == 0 {
+		return domain.CreatedAccessToken{}, errors.New("at least one scope is required")
+	}
+	for _, scope := range req.Scopes {
+		if !isKnownScope(scope) {
+			return domain.CreatedAccessToken{}, errors.New("unknown scope: " + scope)
+		}
+	}
+
+	days := req.ExpiresInDays
+	if days == 0 {
+		days = defaultAccessTokenExpiry
+	}
+	if days < 0 || days > maxAccessTokenExpiry {
+		return domain.CreatedAccessToken{}, errors.New("expires_in_days must be between 1 and 365")
+	}
+
+	secret, err := utils.GenerateRandomString(accessTokenLength)
+	if err != nil {
+		return domain.CreatedAccessToken{}, errors.New("failed to generate token")
+	}
+	plain := auth.PersonalTokenPrefix + secret
+
+	token, err := s.storage.InsertAccessToken(domain.PersonalAccessToken{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    plain[:len(auth.PersonalTokenPrefix)+4],
+		TokenHash: hash.HashToken(plain),
+		Scopes:    req.Scopes,
+		ExpiresAt: time.Now().Add(time.Duration(days) * 24 * time.Hour),
+	})
+	if err != nil {
+		return domain.CreatedAccessToken{}, err
+	}
+
+	_ = s.audit.Record(newAuditEvent(domain.AuditTokenCreated, userID, userID, client, map[string]interface{}{
+		"token_id": token.ID,
+		"name":     token.Name,
+		"scopes":   token.Scopes,
+	}))
+
+	return domain.CreatedAccessToken{PersonalAccessToken: token, Token: plain}, nil
+}
+
+func (s *AccessTokenService) List(userID int64) ([]domain.PersonalAccessToken, error) {
+	return s.storage.SelectAccessTokens(userID)
+}
+
+func (s *AccessTokenService) Revoke(userID, id int64, client domain.ClientInfo) error {
+	revoked, err := s.storage.RevokeAccessToken(id, userID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrAccessTokenNotFound
+	}
+
+	_ = s.audit.Record(newAuditEvent(domain.AuditTokenRevoked, userID, userID, client, map[string]interface{}{
+		"token_id": id,
+	}))
+	return nil
+}
+
+func (s *AccessTokenService) RevokeAll(userID int64, client domain.ClientInfo) error {
+	if err := s.storage.RevokeAllAccessTokens(userID); err != nil {
+		return err
+	}
+
+	_ = s.audit.Record(newAuditEvent(domain.AuditTokensRevoked, userID, userID, client, nil))
+	return nil
+}
+
+// AuthenticatePersonalToken resolves a raw token to its owner and scopes and
+// records the usage. Tokens of locked or deleted accounts are refused. It
+// satisfies middleware.PersonalTokenAuthenticator.
+func (s *AccessTokenService) AuthenticatePersonalToken(token, ip string) (int64, []string, error) {
+	if !auth.IsPersonalToken(token) {
+		return 0, nil, ErrAccessTokenInvalid
+	}
+
+	stored, err := s.storage.SelectAccessTokenByHash(hash.HashToken(token))
+	if err != nil {
+		return 0, nil, ErrAccessTokenInvalid
+	}
+
+	if !stored.RevokedAt.IsZero() {
+		return 0, nil, ErrAccessTokenInvalid
+	}
+
+	if !stored.ExpiresAt.IsZero() && time.Now().After(stored.ExpiresAt) {
+		return 0, nil, ErrAccessTokenInvalid
+	}
+
+	owner, err := s.storage.SelectUserForAdmin(stored.UserID)
+	if errors.Is(err, psql.ErrUserNotFound) {
+		return 0, nil, ErrAccessTokenInvalid
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	if owner.DeletedAt != nil || owner.BlockedUntil != nil && time.Now().Before(*owner.BlockedUntil) {
+		return 0, nil, ErrAccessTokenInvalid
+	}
+
+	if err := s.storage.TouchAccessToken(stored.ID, ip); err != nil {
+		return 0, nil, err
+	}
+
+	return stored.UserID, stored.Scopes, nil
+}
+
+func isKnownScope(scope string) bool {
+	for _, known := range domain.AccessTokenScopes {
+		if scope == known {
+			return true
+		}
+	}
+	return false
+}
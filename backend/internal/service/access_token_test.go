@@ -0,0 +1,151 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/auth"
+	"github.com/your-team/taskmanager-chat/backend/pkg/hash"
+)
+
+const testPersonalToken = auth.PersonalTokenPrefix + "0123456789abcdef0123456789abcdef01234567"
+
+// tokenStorage holds one user and one personal access token for them. It
+// serves both the access token and the admin service.
+type tokenStorage struct {
+	user  domain.AdminUser
+	token domain.PersonalAccessToken
+}
+
+func newTokenStorage() *tokenStorage {
+	return &tokenStorage{
+		user: domain.AdminUser{ID: 7, Email: "ann@example.com", Role: domain.RoleUser},
+		token: domain.PersonalAccessToken{
+			ID:        1,
+			UserID:    7,
+			TokenHash: hash.HashToken(testPersonalToken),
+			Scopes:    []string{domain.ScopeBoardsRead},
+			ExpiresAt: time.Now().Add(time.Hour),
+		},
+	}
+}
+
+func (s *tokenStorage) InsertAccessToken(t domain.PersonalAccessToken) (domain.PersonalAccessToken, error) {
+	return t, nil
+}
+
+func (s *tokenStorage) SelectAccessTokenByHash(tokenHash string) (domain.PersonalAccessToken, error) {
+	if tokenHash != s.token.TokenHash {
+		return domain.PersonalAccessToken{}, errors.New("no rows in result set")
+	}
+	return s.token, nil
+}
+
+func (s *tokenStorage) SelectAccessTokens(userID int64) ([]domain.PersonalAccessToken, error) {
+	return []domain.PersonalAccessToken{s.token}, nil
+}
+
+func (s *tokenStorage) TouchAccessToken(id int64, ip string) error { return nil }
+
+func (s *tokenStorage) RevokeAccessToken(id, userID int64) (bool, error) {
+	s.token.RevokedAt = time.Now()
+	return true, nil
+}
+
+func (s *tokenStorage) RevokeAllAccessTokens(userID int64) error {
+	s.token.RevokedAt = time.Now()
+	return nil
+}
+
+func (s *tokenStorage) SelectUserForAdmin(userID int64) (domain.AdminUser, error) {
+	if userID != s.user.ID {
+		return domain.AdminUser{}, psql.ErrUserNotFound
+	}
+	return s.user, nil
+}
+
+func (s *tokenStorage) SelectUsersForAdmin(query string, limit, offset int) (domain.AdminUserList, error) {
+	return domain.AdminUserList{Users: []domain.AdminUser{s.user}, Total: 1}, nil
+}
+
+func (s *tokenStorage) UpdateRole(userID int64, role string) error             { return nil }
+func (s *tokenStorage) RequirePasswordReset(userID int64) error                { return nil }
+func (s *tokenStorage) RenovationTwoFAStatus(userID int64, enabled bool) error { return nil }
+func (s *tokenStorage) ResetFailedAttempts(email string) error                 { return nil }
+func (s *tokenStorage) ClearLoginAttempts(email, ip string) error              { return nil }
+func (s *tokenStorage) SelectLoginAttempts(email string, limit int) ([]domain.LoginAttempt, error) {
+	return nil, nil
+}
+
+func (s *tokenStorage) RevokeSessions(userID int64) error {
+	return s.RevokeAllAccessTokens(userID)
+}
+
+func (s *tokenStorage) BlockUser(email, blockedUntil string) error {
+	until, err := time.Parse(time.RFC3339, blockedUntil)
+	if err != nil {
+		return err
+	}
+	s.user.BlockedUntil = &until
+	return nil
+}
+
+type discardAudit struct{}
+
+func (discardAudit) Record(domain.AuditEvent) error { return nil }
+
+func TestAuthenticatePersonalToken(t *testing.T) {
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Hour)
+	tests := []struct {
+		name   string
+		change func(s *tokenStorage)
+		ok     bool
+	}{
+		{"active", func(*tokenStorage) {}, true},
+		{"revoked", func(s *tokenStorage) { s.token.RevokedAt = past }, false},
+		{"expired", func(s *tokenStorage) { s.token.ExpiresAt = past }, false},
+		{"owner locked", func(s *tokenStorage) { s.user.BlockedUntil = &future }, false},
+		{"owner lock ran out", func(s *tokenStorage) { s.user.BlockedUntil = &past }, true},
+		{"owner deleted", func(s *tokenStorage) { s.user.DeletedAt = &past }, false},
+		{"owner gone", func(s *tokenStorage) { s.user.ID = 8 }, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newTokenStorage()
+			tt.change(storage)
+
+			userID, scopes, err := NewAccessTokenService(storage, discardAudit{}).AuthenticatePersonalToken(testPersonalToken, "")
+			if !tt.ok {
+				if !errors.Is(err, ErrAccessTokenInvalid) {
+					t.Errorf("err = %v, want ErrAccessTokenInvalid", err)
+				}
+				return
+			}
+			if err != nil || userID != 7 || len(scopes) != 1 {
+				t.Errorf("got user %d, scopes %v, err %v; want user 7 with one scope", userID, scopes, err)
+			}
+		})
+	}
+}
+
+func TestLockRefusesPersonalTokens(t *testing.T) {
+	storage := newTokenStorage()
+	tokens := NewAccessTokenService(storage, discardAudit{})
+	if _, _, err := tokens.AuthenticatePersonalToken(testPersonalToken, ""); err != nil {
+		t.Fatalf("token refused before the lock: %v", err)
+	}
+
+	admin := NewAdminService(storage, nil, discardAudit{})
+	if err := admin.Lock(domain.AdminActor{UserID: 1}, 7, 30); err != nil {
+		t.Fatal(err)
+	}
+	if storage.token.RevokedAt.IsZero() {
+		t.Error("Lock left the personal access token unrevoked")
+	}
+	if _, _, err := tokens.AuthenticatePersonalToken(testPersonalToken, ""); !errors.Is(err, ErrAccessTokenInvalid) {
+		t.Errorf("token of a locked user: err = %v, want ErrAccessTokenInvalid", err)
+	}
+}
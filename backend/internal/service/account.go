@@ -0,0 +1,231 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/pkg/config"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const deletedUserName = "Deleted user"
+
+var (
+	ErrInvalidPassword     = errors.New("invalid password")
+	ErrNoDeletionScheduled = errors.New("no account deletion is scheduled")
+)
+
+type AccountStorage interface {
+	SelectUserByID(userID int64) (domain.User, error)
+	SelectTasksByUserID(userID int64) ([]domain.Task, error)
+	SelectCommentsByUserID(userID int64) ([]domain.Comment, error)
+	GetNotifications(userID int64) ([]domain.Notification, error)
+	SelectAccessTokens(userID int64) ([]domain.PersonalAccessToken, error)
+	ScheduleDeletion(userID int64, at time.Time) error
+	CancelDeletion(userID int64) (bool, error)
+	RevokeSessions(userID int64) error
+	SelectUsersDueForDeletion(now time.Time) ([]domain.User, error)
+	AnonymizeUser(user domain.User) error
+}
+
+type MessageArchive interface {
+	GetMessagesByUserID(ctx context.Context, userID int64) ([]domain.Message, error)
+	AnonymizeUserMessages(ctx context.Context, userID int64, username string) error
+}
+
+type AccountService struct {
+	storage  AccountStorage
+	messages MessageArchive
+	profiles *ProfileService
+	audit    AuditRecorder
+	grace    time.Duration
+	logger   *logging.Logger
+}
+
+func NewAccountService(storage AccountStorage, messages MessageArchive, profiles *ProfileService, audit AuditRecorder, cfg config.AccountConfig, logger *logging.Logger) *AccountService {
+	return &AccountService{
+		storage:  storage,
+		messages: messages,
+		profiles: profiles,
+		audit:    audit,
+		grace:    cfg.AccountDeletionGrace,
+		logger:   logger,
+	}
+}
+
+func (s *AccountService) Export(ctx context.Context, userID int64, client domain.ClientInfo) (domain.AccountExport, error) {
+	profile, err := s.profiles.Get(userID)
+	if err != nil {
+		return domain.AccountExport{}, err
+	}
+
+	tasks, err := s.storage.SelectTasksByUserID(userID)
+	if err != nil {
+		return domain.AccountExport{}, err
+	}
+
+	comments, err := s.storage.SelectCommentsByUserID(userID)
+	if err != nil {
+		return domain.AccountExport{}, err
+	}
+
+	notifications, err := s.storage.GetNotifications(userID)
+	if err != nil {
+		return domain.AccountExport{}, err
+	}
+
+	tokens, err := s.storage.SelectAccessTokens(userID)
+	if err != nil {
+		return domain.AccountExport{}, err
+	}
+
+	messages, err := s.messages.GetMessagesByUserID(ctx, userID)
+	if err != nil {
+		return domain.AccountExport{}, err
+	}
+
+	_ = s.audit.Record(newAuditEvent(domain.AuditAccountExported, userID, userID, client, nil))
+
+	return domain.AccountExport{
+		ExportedAt:    time.Now().UTC(),
+		Profile:       profile,
+		Tasks:         tasks,
+		Comments:      comments,
+		Notifications: notifications,
+		AccessTokens:  tokens,
+		Messages:      messages,
+	}, nil
+}
+
+// WriteExportZip writes the export as a ZIP archive with one JSON file per
+// data set.
+func (s *AccountService) WriteExportZip(w io.Writer, export domain.AccountExport) error {
+	archive := zip.NewWriter(w)
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"tasks.json", export.Tasks},
+		{"comments.json", export.Comments},
+		{"notifications.json", export.Notifications},
+		{"access_tokens.json", export.AccessTokens},
+		{"messages.json", export.Messages},
+	}
+
+	for _, file := range files {
+		f, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(file.data); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// RequestDeletion schedules the account for anonymization after the grace
+// period and signs the user out everywhere. Logging in again and cancelling
+// within the grace period keeps the account.
+func (s *AccountService) RequestDeletion(userID int64, password string, client domain.ClientInfo) (domain.AccountDeletion, error) {
+	user, err := s.storage.SelectUserByID(userID)
+	if err != nil {
+		return domain.AccountDeletion{}, err
+	}
+	if user.ID == 0 {
+		return domain.AccountDeletion{}, ErrUserNotFound
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return domain.AccountDeletion{}, ErrInvalidPassword
+	}
+
+	scheduledAt := time.Now().Add(s.grace).UTC()
+	if err := s.storage.ScheduleDeletion(userID, scheduledAt); err != nil {
+		return domain.AccountDeletion{}, err
+	}
+
+	if err := s.storage.RevokeSessions(userID); err != nil {
+		return domain.AccountDeletion{}, err
+	}
+
+	_ = s.audit.Record(newAuditEvent(domain.AuditDeletionRequested, userID, userID, client, map[string]interface{}{
+		"deletion_scheduled_at": scheduledAt,
+	}))
+
+	return domain.AccountDeletion{DeletionScheduledAt: scheduledAt}, nil
+}
+
+func (s *AccountService) CancelDeletion(userID int64, client domain.ClientInfo) error {
+	cancelled, err := s.storage.CancelDeletion(userID)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return ErrNoDeletionScheduled
+	}
+
+	_ = s.audit.Record(newAuditEvent(domain.AuditDeletionCancelled, userID, userID, client, nil))
+	return nil
+}
+
+func (s *AccountService) StartDeletionWorker(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	s.purgeDueAccounts(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.purgeDueAccounts(ctx)
+		}
+	}
+}
+
+func (s *AccountService) purgeDueAccounts(ctx context.Context) {
+	users, err := s.storage.SelectUsersDueForDeletion(time.Now())
+	if err != nil {
+		s.logger.Errorf("Failed to list accounts due for deletion: %v", err)
+		return
+	}
+
+	for _, user := range users {
+		if err := s.anonymize(ctx, user); err != nil {
+			s.logger.Errorf("Failed to delete account %d: %v", user.ID, err)
+			continue
+		}
+		_ = s.audit.Record(newAuditEvent(domain.AuditAccountDeleted, 0, user.ID, domain.ClientInfo{}, nil))
+		s.logger.Infof("Deleted account %d", user.ID)
+	}
+}
+
+func (s *AccountService) anonymize(ctx context.Context, user domain.User) error {
+	if err := s.messages.AnonymizeUserMessages(ctx, user.ID, deletedUserName); err != nil {
+		return err
+	}
+
+	if err := s.profiles.DeleteAvatar(user.ID); err != nil {
+		return err
+	}
+
+	return s.storage.AnonymizeUser(user)
+}
@@ -0,0 +1,249 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+const (
+	defaultActivityPageSize = 50
+	maxActivityPageSize     = 200
+	activityExcerptLength   = 200
+)
+
+type TaskActivityStorage interface {
+	InsertTaskActivity(entries []domain.TaskActivity) ([]domain.TaskActivity, error)
+}
+
+// ActivityListener hears about timeline entries once they are stored.
+type ActivityListener interface {
+	ActivityRecorded(entries []domain.TaskActivity)
+}
+
+type ActivityStorage interface {
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+	SelectTask(userID, taskID int64) (domain.Task, error)
+	SelectTaskActivity(taskID, beforeID int64, limit int) ([]domain.TaskActivity, error)
+}
+
+// ActivityService reads the timelines of tasks. The entries are written by
+// the services that make the changes.
+type ActivityService struct {
+	storage ActivityStorage
+	logger  *logging.Logger
+}
+
+func NewActivityService(storage ActivityStorage, logger *logging.Logger) *ActivityService {
+	return &ActivityService{storage: storage, logger: logger}
+}
+
+// List returns a page of the task's timeline, newest first. BeforeID
+// continues a previous page.
+func (s *ActivityService) List(userID, taskID, beforeID int64, limit int) (domain.TaskActivityPage, error) {
+	task, err := s.storage.SelectTask(userID, taskID)
+	if errors.Is(err, psql.ErrTaskNotFound) {
+		return domain.TaskActivityPage{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return domain.TaskActivityPage{}, err
+	}
+	if _, err := s.storage.SelectBoard(userID, task.BoardID); err != nil {
+		if errors.Is(err, psql.ErrBoardNotFound) {
+			return domain.TaskActivityPage{}, ErrBoardNotFound
+		}
+		return domain.TaskActivityPage{}, err
+	}
+
+	if limit <= 0 {
+		limit = defaultActivityPageSize
+	}
+	if limit > maxActivityPageSize {
+		limit = maxActivityPageSize
+	}
+
+	entries, err := s.storage.SelectTaskActivity(taskID, beforeID, limit)
+	if err != nil {
+		return domain.TaskActivityPage{}, err
+	}
+
+	page := domain.TaskActivityPage{Activity: entries}
+	if len(entries) == limit {
+		page.NextBeforeID = entries[len(entries)-1].ID
+	}
+	return page, nil
+}
+
+// taskActivity appends entries to task timelines. The entries describe a
+// change that has happened already, so a failure is logged and not returned.
+// The listener, if any, is told about the stored entries.
+type taskActivity struct {
+	storage  TaskActivityStorage
+	listener ActivityListener
+	logger   *logging.Logger
+}
+
+// record stores the entries and returns them as stored, or nil if that
+// failed.
+func (a taskActivity) record(entries ...domain.TaskActivity) []domain.TaskActivity {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	recorded, err := a.storage.InsertTaskActivity(entries)
+	if err != nil {
+		a.logger.Errorf("Failed to record activity of task %d: %v", entries[0].TaskID, err)
+		return nil
+	}
+	if a.listener != nil {
+		a.listener.ActivityRecorded(recorded)
+	}
+	return recorded
+}
+
+// recordOne stores a single entry, returning nil if that failed.
+func (a taskActivity) recordOne(entry domain.TaskActivity) *domain.TaskActivity {
+	recorded := a.record(entry)
+	if len(recorded) == 0 {
+		return nil
+	}
+	return &recorded[0]
+}
+
+// activityEntry builds a timeline entry. A nil before or after is left out;
+// anything else, including a nil pointer, is stored as JSON.
+func activityEntry(actorID, taskID, boardID int64, activityType, field string, before, after interface{}) domain.TaskActivity {
+	return domain.TaskActivity{
+		TaskID:  taskID,
+		BoardID: boardID,
+		ActorID: actorID,
+		Type:    activityType,
+		Field:   field,
+		Before:  activityValue(before),
+		After:   activityValue(after),
+	}
+}
+
+func activityValue(v interface{}) json.RawMessage {
+	if v == nil {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return raw
+}
+
+func fieldChange(actorID int64, task domain.Task, field string, before, after interface{}) domain.TaskActivity {
+	return activityEntry(actorID, task.ID, task.BoardID, domain.ActivityFieldChanged, field, before, after)
+}
+
+// taskChanges lists the edits between two states of a task.
+func taskChanges(actorID int64, before, after domain.Task) []domain.TaskActivity {
+	var changes []domain.TaskActivity
+	add := func(field string, from, to interface{}) {
+		changes = append(changes, fieldChange(actorID, after, field, from, to))
+	}
+
+	if before.Title != after.Title {
+		add(domain.ActivityFieldTitle, before.Title, after.Title)
+	}
+	if before.Description != after.Description {
+		add(domain.ActivityFieldDescription, before.Description, after.Description)
+	}
+	if before.ColumnID != after.ColumnID {
+		add(domain.ActivityFieldStatus, taskStatus(before), taskStatus(after))
+	}
+	if !sameTime(before.Deadline, after.Deadline) {
+		add(domain.ActivityFieldDeadline, before.Deadline, after.Deadline)
+	}
+	if before.Priority != after.Priority {
+		add(domain.ActivityFieldPriority, before.Priority, after.Priority)
+	}
+	if !sameIDs(before.LabelIDs, after.LabelIDs) {
+		add(domain.ActivityFieldLabels, sortedIDs(before.LabelIDs), sortedIDs(after.LabelIDs))
+	}
+	if !sameIDs(before.AssigneeIDs, after.AssigneeIDs) {
+		add(domain.ActivityFieldAssignees, sortedIDs(before.AssigneeIDs), sortedIDs(after.AssigneeIDs))
+	}
+	if !sameID(before.ParentID, after.ParentID) {
+		add(domain.ActivityFieldParent, before.ParentID, after.ParentID)
+	}
+
+	fieldIDs := make([]int64, 0, len(before.Fields)+len(after.Fields))
+	for id := range before.Fields {
+		fieldIDs = append(fieldIDs, id)
+	}
+	for id := range after.Fields {
+		if _, ok := before.Fields[id]; !ok {
+			fieldIDs = append(fieldIDs, id)
+		}
+	}
+	sort.Slice(fieldIDs, func(i, j int) bool { return fieldIDs[i] < fieldIDs[j] })
+	for _, id := range fieldIDs {
+		from, to := activityValue(before.Fields[id]), activityValue(after.Fields[id])
+		if !bytes.Equal(from, to) {
+			add(fmt.Sprintf("fields.%d", id), from, to)
+		}
+	}
+
+	return changes
+}
+
+func taskStatus(task domain.Task) domain.TaskStatusValue {
+	return domain.TaskStatusValue{ColumnID: task.ColumnID, Status: task.Status}
+}
+
+// activityComment describes a comment by its id and the start of its body.
+func activityComment(comment domain.Comment) domain.ActivityComment {
+	excerpt := strings.TrimSpace(comment.Body)
+	if runes := []rune(excerpt); len(runes) > activityExcerptLength {
+		excerpt = strings.TrimSpace(string(runes[:activityExcerptLength])) + "…"
+	}
+	return domain.ActivityComment{CommentID: comment.ID, Excerpt: excerpt}
+}
+
+func activityAttachment(attachment domain.Attachment) domain.ActivityAttachment {
+	return domain.ActivityAttachment{AttachmentID: attachment.ID, Filename: attachment.Filename}
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// sameIDs compares two id lists as sets.
+func sameIDs(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[int64]bool, len(a))
+	for _, id := range a {
+		seen[id] = true
+	}
+	for _, id := range b {
+		if !seen[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedIDs returns a sorted copy of the list, never nil, so it is stored as
+// [].
+func sortedIDs(v []int64) []int64 {
+	sorted := append([]int64{}, v...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/auth"
+)
+
+const (
+	defaultAdminPageSize  = 50
+	maxAdminPageSize      = 200
+	defaultLockMinutes    = 60
+	maxLockMinutes        = 30 * 24 * 60
+	impersonationDuration = 15 * time.Minute
+)
+
+var (
+	ErrImpersonationNotAllowed   = errors.New("admins and deleted accounts cannot be impersonated")
+	ErrOperatorCannotImpersonate = errors.New("impersonation requires an admin account")
+)
+
+type AdminStorage interface {
+	SelectUsersForAdmin(query string, limit, offset int) (domain.AdminUserList, error)
+	SelectUserForAdmin(userID int64) (domain.AdminUser, error)
+	UpdateRole(userID int64, role string) error
+	RequirePasswordReset(userID int64) error
+	RevokeSessions(userID int64) error
+	RenovationTwoFAStatus(userID int64, enabled bool) error
+	BlockUser(email, blockedUntil string) error
+	ResetFailedAttempts(email string) error
+	ClearLoginAttempts(email, ip string) error
+	SelectLoginAttempts(email string, limit int) ([]domain.LoginAttempt, error)
+}
+
+type AdminService struct {
+	storage AdminStorage
+	tokens  *auth.Manager
+	audit   AuditRecorder
+}
+
+func NewAdminService(storage AdminStorage, tokens *auth.Manager, audit AuditRecorder) *AdminService {
+	return &AdminService{storage: storage, tokens: tokens, audit: audit}
+}
+
+func (s *AdminService) ListUsers(query string, limit, offset int) (domain.AdminUserList, error) {
+	return s.storage.SelectUsersForAdmin(strings.TrimSpace(query), pageSize(limit), max(offset, 0))
+}
+
+func (s *AdminService) GetUser(userID int64) (domain.AdminUser, error) {
+	user, err := s.storage.SelectUserForAdmin(userID)
+	if errors.Is(err, psql.ErrUserNotFound) {
+		return domain.AdminUser{}, ErrUserNotFound
+	}
+	return user, err
+}
+
+// Lock blocks logins for the given minutes and signs the user out
+// everywhere, revoking refresh tokens and personal access tokens.
+func (s *AdminService) Lock(actor domain.AdminActor, userID int64, minutes int) error {
+	if minutes == 0 {
+		minutes = defaultLockMinutes
+	}
+	if minutes < 0 || minutes > maxLockMinutes {
+		return errors.New("minutes must be between 1 and 43200")
+	}
+
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return err
+	}
+
+	until := time.Now().Add(time.Duration(minutes) * time.Minute)
+	if err := s.storage.BlockUser(user.Email, until.Format(time.RFC3339)); err != nil {
+		return err
+	}
+	if err := s.storage.RevokeSessions(userID); err != nil {
+		return err
+	}
+
+	return s.record(actor, domain.AuditAdminLock, userID, map[string]interface{}{
+		"blocked_until": until.UTC(),
+	})
+}
+
+func (s *AdminService) Unlock(actor domain.AdminActor, userID int64) error {
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.storage.ResetFailedAttempts(user.Email); err != nil {
+		return err
+	}
+	if err := s.storage.ClearLoginAttempts(user.Email, ""); err != nil {
+		return err
+	}
+
+	return s.record(actor, domain.AuditAdminUnlock, userID, nil)
+}
+
+// ForcePasswordReset signs the user out everywhere. Their next login only
+// yields a reset token for POST /api/auth/reset-password.
+func (s *AdminService) ForcePasswordReset(actor domain.AdminActor, userID int64) error {
+	if _, err := s.GetUser(userID); err != nil {
+		return err
+	}
+
+	if err := s.storage.RequirePasswordReset(userID); err != nil {
+		return err
+	}
+	if err := s.storage.RevokeSessions(userID); err != nil {
+		return err
+	}
+
+	return s.record(actor, domain.AuditAdminForceReset, userID, nil)
+}
+
+func (s *AdminService) DisableTwoFA(actor domain.AdminActor, userID int64) error {
+	if _, err := s.GetUser(userID); err != nil {
+		return err
+	}
+
+	if err := s.storage.RenovationTwoFAStatus(userID, false); err != nil {
+		return err
+	}
+
+	return s.record(actor, domain.AuditAdminDisableTwoFA, userID, nil)
+}
+
+func (s *AdminService) SetRole(actor domain.AdminActor, userID int64, role string) error {
+	if role != domain.RoleUser && role != domain.RoleAdmin {
+		return errors.New("role must be 'user' or 'admin'")
+	}
+	if actor.UserID == userID && role != domain.RoleAdmin {
+		return errors.New("admins cannot revoke their own admin role")
+	}
+
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.storage.UpdateRole(userID, role); err != nil {
+		return err
+	}
+
+	return s.record(actor, domain.AuditRoleChanged, userID, map[string]interface{}{
+		"from": user.Role,
+		"to":   role,
+	})
+}
+
+// Impersonate issues a short-lived access token for the user. The token
+// names the admin in its imp claim and is not refreshable.
+func (s *AdminService) Impersonate(actor domain.AdminActor, userID int64, reason string) (domain.ImpersonationToken, error) {
+	if actor.UserID == 0 {
+		return domain.ImpersonationToken{}, ErrOperatorCannotImpersonate
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return domain.ImpersonationToken{}, errors.New("a reason is required to impersonate a user")
+	}
+
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return domain.ImpersonationToken{}, err
+	}
+	if user.Role == domain.RoleAdmin || user.DeletedAt != nil {
+		return domain.ImpersonationToken{}, ErrImpersonationNotAllowed
+	}
+
+	expiresAt := time.Now().Add(impersonationDuration)
+	token, err := s.tokens.GenerateImpersonationToken(userID, actor.UserID, impersonationDuration)
+	if err != nil {
+		return domain.ImpersonationToken{}, err
+	}
+
+	if err := s.record(actor, domain.AuditAdminImpersonate, userID, map[string]interface{}{
+		"reason":     reason,
+		"expires_at": expiresAt.UTC(),
+	}); err != nil {
+		return domain.ImpersonationToken{}, err
+	}
+
+	return domain.ImpersonationToken{AccessToken: token, ExpiresAt: expiresAt}, nil
+}
+
+func (s *AdminService) LoginAttempts(userID int64, limit int) ([]domain.LoginAttempt, error) {
+	user, err := s.GetUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.storage.SelectLoginAttempts(user.Email, pageSize(limit))
+}
+
+// record writes an admin action to the audit log. Unlike the security events
+// recorded on behalf of users, a failure here fails the request: admin
+// actions must not go unaudited.
+func (s *AdminService) record(actor domain.AdminActor, eventType string, targetUserID int64, details map[string]interface{}) error {
+	return s.audit.Record(newAuditEvent(eventType, actor.UserID, targetUserID, actor.Client, details))
+}
+
+func pageSize(limit int) int {
+	if limit <= 0 {
+		return defaultAdminPageSize
+	}
+	if limit > maxAdminPageSize {
+		return maxAdminPageSize
+	}
+	return limit
+}
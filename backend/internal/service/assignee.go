@@ -0,0 +1,213 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+var (
+	ErrNotBoardMember   = errors.New("the user is not a member of the board")
+	ErrAssigneeNotFound = errors.New("the user is not assigned to the task")
+	ErrWatcherNotFound  = errors.New("the user is not watching the task")
+	ErrWatcherForbidden = errors.New("only editors can change who else watches a task")
+)
+
+type AssigneeStorage interface {
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+	SelectTask(userID, taskID int64) (domain.Task, error)
+	IsBoardMember(boardID, userID int64) (bool, error)
+	AddTaskAssignee(taskID, userID, assignedBy int64) (bool, error)
+	RemoveTaskAssignee(taskID, userID int64) (bool, error)
+	SelectTaskAssignees(taskID int64) ([]domain.TaskAssignee, error)
+	AddTaskWatcher(taskID, userID int64) error
+	RemoveTaskWatcher(taskID, userID int64) (bool, error)
+	SelectTaskWatchers(taskID int64) ([]domain.TaskWatcher, error)
+	BoardRevisionStorage
+	TaskActivityStorage
+}
+
+// AssigneeService manages who works on and who follows a task. Editors
+// assign board members and may add or remove any watcher; every member can
+// watch and unwatch a task for themselves.
+type AssigneeService struct {
+	storage       AssigneeStorage
+	notifications NotificationCreator
+	events        boardEvents
+	activity      taskActivity
+	logger        *logging.Logger
+}
+
+func NewAssigneeService(storage AssigneeStorage, notifications NotificationCreator, events BoardEventPublisher, listener ActivityListener, logger *logging.Logger) *AssigneeService {
+	return &AssigneeService{
+		storage:       storage,
+		notifications: notifications,
+		events:        boardEvents{storage: storage, publisher: events, logger: logger},
+		activity:      taskActivity{storage: storage, listener: listener, logger: logger},
+		logger:        logger,
+	}
+}
+
+func (s *AssigneeService) Assignees(userID, taskID int64) ([]domain.TaskAssignee, error) {
+	if _, err := s.task(userID, taskID, domain.BoardRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectTaskAssignees(taskID)
+}
+
+// Assign adds assigneeID to the task and notifies them. Assigning someone
+// twice is not an error.
+func (s *AssigneeService) Assign(userID, taskID, assigneeID int64) ([]domain.TaskAssignee, error) {
+	task, err := s.task(userID, taskID, domain.BoardRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.member(task.BoardID, assigneeID); err != nil {
+		return nil, err
+	}
+
+	added, err := s.storage.AddTaskAssignee(taskID, assigneeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if added {
+		before := sortedIDs(task.AssigneeIDs)
+		after := sortedIDs(append(before, assigneeID))
+		activity := s.activity.recordOne(fieldChange(userID, task, domain.ActivityFieldAssignees, before, after))
+		if assigneeID != userID {
+			s.notifyAssigned(task, assigneeID, activity)
+		}
+		s.publishTask(userID, task.BoardID, taskID)
+	}
+
+	return s.storage.SelectTaskAssignees(taskID)
+}
+
+func (s *AssigneeService) Unassign(userID, taskID, assigneeID int64) error {
+	task, err := s.task(userID, taskID, domain.BoardRoleEditor)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.storage.RemoveTaskAssignee(taskID, assigneeID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrAssigneeNotFound
+	}
+
+	before := sortedIDs(task.AssigneeIDs)
+	after := []int64{}
+	for _, id := range before {
+		if id != assigneeID {
+			after = append(after, id)
+		}
+	}
+	s.activity.record(fieldChange(userID, task, domain.ActivityFieldAssignees, before, after))
+	s.publishTask(userID, task.BoardID, taskID)
+	return nil
+}
+
+func (s *AssigneeService) Watchers(userID, taskID int64) ([]domain.TaskWatcher, error) {
+	if _, err := s.task(userID, taskID, domain.BoardRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectTaskWatchers(taskID)
+}
+
+// Watch adds watcherID to the watchers of the task. Watching twice is not an
+// error.
+func (s *AssigneeService) Watch(userID, taskID, watcherID int64) ([]domain.TaskWatcher, error) {
+	task, err := s.watcherTask(userID, taskID, watcherID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.member(task.BoardID, watcherID); err != nil {
+		return nil, err
+	}
+
+	if err := s.storage.AddTaskWatcher(taskID, watcherID); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectTaskWatchers(taskID)
+}
+
+func (s *AssigneeService) Unwatch(userID, taskID, watcherID int64) error {
+	if _, err := s.watcherTask(userID, taskID, watcherID); err != nil {
+		return err
+	}
+
+	removed, err := s.storage.RemoveTaskWatcher(taskID, watcherID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrWatcherNotFound
+	}
+	return nil
+}
+
+func (s *AssigneeService) notifyAssigned(task domain.Task, assigneeID int64, activity *domain.TaskActivity) {
+	_, err := s.notifications.CreateNotification(domain.Notification{
+		UserID:    assigneeID,
+		TaskID:    task.ID,
+		Title:     "You were assigned",
+		Message:   fmt.Sprintf("You were assigned to %q.", task.Title),
+		Type:      "task_assigned",
+		ExpiresAt: time.Now().Add(30 * 24 * time.Hour),
+		Activity:  activity,
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to notify user %d of assignment to task %d: %v", assigneeID, task.ID, err)
+	}
+}
+
+// publishTask sends the task with its new assignees to the board.
+func (s *AssigneeService) publishTask(userID, boardID, taskID int64) {
+	s.events.publishLatest(userID, boardID, domain.BoardEventTaskUpdated, taskLoader(s.storage, userID, taskID))
+}
+
+// watcherTask loads the task for a change to watcherID's subscription:
+// members manage their own, editors anyone's.
+func (s *AssigneeService) watcherTask(userID, taskID, watcherID int64) (domain.Task, error) {
+	if watcherID == userID {
+		return s.task(userID, taskID, domain.BoardRoleViewer)
+	}
+
+	task, err := s.task(userID, taskID, domain.BoardRoleEditor)
+	if errors.Is(err, ErrBoardForbidden) {
+		return domain.Task{}, ErrWatcherForbidden
+	}
+	return task, err
+}
+
+func (s *AssigneeService) member(boardID, userID int64) error {
+	member, err := s.storage.IsBoardMember(boardID, userID)
+	if err != nil {
+		return err
+	}
+	if !member {
+		return ErrNotBoardMember
+	}
+	return nil
+}
+
+func (s *AssigneeService) task(userID, taskID int64, minRole string) (domain.Task, error) {
+	task, err := s.storage.SelectTask(userID, taskID)
+	if errors.Is(err, psql.ErrTaskNotFound) {
+		return domain.Task{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	if _, err := boardAccess(s.storage, userID, task.BoardID, minRole); err != nil {
+		return domain.Task{}, err
+	}
+	return task, nil
+}
@@ -0,0 +1,537 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/blob"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/config"
+	"github.com/your-team/taskmanager-chat/backend/pkg/imaging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+const (
+	AttachmentContent   = "content"
+	AttachmentThumbnail = "thumbnail"
+
+	maxFilenameLength         = 255
+	maxMessageAttachments     = 10
+	maxThumbnailSourcePixels  = 40_000_000
+	attachmentCleanupInterval = time.Hour
+	// Unused blobs are kept this long, so an upload that found the content
+	// stored already cannot lose it to cleanup before it is recorded.
+	attachmentBlobGrace = time.Hour
+)
+
+var (
+	ErrAttachmentNotFound    = errors.New("attachment not found")
+	ErrAttachmentForbidden   = errors.New("only the uploader or a board editor can delete this attachment")
+	ErrAttachmentTooBig      = errors.New("attachment file is too large")
+	ErrAttachmentEmpty       = errors.New("attachment file is empty")
+	ErrAttachmentType        = errors.New("this file type cannot be attached")
+	ErrAttachmentLinkInvalid = errors.New("the download link is invalid or has expired")
+	ErrTooManyAttachments    = fmt.Errorf("a message can carry at most %d attachments", maxMessageAttachments)
+)
+
+// BlobStore keeps attachment contents under slash-separated keys.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type AttachmentStorage interface {
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+	SelectTask(userID, taskID int64) (domain.Task, error)
+	TouchAttachmentBlob(sha256 string) (bool, error)
+	InsertAttachment(a domain.Attachment) (domain.Attachment, error)
+	SelectAttachment(attachmentID int64) (domain.Attachment, error)
+	SelectTaskAttachments(taskID int64) ([]domain.Attachment, error)
+	SelectAttachmentsByIDs(attachmentIDs []int64) ([]domain.Attachment, error)
+	LinkMessageAttachments(messageID string, boardID, userID int64, attachmentIDs []int64) (int64, error)
+	DeleteAttachment(attachmentID int64) (bool, error)
+	DeleteUnreferencedAttachments(before time.Time) (int64, error)
+	DeleteUnusedAttachmentBlobs(before time.Time) ([]domain.AttachmentBlob, error)
+	BoardRevisionStorage
+	TaskActivityStorage
+}
+
+// AttachmentService stores files for tasks and chat messages. Contents are
+// kept once per SHA-256 in the blob store; images get a PNG thumbnail.
+// Downloads go through signed links, so they work without the API token,
+// e.g. in an <img> tag.
+type AttachmentService struct {
+	storage  AttachmentStorage
+	blobs    BlobStore
+	events   boardEvents
+	activity taskActivity
+	cfg      config.AttachmentConfig
+	secret   []byte
+	logger   *logging.Logger
+}
+
+func NewAttachmentService(storage AttachmentStorage, blobs BlobStore, events BoardEventPublisher, listener ActivityListener, cfg config.AttachmentConfig, logger *logging.Logger) *AttachmentService {
+	secret := []byte(cfg.AttachmentURLSecret)
+	if len(secret) == 0 {
+		logger.Warn("ATTACHMENT_URL_SECRET is not set; download links will not survive a restart")
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			logger.Fatalf("Failed to generate the attachment link secret: %v", err)
+		}
+	}
+
+	return &AttachmentService{
+		storage:  storage,
+		blobs:    blobs,
+		events:   boardEvents{storage: storage, publisher: events, logger: logger},
+		activity: taskActivity{storage: storage, listener: listener, logger: logger},
+		cfg:      cfg,
+		secret:   secret,
+		logger:   logger,
+	}
+}
+
+// UploadToBoard stores a file for a chat message on the board. It stays
+// unreferenced until a message takes it and is removed if none does.
+func (s *AttachmentService) UploadToBoard(userID, boardID int64, filename string, r io.Reader) (domain.Attachment, error) {
+	if _, err := boardAccess(s.storage, userID, boardID, domain.BoardRoleViewer); err != nil {
+		return domain.Attachment{}, err
+	}
+	return s.upload(userID, domain.Attachment{BoardID: boardID}, filename, r)
+}
+
+func (s *AttachmentService) UploadToTask(userID, taskID int64, filename string, r io.Reader) (domain.Attachment, error) {
+	task, err := s.task(userID, taskID, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	attachment, err := s.upload(userID, domain.Attachment{BoardID: task.BoardID, TaskID: &task.ID}, filename, r)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	s.activity.record(activityEntry(userID, task.ID, task.BoardID, domain.ActivityAttachmentAdded, "", nil, activityAttachment(attachment)))
+	s.publishTask(userID, task.BoardID, task.ID)
+	return attachment, nil
+}
+
+func (s *AttachmentService) Get(userID, attachmentID int64) (domain.Attachment, error) {
+	attachment, err := s.attachment(userID, attachmentID, domain.BoardRoleViewer)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	return s.signed(attachment), nil
+}
+
+func (s *AttachmentService) ListTask(userID, taskID int64) ([]domain.Attachment, error) {
+	if _, err := s.task(userID, taskID, domain.BoardRoleViewer); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.storage.SelectTaskAttachments(taskID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range attachments {
+		attachments[i] = s.signed(attachments[i])
+	}
+	return attachments, nil
+}
+
+// Delete removes an attachment. The uploader may delete their own; board
+// editors any of the board.
+func (s *AttachmentService) Delete(userID, attachmentID int64) error {
+	attachment, err := s.attachment(userID, attachmentID, domain.BoardRoleViewer)
+	if err != nil {
+		return err
+	}
+	if attachment.UploadedBy == nil || *attachment.UploadedBy != userID {
+		if _, err := boardAccess(s.storage, userID, attachment.BoardID, domain.BoardRoleEditor); err != nil {
+			if errors.Is(err, ErrBoardForbidden) {
+				return ErrAttachmentForbidden
+			}
+			return err
+		}
+	}
+
+	deleted, err := s.storage.DeleteAttachment(attachmentID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrAttachmentNotFound
+	}
+
+	if attachment.TaskID != nil {
+		s.activity.record(activityEntry(userID, *attachment.TaskID, attachment.BoardID, domain.ActivityAttachmentRemoved, "", activityAttachment(attachment), nil))
+		s.publishTask(userID, attachment.BoardID, *attachment.TaskID)
+	}
+	return nil
+}
+
+// Open checks a signed link and returns the attachment with the requested
+// variant of its content. The caller closes the reader.
+func (s *AttachmentService) Open(attachmentID int64, variant string, expires int64, signature string) (domain.Attachment, io.ReadCloser, error) {
+	if !s.validSignature(attachmentID, variant, expires, signature) || time.Now().Unix() > expires {
+		return domain.Attachment{}, nil, ErrAttachmentLinkInvalid
+	}
+
+	attachment, err := s.storage.SelectAttachment(attachmentID)
+	if errors.Is(err, psql.ErrAttachmentNotFound) {
+		return domain.Attachment{}, nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+
+	key := contentKey(attachment.SHA256)
+	if variant == AttachmentThumbnail {
+		if !attachment.HasThumbnail {
+			return domain.Attachment{}, nil, ErrAttachmentNotFound
+		}
+		key = thumbnailKey(attachment.SHA256)
+	}
+
+	content, err := s.blobs.Get(context.Background(), key)
+	if errors.Is(err, blob.ErrNotFound) {
+		return domain.Attachment{}, nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return domain.Attachment{}, nil, err
+	}
+	return attachment, content, nil
+}
+
+// MessageAttachments checks that the user may attach the uploads to a
+// message on the board: they uploaded them there and nothing refers to them
+// yet.
+func (s *AttachmentService) MessageAttachments(userID, boardID int64, attachmentIDs []int64) ([]domain.AttachmentRef, error) {
+	if len(attachmentIDs) > maxMessageAttachments {
+		return nil, ErrTooManyAttachments
+	}
+
+	attachments, err := s.storage.SelectAttachmentsByIDs(attachmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[int64]domain.Attachment, len(attachments))
+	for _, a := range attachments {
+		if a.BoardID == boardID && a.UploadedBy != nil && *a.UploadedBy == userID && a.TaskID == nil && a.MessageID == "" {
+			found[a.ID] = a
+		}
+	}
+
+	refs := make([]domain.AttachmentRef, 0, len(attachmentIDs))
+	for _, id := range attachmentIDs {
+		a, ok := found[id]
+		if !ok {
+			return nil, ErrAttachmentNotFound
+		}
+		refs = append(refs, domain.AttachmentRef{
+			ID:          a.ID,
+			Filename:    a.Filename,
+			ContentType: a.ContentType,
+			Size:        a.Size,
+		})
+	}
+	return refs, nil
+}
+
+// LinkMessageAttachments records that the saved message carries the uploads,
+// so cleanup keeps them.
+func (s *AttachmentService) LinkMessageAttachments(userID, boardID int64, messageID string, attachmentIDs []int64) error {
+	_, err := s.storage.LinkMessageAttachments(messageID, boardID, userID, attachmentIDs)
+	return err
+}
+
+// StartCleanup periodically removes uploads nothing refers to and contents
+// no attachment uses any more.
+func (s *AttachmentService) StartCleanup(ctx context.Context) {
+	ticker := time.NewTicker(attachmentCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.cleanup()
+		}
+	}
+}
+
+func (s *AttachmentService) cleanup() {
+	removed, err := s.storage.DeleteUnreferencedAttachments(time.Now().Add(-s.cfg.AttachmentOrphanTTL))
+	if err != nil {
+		s.logger.Errorf("Failed to remove unreferenced attachments: %v", err)
+		return
+	}
+	if removed > 0 {
+		s.logger.Infof("Removed %d unreferenced attachments", removed)
+	}
+
+	blobs, err := s.storage.DeleteUnusedAttachmentBlobs(time.Now().Add(-attachmentBlobGrace))
+	if err != nil {
+		s.logger.Errorf("Failed to list unused attachment blobs: %v", err)
+		return
+	}
+	for _, b := range blobs {
+		if err := s.blobs.Delete(context.Background(), contentKey(b.SHA256)); err != nil {
+			s.logger.Errorf("Failed to delete attachment blob %s: %v", b.SHA256, err)
+		}
+		if b.HasThumbnail {
+			if err := s.blobs.Delete(context.Background(), thumbnailKey(b.SHA256)); err != nil {
+				s.logger.Errorf("Failed to delete thumbnail of attachment blob %s: %v", b.SHA256, err)
+			}
+		}
+	}
+}
+
+// upload spools the file to disk while hashing it, checks its size and
+// sniffed type, and stores the content unless the same bytes are stored
+// already.
+func (s *AttachmentService) upload(userID int64, attachment domain.Attachment, filename string, r io.Reader) (domain.Attachment, error) {
+	tmp, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(r, s.cfg.AttachmentMaxBytes+1))
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if size > s.cfg.AttachmentMaxBytes {
+		return domain.Attachment{}, ErrAttachmentTooBig
+	}
+	if size == 0 {
+		return domain.Attachment{}, ErrAttachmentEmpty
+	}
+
+	contentType, err := sniffContentType(tmp)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if !s.allowedType(contentType) {
+		return domain.Attachment{}, ErrAttachmentType
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	stored, err := s.storage.TouchAttachmentBlob(sum)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	if !stored {
+		if attachment.HasThumbnail, err = s.store(tmp, sum, size, contentType); err != nil {
+			return domain.Attachment{}, err
+		}
+	}
+
+	attachment.Filename = cleanFilename(filename)
+	attachment.ContentType = contentType
+	attachment.Size = size
+	attachment.SHA256 = sum
+	attachment.UploadedBy = &userID
+
+	attachment, err = s.storage.InsertAttachment(attachment)
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	return s.signed(attachment), nil
+}
+
+// store puts new content into the blob store, with a thumbnail for images.
+// It reports whether the thumbnail was made.
+func (s *AttachmentService) store(file *os.File, sum string, size int64, contentType string) (bool, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return false, err
+	}
+	if err := s.blobs.Put(context.Background(), contentKey(sum), file, size, contentType); err != nil {
+		return false, err
+	}
+
+	thumbnail := s.thumbnail(file, contentType)
+	if thumbnail == nil {
+		return false, nil
+	}
+	if err := s.blobs.Put(context.Background(), thumbnailKey(sum), bytes.NewReader(thumbnail), int64(len(thumbnail)), "image/png"); err != nil {
+		s.logger.Errorf("Failed to store thumbnail of attachment blob %s: %v", sum, err)
+		return false, nil
+	}
+	return true, nil
+}
+
+// thumbnail renders a PNG preview of an image, or returns nil when the file
+// is no image this server can decode.
+func (s *AttachmentService) thumbnail(file *os.File, contentType string) []byte {
+	if !strings.HasPrefix(contentType, "image/") {
+		return nil
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil
+	}
+	imgCfg, _, err := image.DecodeConfig(file)
+	if err != nil || imgCfg.Width*imgCfg.Height > maxThumbnailSourcePixels {
+		return nil
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil
+	}
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil
+	}
+
+	size := s.cfg.AttachmentThumbnailSize
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, imaging.Fit(img, size, size)); err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+func (s *AttachmentService) allowedType(contentType string) bool {
+	for _, allowed := range s.cfg.AttachmentAllowedTypes {
+		if strings.EqualFold(strings.TrimSpace(allowed), contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// signed fills in fresh download links.
+func (s *AttachmentService) signed(attachment domain.Attachment) domain.Attachment {
+	expires := time.Now().Add(s.cfg.AttachmentURLTTL).Unix()
+	attachment.URL = s.link(attachment.ID, AttachmentContent, expires)
+	if attachment.HasThumbnail {
+		attachment.ThumbnailURL = s.link(attachment.ID, AttachmentThumbnail, expires)
+	}
+	return attachment
+}
+
+func (s *AttachmentService) link(attachmentID int64, variant string, expires int64) string {
+	return fmt.Sprintf("%s/%d/%s?expires=%d&signature=%s",
+		strings.TrimRight(s.cfg.AttachmentURLPath, "/"), attachmentID, variant, expires, s.signature(attachmentID, variant, expires))
+}
+
+func (s *AttachmentService) signature(attachmentID int64, variant string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%d:%s:%d", attachmentID, variant, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *AttachmentService) validSignature(attachmentID int64, variant string, expires int64, signature string) bool {
+	expected := s.signature(attachmentID, variant, expires)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// publishTask sends the task with its new attachments to the board.
+func (s *AttachmentService) publishTask(userID, boardID, taskID int64) {
+	s.events.publishLatest(userID, boardID, domain.BoardEventTaskUpdated, taskLoader(s.storage, userID, taskID))
+}
+
+func (s *AttachmentService) attachment(userID, attachmentID int64, minRole string) (domain.Attachment, error) {
+	attachment, err := s.storage.SelectAttachment(attachmentID)
+	if errors.Is(err, psql.ErrAttachmentNotFound) {
+		return domain.Attachment{}, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+
+	// Attachments of boards the user cannot open do not exist for them.
+	_, err = boardAccess(s.storage, userID, attachment.BoardID, minRole)
+	if errors.Is(err, ErrBoardNotFound) {
+		return domain.Attachment{}, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+func (s *AttachmentService) task(userID, taskID int64, minRole string) (domain.Task, error) {
+	task, err := s.storage.SelectTask(userID, taskID)
+	if errors.Is(err, psql.ErrTaskNotFound) {
+		return domain.Task{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if _, err := boardAccess(s.storage, userID, task.BoardID, minRole); err != nil {
+		return domain.Task{}, err
+	}
+	return task, nil
+}
+
+// sniffContentType detects the type from the first bytes of the file,
+// without parameters such as the charset.
+func sniffContentType(file *os.File) (string, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", err
+	}
+
+	contentType := http.DetectContentType(head[:n])
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		contentType = mediaType
+	}
+	return contentType, nil
+}
+
+// cleanFilename keeps the base name of an uploaded file without control
+// characters, cut to a length the database accepts.
+func cleanFilename(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+
+	if runes := []rune(name); len(runes) > maxFilenameLength {
+		name = string(runes[:maxFilenameLength])
+	}
+	return name
+}
+
+func contentKey(sum string) string {
+	return "blobs/" + sum[:2] + "/" + sum
+}
+
+func thumbnailKey(sum string) string {
+	return "thumbnails/" + sum[:2] + "/" + sum + ".png"
+}
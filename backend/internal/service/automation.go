@@ -0,0 +1,891 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/lexorank"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+const (
+	automationQueueSize        = 256
+	automationWebhookQueueSize = 64
+	automationWebhookWorkers   = 4
+	maxAutomationDepth         = 5
+	maxAutomationActions       = 10
+	maxAutomationMessage       = 2000
+	maxMessageTaskRefs         = 10
+	automationDeadlineBatch    = 100
+	defaultExecutionPageSize   = 50
+	maxExecutionPageSize       = 200
+	automationWebhookTimeout   = 10 * time.Second
+	automationMessageUsername  = "automation"
+	automationNotificationType = "automation"
+)
+
+var (
+	ErrAutomationRuleNotFound = errors.New("automation rule not found")
+	ErrAutomationOwnerRole    = errors.New("the rule's creator can no longer edit the board")
+	ErrWebhookAddress         = errors.New("webhooks cannot call internal addresses")
+)
+
+var taskRef = regexp.MustCompile(`(?:^|[^\w#])#(\d+)\b`)
+
+var automationTriggers = map[string]bool{
+	domain.AutomationTriggerTaskMoved:      true,
+	domain.AutomationTriggerDeadlinePassed: true,
+	domain.AutomationTriggerLabelAdded:     true,
+	domain.AutomationTriggerMessagePosted:  true,
+}
+
+type AutomationStorage interface {
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+	SelectTask(userID, taskID int64) (domain.Task, error)
+	SelectColumn(userID, columnID int64) (domain.Column, error)
+	SelectColumns(userID, boardID int64) ([]domain.Column, error)
+	SelectLabels(boardID int64) ([]domain.Label, error)
+	IsBoardMember(boardID, userID int64) (bool, error)
+	InsertAutomationRule(r domain.AutomationRule) (domain.AutomationRule, error)
+	SelectAutomationRules(boardID int64) ([]domain.AutomationRule, error)
+	SelectEnabledAutomationRules(boardID int64, triggerType string) ([]domain.AutomationRule, error)
+	SelectAutomationRule(ruleID int64) (domain.AutomationRule, error)
+	UpdateAutomationRule(ruleID int64, req domain.AutomationRuleRequest) (bool, error)
+	DeleteAutomationRule(ruleID int64) (bool, error)
+	InsertAutomationExecution(e domain.AutomationExecution) error
+	SelectAutomationExecutions(ruleID, beforeID int64, limit int) ([]domain.AutomationExecution, error)
+	SelectDueDeadlines(limit int) ([]domain.AutomationDeadline, error)
+	AddTaskAssignee(taskID, userID, assignedBy int64) (bool, error)
+	AddTaskLabel(taskID, labelID int64) error
+	SelectLastTaskPosition(columnID, excludeID int64) (string, error)
+	MoveTask(userID, taskID, version, columnID int64, position string) (bool, error)
+	CreateNotification(n domain.Notification) (domain.Notification, error)
+	TaskRecipientStorage
+	BoardRevisionStorage
+	TaskActivityStorage
+}
+
+// ChatPoster saves a chat message and sends it to the board's room.
+type ChatPoster interface {
+	PostMessage(ctx context.Context, message domain.Message) (domain.Message, error)
+}
+
+// automationEvent is something that happened on a board that may fire
+// rules. Depth and chain trace the rules whose actions led to it; events
+// caused by users start at depth 0.
+type automationEvent struct {
+	trigger  string
+	boardID  int64
+	taskID   int64
+	columnID int64
+	labelID  int64
+	ruleID   int64
+	message  string
+	depth    int
+	chain    []int64
+}
+
+// webhookCall is a webhook action waiting for a worker. Execution is the
+// log entry for its outcome.
+type webhookCall struct {
+	url       string
+	payload   domain.AutomationWebhook
+	execution domain.AutomationExecution
+}
+
+// AutomationService manages the automation rules of boards and runs them.
+// Board admins manage the rules. Events are queued as they happen and
+// handled one at a time by Start; a rule's actions can fire further rules, up
+// to maxAutomationDepth in a row and never the same rule twice in one chain.
+// Every time a rule's trigger fires is logged with its outcome. Webhooks are
+// called by a few workers of their own, so a slow endpoint does not hold up
+// the other rules; each call's outcome is logged as a run of its own.
+type AutomationService struct {
+	storage  AutomationStorage
+	chat     ChatPoster
+	events   boardEvents
+	activity taskActivity
+	notifier taskNotifier
+	client   *http.Client
+	queue    chan automationEvent
+	webhooks chan webhookCall
+	logger   *logging.Logger
+}
+
+func NewAutomationService(storage AutomationStorage, chat ChatPoster, events BoardEventPublisher, logger *logging.Logger) *AutomationService {
+	return &AutomationService{
+		storage:  storage,
+		chat:     chat,
+		events:   boardEvents{storage: storage, publisher: events, logger: logger},
+		activity: taskActivity{storage: storage, logger: logger},
+		notifier: taskNotifier{storage: storage, notifications: storage, logger: logger},
+		client:   webhookClient(),
+		queue:    make(chan automationEvent, automationQueueSize),
+		webhooks: make(chan webhookCall, automationWebhookQueueSize),
+		logger:   logger,
+	}
+}
+
+func (s *AutomationService) List(userID, boardID int64) ([]domain.AutomationRule, error) {
+	if _, err := boardAccess(s.storage, userID, boardID, domain.BoardRoleAdmin); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectAutomationRules(boardID)
+}
+
+func (s *AutomationService) Get(userID, ruleID int64) (domain.AutomationRule, error) {
+	return s.rule(userID, ruleID)
+}
+
+// Create adds a rule, enabled unless req says otherwise, acting as userID.
+func (s *AutomationService) Create(userID, boardID int64, req domain.AutomationRuleRequest) (domain.AutomationRule, error) {
+	if req.Name == nil || req.Trigger == nil || req.Actions == nil {
+		return domain.AutomationRule{}, errors.New("name, trigger and actions are required")
+	}
+	if _, err := boardAccess(s.storage, userID, boardID, domain.BoardRoleAdmin); err != nil {
+		return domain.AutomationRule{}, err
+	}
+	if err := s.validate(userID, boardID, &req); err != nil {
+		return domain.AutomationRule{}, err
+	}
+
+	rule := domain.AutomationRule{
+		BoardID:   boardID,
+		CreatedBy: userID,
+		Name:      *req.Name,
+		Enabled:   req.Enabled == nil || *req.Enabled,
+		Trigger:   *req.Trigger,
+		Actions:   *req.Actions,
+	}
+	if req.Conditions != nil {
+		rule.Conditions = *req.Conditions
+	}
+	return s.storage.InsertAutomationRule(rule)
+}
+
+func (s *AutomationService) Update(userID, ruleID int64, req domain.AutomationRuleRequest) (domain.AutomationRule, error) {
+	rule, err := s.rule(userID, ruleID)
+	if err != nil {
+		return domain.AutomationRule{}, err
+	}
+	if err := s.validate(userID, rule.BoardID, &req); err != nil {
+		return domain.AutomationRule{}, err
+	}
+
+	updated, err := s.storage.UpdateAutomationRule(ruleID, req)
+	if err != nil {
+		return domain.AutomationRule{}, err
+	}
+	if !updated {
+		return domain.AutomationRule{}, ErrAutomationRuleNotFound
+	}
+	return s.rule(userID, ruleID)
+}
+
+func (s *AutomationService) Delete(userID, ruleID int64) error {
+	if _, err := s.rule(userID, ruleID); err != nil {
+		return err
+	}
+
+	deleted, err := s.storage.DeleteAutomationRule(ruleID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrAutomationRuleNotFound
+	}
+	return nil
+}
+
+// Executions returns a page of the rule's log, newest first. BeforeID
+// continues a previous page.
+func (s *AutomationService) Executions(userID, ruleID, beforeID int64, limit int) (domain.AutomationExecutionPage, error) {
+	if _, err := s.rule(userID, ruleID); err != nil {
+		return domain.AutomationExecutionPage{}, err
+	}
+
+	if limit <= 0 {
+		limit = defaultExecutionPageSize
+	}
+	if limit > maxExecutionPageSize {
+		limit = maxExecutionPageSize
+	}
+
+	executions, err := s.storage.SelectAutomationExecutions(ruleID, beforeID, limit)
+	if err != nil {
+		return domain.AutomationExecutionPage{}, err
+	}
+
+	page := domain.AutomationExecutionPage{Executions: executions}
+	if len(executions) == limit {
+		page.NextBeforeID = executions[len(executions)-1].ID
+	}
+	return page, nil
+}
+
+// ActivityRecorded turns moves and added labels in task timelines into
+// events for the rules.
+func (s *AutomationService) ActivityRecorded(entries []domain.TaskActivity) {
+	for _, entry := range entries {
+		if entry.Type != domain.ActivityFieldChanged {
+			continue
+		}
+		switch entry.Field {
+		case domain.ActivityFieldStatus:
+			var status domain.TaskStatusValue
+			if err := json.Unmarshal(entry.After, &status); err != nil {
+				continue
+			}
+			s.enqueue(automationEvent{
+				trigger:  domain.AutomationTriggerTaskMoved,
+				boardID:  entry.BoardID,
+				taskID:   entry.TaskID,
+				columnID: status.ColumnID,
+			})
+		case domain.ActivityFieldLabels:
+			var before, after []int64
+			if json.Unmarshal(entry.Before, &before) != nil || json.Unmarshal(entry.After, &after) != nil {
+				continue
+			}
+			for _, labelID := range after {
+				if !containsID(before, labelID) {
+					s.enqueue(automationEvent{
+						trigger: domain.AutomationTriggerLabelAdded,
+						boardID: entry.BoardID,
+						taskID:  entry.TaskID,
+						labelID: labelID,
+					})
+				}
+			}
+		}
+	}
+}
+
+// MessagePosted fires the message rules for each task a chat message names
+// as #<id>. Whether the task is on the message's board is checked when the
+// event is handled.
+func (s *AutomationService) MessagePosted(message domain.Message) {
+	var seen []int64
+	for _, match := range taskRef.FindAllStringSubmatch(message.Content, -1) {
+		taskID, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || containsID(seen, taskID) {
+			continue
+		}
+		seen = append(seen, taskID)
+		if len(seen) > maxMessageTaskRefs {
+			break
+		}
+		s.enqueue(automationEvent{
+			trigger: domain.AutomationTriggerMessagePosted,
+			boardID: message.BoardID,
+			taskID:  taskID,
+			message: message.Content,
+		})
+	}
+}
+
+// Start handles queued events and looks for passed deadlines every minute
+// until ctx is done.
+func (s *AutomationService) Start(ctx context.Context) {
+	for i := 0; i < automationWebhookWorkers; i++ {
+		go s.deliverWebhooks(ctx)
+	}
+
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event := <-s.queue:
+			s.handle(event)
+		case <-ticker.C:
+			s.checkDeadlines()
+		}
+	}
+}
+
+// enqueue drops the event when the queue is full rather than hold up the
+// request that caused it.
+func (s *AutomationService) enqueue(event automationEvent) {
+	select {
+	case s.queue <- event:
+	default:
+		s.logger.Warnf("Dropped %s automation event of task %d: queue full", event.trigger, event.taskID)
+	}
+}
+
+func (s *AutomationService) checkDeadlines() {
+	deadlines, err := s.storage.SelectDueDeadlines(automationDeadlineBatch)
+	if err != nil {
+		s.logger.Errorf("Failed to check deadlines for automation rules: %v", err)
+		return
+	}
+
+	for _, d := range deadlines {
+		s.handle(automationEvent{
+			trigger: domain.AutomationTriggerDeadlinePassed,
+			boardID: d.BoardID,
+			taskID:  d.TaskID,
+			ruleID:  d.RuleID,
+		})
+	}
+}
+
+func (s *AutomationService) handle(event automationEvent) {
+	rules, err := s.storage.SelectEnabledAutomationRules(event.boardID, event.trigger)
+	if err != nil {
+		s.logger.Errorf("Failed to load %s rules of board %d: %v", event.trigger, event.boardID, err)
+		return
+	}
+
+	for _, rule := range rules {
+		if event.ruleID != 0 && rule.ID != event.ruleID {
+			continue
+		}
+		switch event.trigger {
+		case domain.AutomationTriggerTaskMoved:
+			if rule.Trigger.ColumnID != 0 && rule.Trigger.ColumnID != event.columnID {
+				continue
+			}
+		case domain.AutomationTriggerLabelAdded:
+			if rule.Trigger.LabelID != 0 && rule.Trigger.LabelID != event.labelID {
+				continue
+			}
+		}
+		s.run(rule, event)
+	}
+}
+
+// run applies the rule to the event's task and logs the outcome. Actions
+// stop at the first one that fails.
+func (s *AutomationService) run(rule domain.AutomationRule, event automationEvent) {
+	task, err := s.storage.SelectTask(rule.CreatedBy, event.taskID)
+	if err == nil && task.BoardID != rule.BoardID {
+		// A message may name any number; only tasks of the board count.
+		return
+	}
+	if errors.Is(err, psql.ErrTaskNotFound) {
+		// The task is gone, or the creator lost the board. Only the latter is
+		// logged, which also keeps deadline rules from picking the task again.
+		if _, err = boardAccess(s.storage, rule.CreatedBy, rule.BoardID, domain.BoardRoleEditor); err == nil {
+			return
+		}
+	}
+
+	execution := domain.AutomationExecution{
+		RuleID:      rule.ID,
+		BoardID:     rule.BoardID,
+		TaskID:      event.taskID,
+		TriggerType: event.trigger,
+		Status:      domain.AutomationSucceeded,
+		Depth:       event.depth,
+		Results:     []string{},
+	}
+	defer func() {
+		if err := s.storage.InsertAutomationExecution(execution); err != nil {
+			s.logger.Errorf("Failed to log run of automation rule %d: %v", rule.ID, err)
+		}
+	}()
+
+	if errors.Is(err, ErrBoardNotFound) || errors.Is(err, ErrBoardForbidden) {
+		execution.Status, execution.Error = domain.AutomationFailed, ErrAutomationOwnerRole.Error()
+		return
+	}
+	if err != nil {
+		execution.Status, execution.Error = domain.AutomationFailed, err.Error()
+		return
+	}
+	if containsID(event.chain, rule.ID) || event.depth >= maxAutomationDepth {
+		execution.Status = domain.AutomationSkipped
+		execution.Error = "skipped to stop a loop: the rule was fired by automation actions too many times in a row"
+		return
+	}
+	if !conditionsHold(rule.Conditions, task) {
+		execution.Status = domain.AutomationSkipped
+		execution.Error = "the conditions do not hold"
+		return
+	}
+
+	board, err := boardAccess(s.storage, rule.CreatedBy, rule.BoardID, domain.BoardRoleEditor)
+	if err != nil {
+		execution.Status, execution.Error = domain.AutomationFailed, ErrAutomationOwnerRole.Error()
+		return
+	}
+
+	var followUps []automationEvent
+	for _, action := range rule.Actions {
+		result, next, err := s.act(rule, board, &task, action, event)
+		if err != nil {
+			execution.Status = domain.AutomationFailed
+			execution.Error = fmt.Sprintf("%s: %v", action.Type, err)
+			break
+		}
+		execution.Results = append(execution.Results, action.Type+": "+result)
+		followUps = append(followUps, next...)
+	}
+
+	chain := append(append([]int64{}, event.chain...), rule.ID)
+	for _, next := range followUps {
+		next.depth, next.chain = event.depth+1, chain
+		s.enqueue(next)
+	}
+}
+
+// act runs one action on the task, keeping task up to date, and returns a
+// line for the log and the events it caused. Changes are recorded in the
+// task's timeline without an actor.
+func (s *AutomationService) act(rule domain.AutomationRule, board domain.Board, task *domain.Task, action domain.AutomationAction, event automationEvent) (string, []automationEvent, error) {
+	switch action.Type {
+	case domain.AutomationActionAssign:
+		if err := s.member(board.ID, action.UserID); err != nil {
+			return "", nil, err
+		}
+		added, err := s.storage.AddTaskAssignee(task.ID, action.UserID, rule.CreatedBy)
+		if err != nil {
+			return "", nil, err
+		}
+		if !added {
+			return fmt.Sprintf("user %d was assigned already", action.UserID), nil, nil
+		}
+		before := sortedIDs(task.AssigneeIDs)
+		s.activity.record(fieldChange(0, *task, domain.ActivityFieldAssignees, before, sortedIDs(append(before, action.UserID))))
+		if err := s.reload(rule, task, domain.BoardEventTaskUpdated, nil); err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("assigned user %d", action.UserID), nil, nil
+
+	case domain.AutomationActionAddLabel:
+		if containsID(task.LabelIDs, action.LabelID) {
+			return fmt.Sprintf("label %d was set already", action.LabelID), nil, nil
+		}
+		if err := s.storage.AddTaskLabel(task.ID, action.LabelID); err != nil {
+			return "", nil, err
+		}
+		before := sortedIDs(task.LabelIDs)
+		if err := s.reload(rule, task, domain.BoardEventTaskUpdated, nil); err != nil {
+			return "", nil, err
+		}
+		if !containsID(task.LabelIDs, action.LabelID) {
+			return "", nil, fmt.Errorf("label %d is not on the board", action.LabelID)
+		}
+		s.activity.record(fieldChange(0, *task, domain.ActivityFieldLabels, before, sortedIDs(task.LabelIDs)))
+		return fmt.Sprintf("added label %d", action.LabelID), []automationEvent{{
+			trigger: domain.AutomationTriggerLabelAdded,
+			boardID: task.BoardID,
+			taskID:  task.ID,
+			labelID: action.LabelID,
+		}}, nil
+
+	case domain.AutomationActionMove:
+		if task.ColumnID == action.ColumnID {
+			return fmt.Sprintf("the task was in column %d already", action.ColumnID), nil, nil
+		}
+		if err := s.move(rule, task, action.ColumnID); err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("moved the task to column %d", action.ColumnID), []automationEvent{{
+			trigger:  domain.AutomationTriggerTaskMoved,
+			boardID:  task.BoardID,
+			taskID:   task.ID,
+			columnID: action.ColumnID,
+		}}, nil
+
+	case domain.AutomationActionPostMessage:
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		// Messages posted by rules do not fire message rules.
+		if _, err := s.chat.PostMessage(ctx, domain.Message{
+			WorkspaceID: board.WorkspaceID,
+			BoardID:     board.ID,
+			Username:    automationMessageUsername,
+			Content:     automationText(action.Message, *task),
+		}); err != nil {
+			return "", nil, err
+		}
+		return "posted a chat message", nil, nil
+
+	case domain.AutomationActionNotify:
+		n := domain.Notification{
+			TaskID:    task.ID,
+			Title:     rule.Name,
+			Message:   automationText(action.Message, *task),
+			Type:      fmt.Sprintf("%s.%d", automationNotificationType, rule.ID),
+			ExpiresAt: time.Now().Add(30 * 24 * time.Hour),
+		}
+		if action.UserID == 0 {
+			return fmt.Sprintf("notified %d users", s.notifier.notify(n)), nil, nil
+		}
+		if err := s.member(board.ID, action.UserID); err != nil {
+			return "", nil, err
+		}
+		n.UserID = action.UserID
+		_, err := s.storage.CreateNotification(n)
+		if errors.Is(err, psql.ErrNotificationExists) {
+			return fmt.Sprintf("user %d was notified already", action.UserID), nil, nil
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("notified user %d", action.UserID), nil, nil
+
+	case domain.AutomationActionWebhook:
+		call := webhookCall{
+			url: action.URL,
+			payload: domain.AutomationWebhook{
+				RuleID:   rule.ID,
+				RuleName: rule.Name,
+				Trigger:  event.trigger,
+				BoardID:  board.ID,
+				Task:     *task,
+				Message:  event.message,
+			},
+			execution: domain.AutomationExecution{
+				RuleID:      rule.ID,
+				BoardID:     rule.BoardID,
+				TaskID:      task.ID,
+				TriggerType: event.trigger,
+				Depth:       event.depth,
+			},
+		}
+		select {
+		case s.webhooks <- call:
+			return "queued the webhook call", nil, nil
+		default:
+			return "", nil, errors.New("too many webhook calls are waiting")
+		}
+	}
+	return "", nil, fmt.Errorf("unknown action %q", action.Type)
+}
+
+// move puts the task at the end of the column, within the column's enforced
+// work-in-progress limit.
+func (s *AutomationService) move(rule domain.AutomationRule, task *domain.Task, columnID int64) error {
+	column, err := s.storage.SelectColumn(rule.CreatedBy, columnID)
+	if errors.Is(err, psql.ErrColumnNotFound) || (err == nil && column.BoardID != task.BoardID) {
+		return ErrColumnNotFound
+	}
+	if err != nil {
+		return err
+	}
+	last, err := s.storage.SelectLastTaskPosition(column.ID, task.ID)
+	if err != nil {
+		return err
+	}
+	position, err := lexorank.Between(last, "")
+	if err != nil {
+		return err
+	}
+	moved, err := s.storage.MoveTask(rule.CreatedBy, task.ID, 0, column.ID, position)
+	if errors.Is(err, psql.ErrColumnFull) {
+		return ErrWIPLimitReached
+	}
+	if err != nil {
+		return err
+	}
+	if !moved {
+		return ErrTaskNotFound
+	}
+
+	before := *task
+	if err := s.reload(rule, task, domain.BoardEventTaskMoved, &before); err != nil {
+		return err
+	}
+	s.activity.record(fieldChange(0, *task, domain.ActivityFieldStatus, taskStatus(before), taskStatus(*task)))
+	return nil
+}
+
+// reload reads the task after a change and publishes it to the board. For a
+// move, before is the task as it was.
+func (s *AutomationService) reload(rule domain.AutomationRule, task *domain.Task, eventType string, before *domain.Task) error {
+	current, err := s.storage.SelectTask(rule.CreatedBy, task.ID)
+	if errors.Is(err, psql.ErrTaskNotFound) {
+		return ErrTaskNotFound
+	}
+	if err != nil {
+		return err
+	}
+	*task = current
+
+	if before != nil {
+		fromColumnID := before.ColumnID
+		s.events.publishLatest(0, task.BoardID, eventType, func() (interface{}, error) {
+			latest, err := s.storage.SelectTask(rule.CreatedBy, current.ID)
+			return domain.TaskMovedEvent{Task: latest, FromColumnID: fromColumnID}, err
+		})
+	} else {
+		s.events.publishLatest(0, task.BoardID, eventType, taskLoader(s.storage, rule.CreatedBy, current.ID))
+	}
+	return nil
+}
+
+// callWebhook posts payload as JSON and returns the response status.
+// deliverWebhooks calls queued webhooks until ctx is done.
+func (s *AutomationService) deliverWebhooks(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case call := <-s.webhooks:
+			s.deliverWebhook(call)
+		}
+	}
+}
+
+// deliverWebhook calls the webhook and logs the outcome. The run that queued
+// the call has been logged already, so this is an entry of its own.
+func (s *AutomationService) deliverWebhook(call webhookCall) {
+	execution := call.execution
+	execution.Status, execution.Results = domain.AutomationSucceeded, []string{}
+
+	status, err := s.callWebhook(call.url, call.payload)
+	if err != nil {
+		execution.Status = domain.AutomationFailed
+		execution.Error = fmt.Sprintf("%s: %v", domain.AutomationActionWebhook, err)
+	} else {
+		execution.Results = append(execution.Results, domain.AutomationActionWebhook+": called the webhook: "+status)
+	}
+	if err := s.storage.InsertAutomationExecution(execution); err != nil {
+		s.logger.Errorf("Failed to log webhook call of automation rule %d: %v", execution.RuleID, err)
+	}
+}
+
+func (s *AutomationService) callWebhook(target string, payload domain.AutomationWebhook) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), automationWebhookTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Automation-Rule", strconv.FormatInt(payload.RuleID, 10))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("the webhook answered %s", resp.Status)
+	}
+	return resp.Status, nil
+}
+
+// validate checks the parts of a rule that req sets against the board and
+// trims the name.
+func (s *AutomationService) validate(userID, boardID int64, req *domain.AutomationRuleRequest) error {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len([]rune(name)) > 100 {
+			return errors.New("rule name is required and must be at most 100 characters")
+		}
+		req.Name = &name
+	}
+
+	columns, err := s.storage.SelectColumns(userID, boardID)
+	if err != nil {
+		return err
+	}
+	columnIDs := make([]int64, 0, len(columns))
+	for _, column := range columns {
+		columnIDs = append(columnIDs, column.ID)
+	}
+	labels, err := s.storage.SelectLabels(boardID)
+	if err != nil {
+		return err
+	}
+	labelIDs := make([]int64, 0, len(labels))
+	for _, label := range labels {
+		labelIDs = append(labelIDs, label.ID)
+	}
+
+	if t := req.Trigger; t != nil {
+		if !automationTriggers[t.Type] {
+			return fmt.Errorf("unknown trigger %q", t.Type)
+		}
+		if t.ColumnID != 0 && t.Type != domain.AutomationTriggerTaskMoved ||
+			t.LabelID != 0 && t.Type != domain.AutomationTriggerLabelAdded {
+			return fmt.Errorf("a %s trigger takes no column or label", t.Type)
+		}
+		if t.ColumnID != 0 && !containsID(columnIDs, t.ColumnID) {
+			return fmt.Errorf("column %d is not a column of the board", t.ColumnID)
+		}
+		if t.LabelID != 0 && !containsID(labelIDs, t.LabelID) {
+			return fmt.Errorf("label %d is not a label of the board", t.LabelID)
+		}
+	}
+
+	if c := req.Conditions; c != nil {
+		for _, priority := range c.Priorities {
+			if !priorities[priority] {
+				return fmt.Errorf("unknown priority %q", priority)
+			}
+		}
+	}
+
+	if req.Actions == nil {
+		return nil
+	}
+	actions := *req.Actions
+	if len(actions) == 0 || len(actions) > maxAutomationActions {
+		return fmt.Errorf("a rule needs between 1 and %d actions", maxAutomationActions)
+	}
+	for i, action := range actions {
+		switch action.Type {
+		case domain.AutomationActionAssign:
+			if err := s.member(boardID, action.UserID); err != nil {
+				return err
+			}
+		case domain.AutomationActionAddLabel:
+			if !containsID(labelIDs, action.LabelID) {
+				return fmt.Errorf("label %d is not a label of the board", action.LabelID)
+			}
+		case domain.AutomationActionMove:
+			if !containsID(columnIDs, action.ColumnID) {
+				return fmt.Errorf("column %d is not a column of the board", action.ColumnID)
+			}
+		case domain.AutomationActionPostMessage, domain.AutomationActionNotify:
+			message := strings.TrimSpace(action.Message)
+			if message == "" || len([]rune(message)) > maxAutomationMessage {
+				return fmt.Errorf("the message of a %s action is required and must be at most %d characters", action.Type, maxAutomationMessage)
+			}
+			actions[i].Message = message
+			if action.Type == domain.AutomationActionNotify && action.UserID != 0 {
+				if err := s.member(boardID, action.UserID); err != nil {
+					return err
+				}
+			}
+		case domain.AutomationActionWebhook:
+			u, err := url.Parse(action.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("invalid webhook URL %q", action.URL)
+			}
+		default:
+			return fmt.Errorf("unknown action %q", action.Type)
+		}
+	}
+	return nil
+}
+
+// rule loads a rule of a board the user administers.
+func (s *AutomationService) rule(userID, ruleID int64) (domain.AutomationRule, error) {
+	rule, err := s.storage.SelectAutomationRule(ruleID)
+	if errors.Is(err, psql.ErrAutomationRuleNotFound) {
+		return domain.AutomationRule{}, ErrAutomationRuleNotFound
+	}
+	if err != nil {
+		return domain.AutomationRule{}, err
+	}
+	if _, err := boardAccess(s.storage, userID, rule.BoardID, domain.BoardRoleAdmin); err != nil {
+		if errors.Is(err, ErrBoardNotFound) {
+			return domain.AutomationRule{}, ErrAutomationRuleNotFound
+		}
+		return domain.AutomationRule{}, err
+	}
+	return rule, nil
+}
+
+func (s *AutomationService) member(boardID, userID int64) error {
+	ok, err := s.storage.IsBoardMember(boardID, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("user %d is not a member of the board", userID)
+	}
+	return nil
+}
+
+// conditionsHold tells whether the task meets every condition.
+func conditionsHold(c domain.AutomationConditions, task domain.Task) bool {
+	if len(c.Priorities) > 0 {
+		found := false
+		for _, priority := range c.Priorities {
+			found = found || priority == task.Priority
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(c.LabelIDs) > 0 && !anyID(c.LabelIDs, task.LabelIDs) {
+		return false
+	}
+	if len(c.AssigneeIDs) > 0 && !anyID(c.AssigneeIDs, task.AssigneeIDs) {
+		return false
+	}
+	if c.Unassigned && len(task.AssigneeIDs) > 0 {
+		return false
+	}
+	if len(c.ColumnIDs) > 0 && !containsID(c.ColumnIDs, task.ColumnID) {
+		return false
+	}
+	if c.Done != nil && *c.Done != task.Done {
+		return false
+	}
+	if c.TitleContains != "" && !strings.Contains(strings.ToLower(task.Title), strings.ToLower(c.TitleContains)) {
+		return false
+	}
+	return true
+}
+
+func anyID(want, have []int64) bool {
+	for _, id := range want {
+		if containsID(have, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// automationText fills the task placeholders of an action's message.
+func automationText(message string, task domain.Task) string {
+	return strings.NewReplacer(
+		"{task.id}", strconv.FormatInt(task.ID, 10),
+		"{task.title}", task.Title,
+		"{task.status}", task.Status,
+	).Replace(message)
+}
+
+// webhookClient refuses to connect to loopback, private and link-local
+// addresses, so rules cannot reach services inside our network. The check is
+// on the resolved address, which also covers redirects and DNS names.
+func webhookClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+				ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast() {
+				return ErrWebhookAddress
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout:   automationWebhookTimeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+	}
+}
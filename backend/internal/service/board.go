@@ -0,0 +1,214 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/config"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+var (
+	ErrBoardNotFound        = errors.New("board not found")
+	ErrBoardForbidden       = errors.New("your role on this board does not allow this")
+	ErrBoardInviteNotFound  = errors.New("invite not found")
+	ErrBoardInviteInvalid   = errors.New("invite has expired, was revoked or has no uses left")
+	ErrBoardInviteForeignTo = errors.New("invite was sent to a different email address")
+)
+
+var boardRoleRank = map[string]int{
+	domain.BoardRoleViewer: 1,
+	domain.BoardRoleEditor: 2,
+	domain.BoardRoleAdmin:  3,
+}
+
+type boardSelector interface {
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+}
+
+// boardAccess loads the board as userID sees it and checks that their
+// effective role on it is at least minRole.
+func boardAccess(boards boardSelector, userID, boardID int64, minRole string) (domain.Board, error) {
+	board, err := boards.SelectBoard(userID, boardID)
+	if errors.Is(err, psql.ErrBoardNotFound) {
+		return domain.Board{}, ErrBoardNotFound
+	}
+	if err != nil {
+		return domain.Board{}, err
+	}
+	if boardRoleRank[board.Role] < boardRoleRank[minRole] {
+		return domain.Board{}, ErrBoardForbidden
+	}
+	return board, nil
+}
+
+type BoardStorage interface {
+	SelectWorkspaceRole(workspaceID, userID int64) (string, error)
+	InsertBoard(b domain.Board) (domain.Board, error)
+	SelectBoards(userID, workspaceID int64) ([]domain.Board, error)
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+	UpdateBoard(userID, boardID, version int64, req domain.BoardRequest) (bool, error)
+	DeleteBoard(userID, boardID int64) (bool, error)
+	SelectBoardAdminIDs(boardID int64) ([]int64, error)
+	InsertBoardInvite(inv domain.BoardInvite, tokenHash string) (domain.BoardInvite, error)
+	SelectBoardInvites(boardID int64) ([]domain.BoardInvite, error)
+	RevokeBoardInvite(boardID, inviteID int64) (bool, error)
+	SelectBoardInviteByToken(tokenHash string) (domain.BoardInvite, error)
+	AcceptBoardInvite(inv domain.BoardInvite, userID int64) (bool, error)
+	SelectUserByID(userID int64) (domain.User, error)
+}
+
+// BoardService manages boards. Every storage call carries the acting user,
+// so boards of workspaces the user does not belong to look like they do not
+// exist.
+type BoardService struct {
+	storage       BoardStorage
+	notifications NotificationCreator
+	audit         AuditRecorder
+	cfg           config.WorkspaceConfig
+	logger        *logging.Logger
+}
+
+func NewBoardService(storage BoardStorage, notifications NotificationCreator, audit AuditRecorder, cfg config.WorkspaceConfig, logger *logging.Logger) *BoardService {
+	return &BoardService{
+		storage:       storage,
+		notifications: notifications,
+		audit:         audit,
+		cfg:           cfg,
+		logger:        logger,
+	}
+}
+
+// Create adds a board to the workspace. Guests cannot create boards.
+func (s *BoardService) Create(userID, workspaceID int64, req domain.BoardRequest) (domain.Board, error) {
+	if req.Name == nil {
+		return domain.Board{}, errors.New("board name is required")
+	}
+	if err := validateBoardRequest(&req); err != nil {
+		return domain.Board{}, err
+	}
+
+	role, err := s.storage.SelectWorkspaceRole(workspaceID, userID)
+	if errors.Is(err, psql.ErrWorkspaceNotFound) {
+		return domain.Board{}, ErrWorkspaceNotFound
+	}
+	if err != nil {
+		return domain.Board{}, err
+	}
+	if role == domain.WorkspaceRoleGuest {
+		return domain.Board{}, ErrWorkspaceForbidden
+	}
+
+	board := domain.Board{
+		WorkspaceID: workspaceID,
+		Name:        *req.Name,
+		CreatedBy:   userID,
+	}
+	if req.Description != nil {
+		board.Description = *req.Description
+	}
+
+	board, err = s.storage.InsertBoard(board)
+	if errors.Is(err, psql.ErrWorkspaceNotFound) {
+		return domain.Board{}, ErrWorkspaceNotFound
+	}
+	return board, err
+}
+
+func (s *BoardService) List(userID, workspaceID int64) ([]domain.Board, error) {
+	if _, err := s.storage.SelectWorkspaceRole(workspaceID, userID); err != nil {
+		if errors.Is(err, psql.ErrWorkspaceNotFound) {
+			return nil, ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+	return s.storage.SelectBoards(userID, workspaceID)
+}
+
+func (s *BoardService) Get(userID, boardID int64) (domain.Board, error) {
+	board, err := s.storage.SelectBoard(userID, boardID)
+	if errors.Is(err, psql.ErrBoardNotFound) {
+		return domain.Board{}, ErrBoardNotFound
+	}
+	return board, err
+}
+
+// Update refuses to write when version is set and the board has moved past
+// it; version 0 writes unconditionally.
+func (s *BoardService) Update(userID, boardID, version int64, req domain.BoardRequest) (domain.Board, error) {
+	if err := validateBoardRequest(&req); err != nil {
+		return domain.Board{}, err
+	}
+
+	board, err := s.requireRole(userID, boardID, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.Board{}, err
+	}
+	if version != 0 && board.Version != version {
+		return domain.Board{}, &VersionConflictError{Version: board.Version, Current: board}
+	}
+
+	updated, err := s.storage.UpdateBoard(userID, boardID, version, req)
+	if err != nil {
+		return domain.Board{}, err
+	}
+	if !updated {
+		current, err := s.Get(userID, boardID)
+		if err != nil {
+			return domain.Board{}, err
+		}
+		if version == 0 {
+			return domain.Board{}, ErrBoardNotFound
+		}
+		return domain.Board{}, &VersionConflictError{Version: current.Version, Current: current}
+	}
+	return s.Get(userID, boardID)
+}
+
+// Delete removes a board. It takes a board admin: the creator, an invited
+// admin or a workspace owner or admin.
+func (s *BoardService) Delete(userID, boardID int64) error {
+	if _, err := s.requireRole(userID, boardID, domain.BoardRoleAdmin); err != nil {
+		return err
+	}
+
+	deleted, err := s.storage.DeleteBoard(userID, boardID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrBoardNotFound
+	}
+	return nil
+}
+
+// BoardWorkspace returns the workspace owning the board, or ErrBoardNotFound
+// when the user cannot see it. The chat hub keys its rooms by the result.
+func (s *BoardService) BoardWorkspace(userID, boardID int64) (int64, error) {
+	board, err := s.Get(userID, boardID)
+	if err != nil {
+		return 0, err
+	}
+	return board.WorkspaceID, nil
+}
+
+// requireRole loads the board and checks the user's effective role on it.
+func (s *BoardService) requireRole(userID, boardID int64, minRole string) (domain.Board, error) {
+	return boardAccess(s.storage, userID, boardID, minRole)
+}
+
+func validateBoardRequest(req *domain.BoardRequest) error {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len(name) > 100 {
+			return errors.New("board name is required and must be at most 100 characters")
+		}
+		req.Name = &name
+	}
+	if req.Description != nil && len(*req.Description) > 2000 {
+		return errors.New("board description must be at most 2000 characters")
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+// BoardEventPublisher delivers board events to the clients watching the
+// board.
+type BoardEventPublisher interface {
+	PublishBoardEvent(event domain.BoardEvent)
+}
+
+type BoardRevisionStorage interface {
+	NextBoardRevision(boardID int64) (int64, int64, error)
+}
+
+// boardEvents is shared by the services whose changes show up on a board.
+type boardEvents struct {
+	storage   BoardRevisionStorage
+	publisher BoardEventPublisher
+	logger    *logging.Logger
+}
+
+// publish sends an event whose payload cannot go stale, such as the id of
+// a deleted record.
+func (e boardEvents) publish(userID, boardID int64, eventType string, data interface{}) {
+	e.publishLatest(userID, boardID, eventType, func() (interface{}, error) {
+		return data, nil
+	})
+}
+
+// publishLatest stamps the event with the board's next revision and only
+// then calls load for its payload. Every change behind an earlier revision
+// is committed by then, so whatever order concurrent writers get here in,
+// the event with the highest revision carries the latest state.
+//
+// The change itself is already committed, so a failure here is only
+// logged. A revision that was taken but not sent shows up to clients as a
+// gap, and they reload the board.
+func (e boardEvents) publishLatest(userID, boardID int64, eventType string, load func() (interface{}, error)) {
+	workspaceID, revision, err := e.storage.NextBoardRevision(boardID)
+	if err != nil {
+		e.logger.Errorf("Failed to publish %s event on board %d: %v", eventType, boardID, err)
+		return
+	}
+
+	data, err := load()
+	if err != nil {
+		e.logger.Errorf("Failed to load the %s event of revision %d on board %d: %v", eventType, revision, boardID, err)
+		return
+	}
+
+	e.publisher.PublishBoardEvent(domain.BoardEvent{
+		Type:        eventType,
+		WorkspaceID: workspaceID,
+		BoardID:     boardID,
+		Revision:    revision,
+		ActorID:     userID,
+		Data:        data,
+		CreatedAt:   time.Now(),
+	})
+}
+
+type taskSelector interface {
+	SelectTask(userID, taskID int64) (domain.Task, error)
+}
+
+// taskLoader reads a task as userID sees it, for publishLatest.
+func taskLoader(tasks taskSelector, userID, taskID int64) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		return tasks.SelectTask(userID, taskID)
+	}
+}
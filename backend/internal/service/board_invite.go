@@ -0,0 +1,173 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/hash"
+	"github.com/your-team/taskmanager-chat/backend/pkg/utils"
+)
+
+// CreateInvite creates an invite link for the board. Only board admins can
+// invite.
+func (s *BoardService) CreateInvite(userID, boardID int64, req domain.BoardInviteRequest) (domain.CreatedBoardInvite, error) {
+	invite := domain.BoardInvite{
+		BoardID:   boardID,
+		Role:      req.Role,
+		MaxUses:   req.MaxUses,
+		ExpiresAt: req.ExpiresAt,
+		CreatedBy: userID,
+	}
+
+	if email := strings.TrimSpace(req.Email); email != "" {
+		address, err := mail.ParseAddress(email)
+		if err != nil {
+			return domain.CreatedBoardInvite{}, errors.New("email is not valid")
+		}
+		invite.Email = strings.ToLower(address.Address)
+	}
+	if invite.Role == "" {
+		invite.Role = domain.BoardRoleEditor
+	}
+	if _, ok := boardRoleRank[invite.Role]; !ok {
+		return domain.CreatedBoardInvite{}, errors.New("role must be 'admin', 'editor' or 'viewer'")
+	}
+	if invite.MaxUses < 0 {
+		return domain.CreatedBoardInvite{}, errors.New("max_uses must not be negative")
+	}
+
+	now := time.Now()
+	if invite.ExpiresAt.IsZero() {
+		invite.ExpiresAt = now.Add(s.cfg.BoardInviteTTL)
+	}
+	if !invite.ExpiresAt.After(now) || invite.ExpiresAt.Sub(now) > s.cfg.BoardInviteMaxTTL {
+		return domain.CreatedBoardInvite{}, fmt.Errorf("expires_at must be in the future and at most %s away", s.cfg.BoardInviteMaxTTL)
+	}
+
+	board, err := s.requireRole(userID, boardID, domain.BoardRoleAdmin)
+	if err != nil {
+		return domain.CreatedBoardInvite{}, err
+	}
+
+	token, err := utils.GenerateRandomString(invitationTokenLength)
+	if err != nil {
+		return domain.CreatedBoardInvite{}, errors.New("failed to generate invite token")
+	}
+
+	invite, err = s.storage.InsertBoardInvite(invite, hash.HashToken(token))
+	if err != nil {
+		return domain.CreatedBoardInvite{}, err
+	}
+	invite.BoardName = board.Name
+	invite.WorkspaceID = board.WorkspaceID
+
+	return domain.CreatedBoardInvite{
+		BoardInvite: invite,
+		Token:       token,
+		URL:         strings.TrimRight(s.cfg.BoardInviteURL, "/") + "/" + token,
+	}, nil
+}
+
+func (s *BoardService) Invites(userID, boardID int64) ([]domain.BoardInvite, error) {
+	if _, err := s.requireRole(userID, boardID, domain.BoardRoleAdmin); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectBoardInvites(boardID)
+}
+
+func (s *BoardService) RevokeInvite(userID, boardID, inviteID int64) error {
+	if _, err := s.requireRole(userID, boardID, domain.BoardRoleAdmin); err != nil {
+		return err
+	}
+
+	revoked, err := s.storage.RevokeBoardInvite(boardID, inviteID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrBoardInviteNotFound
+	}
+	return nil
+}
+
+// AcceptInvite redeems an invite for the user. Users that already have the
+// invite's role or a higher one on the board just get the board back; the
+// invite is not used up for them.
+func (s *BoardService) AcceptInvite(userID int64, token string, client domain.ClientInfo) (domain.Board, error) {
+	invite, err := s.storage.SelectBoardInviteByToken(hash.HashToken(token))
+	if errors.Is(err, psql.ErrBoardInviteNotFound) {
+		return domain.Board{}, ErrBoardInviteNotFound
+	}
+	if err != nil {
+		return domain.Board{}, err
+	}
+
+	usedUp := invite.MaxUses > 0 && invite.UseCount >= invite.MaxUses
+	if invite.RevokedAt != nil || usedUp || time.Now().After(invite.ExpiresAt) {
+		return domain.Board{}, ErrBoardInviteInvalid
+	}
+
+	user, err := s.storage.SelectUserByID(userID)
+	if err != nil {
+		return domain.Board{}, err
+	}
+	if invite.Email != "" && !strings.EqualFold(user.Email, invite.Email) {
+		return domain.Board{}, ErrBoardInviteForeignTo
+	}
+
+	if board, err := s.Get(userID, invite.BoardID); err == nil && boardRoleRank[board.Role] >= boardRoleRank[invite.Role] {
+		return board, nil
+	}
+
+	accepted, err := s.storage.AcceptBoardInvite(invite, userID)
+	if err != nil {
+		return domain.Board{}, err
+	}
+	if !accepted {
+		return domain.Board{}, ErrBoardInviteInvalid
+	}
+
+	_ = s.audit.Record(newAuditEvent(domain.AuditBoardJoined, userID, userID, client, map[string]interface{}{
+		"board_id":     invite.BoardID,
+		"workspace_id": invite.WorkspaceID,
+		"invite_id":    invite.ID,
+		"role":         invite.Role,
+	}))
+	s.notifyBoardAdmins(invite, user)
+
+	return s.Get(userID, invite.BoardID)
+}
+
+func (s *BoardService) notifyBoardAdmins(invite domain.BoardInvite, joined domain.User) {
+	if s.notifications == nil {
+		return
+	}
+
+	adminIDs, err := s.storage.SelectBoardAdminIDs(invite.BoardID)
+	if err != nil {
+		s.logger.Errorf("Failed to load admins of board %d: %v", invite.BoardID, err)
+		return
+	}
+
+	message := fmt.Sprintf("%s joined %q as %s.", joined.Username, invite.BoardName, invite.Role)
+	for _, adminID := range adminIDs {
+		if adminID == joined.ID {
+			continue
+		}
+		_, err := s.notifications.CreateNotification(domain.Notification{
+			UserID:    adminID,
+			Title:     "New board member",
+			Message:   message,
+			Type:      "board_member_joined",
+			ExpiresAt: time.Now().Add(30 * 24 * time.Hour),
+		})
+		if err != nil {
+			s.logger.Errorf("Failed to notify admin %d of board %d: %v", adminID, invite.BoardID, err)
+		}
+	}
+}
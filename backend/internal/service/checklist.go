@@ -0,0 +1,298 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/lexorank"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+const maxChecklistItemLength = 500
+
+var (
+	ErrChecklistItemNotFound = errors.New("checklist item not found")
+	ErrChecklistMoveConflict = errors.New("the checklist changed in the meantime, reload it and try again")
+)
+
+type ChecklistStorage interface {
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+	SelectTask(userID, taskID int64) (domain.Task, error)
+	IsBoardMember(boardID, userID int64) (bool, error)
+	InsertChecklistItem(item domain.ChecklistItem) (domain.ChecklistItem, error)
+	SelectChecklistItems(taskID int64) ([]domain.ChecklistItem, error)
+	SelectChecklistItem(itemID int64) (domain.ChecklistItem, error)
+	UpdateChecklistItem(itemID int64, req domain.ChecklistItemRequest) (bool, error)
+	DeleteChecklistItem(itemID int64) (bool, error)
+	SelectLastChecklistPosition(taskID, excludeID int64) (string, error)
+	SelectNextChecklistPosition(taskID int64, position string, excludeID int64) (string, error)
+	MoveChecklistItem(itemID int64, position string) error
+	RebalanceChecklist(taskID int64) error
+	BoardRevisionStorage
+}
+
+// ChecklistService manages the steps inside a task. Viewers read the
+// checklist; editors change it. Every change is published together with the
+// task's new progress.
+type ChecklistService struct {
+	storage ChecklistStorage
+	events  boardEvents
+	logger  *logging.Logger
+}
+
+func NewChecklistService(storage ChecklistStorage, events BoardEventPublisher, logger *logging.Logger) *ChecklistService {
+	return &ChecklistService{
+		storage: storage,
+		events:  boardEvents{storage: storage, publisher: events, logger: logger},
+		logger:  logger,
+	}
+}
+
+func (s *ChecklistService) List(userID, taskID int64) ([]domain.ChecklistItem, error) {
+	if _, err := s.task(userID, taskID, domain.BoardRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectChecklistItems(taskID)
+}
+
+// Create adds an item at the end of the task's checklist.
+func (s *ChecklistService) Create(userID, taskID int64, req domain.ChecklistItemRequest) (domain.ChecklistItem, error) {
+	if req.Text == nil {
+		return domain.ChecklistItem{}, errors.New("text is required")
+	}
+	text, err := checklistText(*req.Text)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+
+	task, err := s.task(userID, taskID, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	if req.AssigneeID != nil {
+		if err := s.member(task.BoardID, *req.AssigneeID); err != nil {
+			return domain.ChecklistItem{}, err
+		}
+	}
+
+	last, err := s.storage.SelectLastChecklistPosition(taskID, 0)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	position, err := lexorank.Between(last, "")
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+
+	item, err := s.storage.InsertChecklistItem(domain.ChecklistItem{
+		TaskID:     taskID,
+		Text:       text,
+		AssigneeID: req.AssigneeID,
+		Position:   position,
+	})
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	if req.Done != nil && *req.Done {
+		if _, err := s.storage.UpdateChecklistItem(item.ID, domain.ChecklistItemRequest{Done: req.Done}); err != nil {
+			return domain.ChecklistItem{}, err
+		}
+		item.Done = true
+	}
+
+	s.publish(userID, task.BoardID, domain.BoardEventChecklistItemCreated, item)
+	return item, nil
+}
+
+func (s *ChecklistService) Update(userID, itemID int64, req domain.ChecklistItemRequest) (domain.ChecklistItem, error) {
+	if req.Text != nil {
+		text, err := checklistText(*req.Text)
+		if err != nil {
+			return domain.ChecklistItem{}, err
+		}
+		req.Text = &text
+	}
+
+	item, task, err := s.item(userID, itemID, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	if req.AssigneeID != nil && !req.ClearAssignee {
+		if err := s.member(task.BoardID, *req.AssigneeID); err != nil {
+			return domain.ChecklistItem{}, err
+		}
+	}
+
+	updated, err := s.storage.UpdateChecklistItem(item.ID, req)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	if !updated {
+		return domain.ChecklistItem{}, ErrChecklistItemNotFound
+	}
+
+	item, err = s.storage.SelectChecklistItem(item.ID)
+	if errors.Is(err, psql.ErrChecklistItemNotFound) {
+		return domain.ChecklistItem{}, ErrChecklistItemNotFound
+	}
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+
+	s.publish(userID, task.BoardID, domain.BoardEventChecklistItemUpdated, item)
+	return item, nil
+}
+
+// Move puts the item right after req.AfterID, or first when it is nil. When
+// the neighbours' keys collide, the checklist is rebalanced once and the move
+// retried.
+func (s *ChecklistService) Move(userID, itemID int64, req domain.ChecklistMoveRequest) (domain.ChecklistItem, error) {
+	item, task, err := s.item(userID, itemID, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	if req.AfterID != nil && *req.AfterID == item.ID {
+		return domain.ChecklistItem{}, errors.New("an item cannot be moved after itself")
+	}
+
+	for attempt := 0; ; attempt++ {
+		position, err := s.positionAfter(item, req.AfterID)
+		if errors.Is(err, lexorank.ErrInvalidRange) && attempt == 0 {
+			if err := s.storage.RebalanceChecklist(item.TaskID); err != nil {
+				return domain.ChecklistItem{}, err
+			}
+			continue
+		}
+		if errors.Is(err, lexorank.ErrInvalidRange) {
+			return domain.ChecklistItem{}, ErrChecklistMoveConflict
+		}
+		if err != nil {
+			return domain.ChecklistItem{}, err
+		}
+
+		if err := s.storage.MoveChecklistItem(item.ID, position); err != nil {
+			return domain.ChecklistItem{}, err
+		}
+		item.Position = position
+
+		s.publish(userID, task.BoardID, domain.BoardEventChecklistItemMoved, item)
+		return item, nil
+	}
+}
+
+func (s *ChecklistService) Delete(userID, itemID int64) error {
+	item, task, err := s.item(userID, itemID, domain.BoardRoleEditor)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.storage.DeleteChecklistItem(item.ID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrChecklistItemNotFound
+	}
+
+	s.publish(userID, task.BoardID, domain.BoardEventChecklistItemDeleted, item)
+	return nil
+}
+
+// positionAfter computes a key between the item after which the moved item
+// goes and the one following it, both read fresh.
+func (s *ChecklistService) positionAfter(item domain.ChecklistItem, afterID *int64) (string, error) {
+	lower := ""
+	if afterID != nil {
+		after, err := s.storage.SelectChecklistItem(*afterID)
+		if errors.Is(err, psql.ErrChecklistItemNotFound) || err == nil && after.TaskID != item.TaskID {
+			return "", ErrChecklistItemNotFound
+		}
+		if err != nil {
+			return "", err
+		}
+		lower = after.Position
+	}
+
+	upper, err := s.storage.SelectNextChecklistPosition(item.TaskID, lower, item.ID)
+	if err != nil {
+		return "", err
+	}
+	return lexorank.Between(lower, upper)
+}
+
+// publish sends a checklist change with the task's new progress. Items
+// that still exist are sent as they are by then.
+func (s *ChecklistService) publish(userID, boardID int64, eventType string, item domain.ChecklistItem) {
+	s.events.publishLatest(userID, boardID, eventType, func() (interface{}, error) {
+		if eventType != domain.BoardEventChecklistItemDeleted {
+			latest, err := s.storage.SelectChecklistItem(item.ID)
+			if err != nil {
+				return nil, err
+			}
+			item = latest
+		}
+		task, err := s.storage.SelectTask(userID, item.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		return domain.ChecklistEvent{Item: item, Progress: task.Progress}, nil
+	})
+}
+
+func (s *ChecklistService) member(boardID, userID int64) error {
+	member, err := s.storage.IsBoardMember(boardID, userID)
+	if err != nil {
+		return err
+	}
+	if !member {
+		return ErrNotBoardMember
+	}
+	return nil
+}
+
+// item loads a checklist item with its task, checking the user's role on the
+// board.
+func (s *ChecklistService) item(userID, itemID int64, minRole string) (domain.ChecklistItem, domain.Task, error) {
+	item, err := s.storage.SelectChecklistItem(itemID)
+	if errors.Is(err, psql.ErrChecklistItemNotFound) {
+		return domain.ChecklistItem{}, domain.Task{}, ErrChecklistItemNotFound
+	}
+	if err != nil {
+		return domain.ChecklistItem{}, domain.Task{}, err
+	}
+
+	task, err := s.task(userID, item.TaskID, minRole)
+	if errors.Is(err, ErrTaskNotFound) {
+		return domain.ChecklistItem{}, domain.Task{}, ErrChecklistItemNotFound
+	}
+	if err != nil {
+		return domain.ChecklistItem{}, domain.Task{}, err
+	}
+	return item, task, nil
+}
+
+func (s *ChecklistService) task(userID, taskID int64, minRole string) (domain.Task, error) {
+	task, err := s.storage.SelectTask(userID, taskID)
+	if errors.Is(err, psql.ErrTaskNotFound) {
+		return domain.Task{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	if _, err := boardAccess(s.storage, userID, task.BoardID, minRole); err != nil {
+		return domain.Task{}, err
+	}
+	return task, nil
+}
+
+func checklistText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || len(text) > maxChecklistItemLength {
+		return "", fmt.Errorf("text is required and must be at most %d characters", maxChecklistItemLength)
+	}
+	return text, nil
+}
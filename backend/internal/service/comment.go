@@ -0,0 +1,291 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/markdown"
+)
+
+const maxCommentLength = 10000
+
+var (
+	ErrCommentNotFound  = errors.New("comment not found")
+	ErrCommentForbidden = errors.New("only the author can change this comment")
+)
+
+type CommentStorage interface {
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+	SelectTask(userID, taskID int64) (domain.Task, error)
+	InsertComment(c domain.Comment) (domain.Comment, error)
+	SelectComments(userID, taskID int64) ([]domain.Comment, error)
+	SelectComment(userID, commentID int64) (domain.Comment, error)
+	UpdateComment(commentID, editorID int64, body string) (bool, error)
+	DeleteComment(commentID, editorID int64) (bool, error)
+	SelectCommentRevisions(commentID int64) ([]domain.CommentRevision, error)
+	SelectBoardMemberIDsByUsername(boardID int64, usernames []string) ([]int64, error)
+	TaskRecipientStorage
+	BoardRevisionStorage
+	TaskActivityStorage
+}
+
+// CommentService manages the discussion on tasks. Editors and admins of the
+// board can comment; only the author edits a comment, while the author or a
+// board admin may delete it. Mentioned board members are notified, and new
+// comments reach the task's assignees and watchers. Commenting on a task
+// starts watching it.
+type CommentService struct {
+	storage       CommentStorage
+	notifications NotificationCreator
+	watchers      taskNotifier
+	events        boardEvents
+	activity      taskActivity
+	logger        *logging.Logger
+}
+
+func NewCommentService(storage CommentStorage, notifications NotificationCreator, events BoardEventPublisher, listener ActivityListener, logger *logging.Logger) *CommentService {
+	return &CommentService{
+		storage:       storage,
+		notifications: notifications,
+		watchers:      taskNotifier{storage: storage, notifications: notifications, logger: logger},
+		events:        boardEvents{storage: storage, publisher: events, logger: logger},
+		activity:      taskActivity{storage: storage, listener: listener, logger: logger},
+		logger:        logger,
+	}
+}
+
+func (s *CommentService) List(userID, taskID int64) ([]domain.Comment, error) {
+	if _, err := s.task(userID, taskID, domain.BoardRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectComments(userID, taskID)
+}
+
+func (s *CommentService) Create(userID, taskID int64, req domain.CommentRequest) (domain.Comment, error) {
+	body, err := commentBody(req)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	task, err := s.task(userID, taskID, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	comment, err := s.storage.InsertComment(domain.Comment{
+		TaskID:  task.ID,
+		BoardID: task.BoardID,
+		UserID:  userID,
+		Body:    body,
+	})
+	if errors.Is(err, psql.ErrTaskNotFound) {
+		return domain.Comment{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	// Re-read for the author's username.
+	comment, err = s.comment(userID, comment.ID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	activity := s.activity.recordOne(activityEntry(userID, task.ID, task.BoardID, domain.ActivityCommentCreated, "", nil, activityComment(comment)))
+	mentioned := s.notifyMentions(task, comment, nil, activity)
+	s.watchers.notify(domain.Notification{
+		TaskID:    task.ID,
+		Title:     "New comment",
+		Message:   fmt.Sprintf("%s commented on %q.", comment.Username, task.Title),
+		Type:      "comment",
+		ExpiresAt: time.Now().Add(30 * 24 * time.Hour),
+		Activity:  activity,
+	}, append(mentioned, userID)...)
+	s.events.publishLatest(userID, task.BoardID, domain.BoardEventCommentCreated, s.commentLoader(userID, comment.ID))
+	return comment, nil
+}
+
+func (s *CommentService) Update(userID, commentID int64, req domain.CommentRequest) (domain.Comment, error) {
+	body, err := commentBody(req)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	previous, err := s.comment(userID, commentID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if previous.UserID != userID {
+		return domain.Comment{}, ErrCommentForbidden
+	}
+	task, err := s.task(userID, previous.TaskID, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	updated, err := s.storage.UpdateComment(commentID, userID, body)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if !updated {
+		return domain.Comment{}, ErrCommentNotFound
+	}
+
+	comment, err := s.comment(userID, commentID)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+
+	activity := s.activity.recordOne(activityEntry(userID, task.ID, task.BoardID, domain.ActivityCommentUpdated, "", activityComment(previous), activityComment(comment)))
+	s.notifyMentions(task, comment, markdown.Mentions(previous.Body), activity)
+	s.events.publishLatest(userID, task.BoardID, domain.BoardEventCommentUpdated, s.commentLoader(userID, commentID))
+	return comment, nil
+}
+
+func (s *CommentService) Delete(userID, commentID int64) error {
+	comment, err := s.comment(userID, commentID)
+	if err != nil {
+		return err
+	}
+
+	minRole := domain.BoardRoleAdmin
+	if comment.UserID == userID {
+		minRole = domain.BoardRoleEditor
+	}
+	if _, err := boardAccess(s.storage, userID, comment.BoardID, minRole); err != nil {
+		if errors.Is(err, ErrBoardForbidden) && comment.UserID != userID {
+			return ErrCommentForbidden
+		}
+		return err
+	}
+
+	deleted, err := s.storage.DeleteComment(commentID, userID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrCommentNotFound
+	}
+
+	s.activity.record(activityEntry(userID, comment.TaskID, comment.BoardID, domain.ActivityCommentDeleted, "", activityComment(comment), nil))
+
+	s.events.publish(userID, comment.BoardID, domain.BoardEventCommentDeleted, domain.CommentDeletedEvent{
+		ID:     comment.ID,
+		TaskID: comment.TaskID,
+	})
+	return nil
+}
+
+// History returns the earlier bodies of a comment, including the last one of
+// a deleted comment. It is open to the author and to board admins.
+func (s *CommentService) History(userID, commentID int64) ([]domain.CommentRevision, error) {
+	comment, err := s.storage.SelectComment(userID, commentID)
+	if errors.Is(err, psql.ErrCommentNotFound) {
+		return nil, ErrCommentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.UserID != userID {
+		if _, err := boardAccess(s.storage, userID, comment.BoardID, domain.BoardRoleAdmin); err != nil {
+			if errors.Is(err, ErrBoardForbidden) {
+				return nil, ErrCommentForbidden
+			}
+			return nil, err
+		}
+	}
+
+	return s.storage.SelectCommentRevisions(commentID)
+}
+
+// notifyMentions notifies the board members mentioned in comment, skipping
+// the author and anyone already mentioned in known, with the activity entry
+// of the change. It returns the users notified.
+func (s *CommentService) notifyMentions(task domain.Task, comment domain.Comment, known []string, activity *domain.TaskActivity) []int64 {
+	skip := make(map[string]bool, len(known))
+	for _, name := range known {
+		skip[name] = true
+	}
+
+	var names []string
+	for _, name := range markdown.Mentions(comment.Body) {
+		if !skip[name] && name != strings.ToLower(comment.Username) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	userIDs, err := s.storage.SelectBoardMemberIDsByUsername(task.BoardID, names)
+	if err != nil {
+		s.logger.Errorf("Failed to resolve mentions in comment %d: %v", comment.ID, err)
+		return nil
+	}
+
+	var notified []int64
+	message := fmt.Sprintf("%s mentioned you on %q.", comment.Username, task.Title)
+	for _, userID := range userIDs {
+		if userID == comment.UserID {
+			continue
+		}
+		_, err := s.notifications.CreateNotification(domain.Notification{
+			UserID:    userID,
+			TaskID:    task.ID,
+			Title:     "You were mentioned",
+			Message:   message,
+			Type:      "comment_mention",
+			ExpiresAt: time.Now().Add(30 * 24 * time.Hour),
+			Activity:  activity,
+		})
+		if err != nil {
+			s.logger.Errorf("Failed to notify user %d of mention in comment %d: %v", userID, comment.ID, err)
+			continue
+		}
+		notified = append(notified, userID)
+	}
+	return notified
+}
+
+// comment loads a live comment the user can see.
+func (s *CommentService) comment(userID, commentID int64) (domain.Comment, error) {
+	comment, err := s.storage.SelectComment(userID, commentID)
+	if errors.Is(err, psql.ErrCommentNotFound) || err == nil && comment.DeletedAt != nil {
+		return domain.Comment{}, ErrCommentNotFound
+	}
+	return comment, err
+}
+
+func (s *CommentService) commentLoader(userID, commentID int64) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		return s.comment(userID, commentID)
+	}
+}
+
+func (s *CommentService) task(userID, taskID int64, minRole string) (domain.Task, error) {
+	task, err := s.storage.SelectTask(userID, taskID)
+	if errors.Is(err, psql.ErrTaskNotFound) {
+		return domain.Task{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if _, err := boardAccess(s.storage, userID, task.BoardID, minRole); err != nil {
+		return domain.Task{}, err
+	}
+	return task, nil
+}
+
+func commentBody(req domain.CommentRequest) (string, error) {
+	body := strings.TrimSpace(markdown.Sanitize(req.Body))
+	if body == "" || len(body) > maxCommentLength {
+		return "", fmt.Errorf("comment body is required and must be at most %d characters", maxCommentLength)
+	}
+	return body, nil
+}
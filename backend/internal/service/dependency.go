@@ -0,0 +1,134 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+var (
+	ErrDependencyNotFound = errors.New("the task is not blocked by this task")
+	ErrDependencyCycle    = errors.New("the dependency would create a cycle")
+	ErrInvalidBlocker     = errors.New("a blocker must be another task on the same board")
+)
+
+type DependencyStorage interface {
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+	SelectTask(userID, taskID int64) (domain.Task, error)
+	AddTaskDependency(boardID, blockerID, blockedID, createdBy int64) (bool, error)
+	RemoveTaskDependency(blockerID, blockedID int64) (bool, error)
+	SelectTaskBlockers(taskID int64) ([]domain.TaskRef, error)
+	SelectBlockedTasks(taskID int64) ([]domain.TaskRef, error)
+	BoardRevisionStorage
+}
+
+// DependencyService manages which tasks block which. Both tasks of a
+// dependency live on the same board and dependencies never form a cycle.
+type DependencyService struct {
+	storage DependencyStorage
+	events  boardEvents
+	logger  *logging.Logger
+}
+
+func NewDependencyService(storage DependencyStorage, events BoardEventPublisher, logger *logging.Logger) *DependencyService {
+	return &DependencyService{
+		storage: storage,
+		events:  boardEvents{storage: storage, publisher: events, logger: logger},
+		logger:  logger,
+	}
+}
+
+func (s *DependencyService) Dependencies(userID, taskID int64) (domain.TaskDependencies, error) {
+	if _, err := s.task(userID, taskID, domain.BoardRoleViewer); err != nil {
+		return domain.TaskDependencies{}, err
+	}
+	return s.dependencies(taskID)
+}
+
+// AddBlocker records that blockerID blocks the task. Adding it twice is not
+// an error.
+func (s *DependencyService) AddBlocker(userID, taskID, blockerID int64) (domain.TaskDependencies, error) {
+	task, err := s.task(userID, taskID, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.TaskDependencies{}, err
+	}
+	if blockerID == task.ID {
+		return domain.TaskDependencies{}, ErrInvalidBlocker
+	}
+
+	blocker, err := s.storage.SelectTask(userID, blockerID)
+	if errors.Is(err, psql.ErrTaskNotFound) {
+		return domain.TaskDependencies{}, ErrInvalidBlocker
+	}
+	if err != nil {
+		return domain.TaskDependencies{}, err
+	}
+	if blocker.BoardID != task.BoardID {
+		return domain.TaskDependencies{}, ErrInvalidBlocker
+	}
+
+	added, err := s.storage.AddTaskDependency(task.BoardID, blocker.ID, task.ID, userID)
+	if errors.Is(err, psql.ErrDependencyCycle) {
+		return domain.TaskDependencies{}, ErrDependencyCycle
+	}
+	if err != nil {
+		return domain.TaskDependencies{}, err
+	}
+	if added {
+		s.publishTask(userID, task.BoardID, task.ID)
+	}
+
+	return s.dependencies(task.ID)
+}
+
+func (s *DependencyService) RemoveBlocker(userID, taskID, blockerID int64) error {
+	task, err := s.task(userID, taskID, domain.BoardRoleEditor)
+	if err != nil {
+		return err
+	}
+
+	removed, err := s.storage.RemoveTaskDependency(blockerID, taskID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrDependencyNotFound
+	}
+
+	s.publishTask(userID, task.BoardID, taskID)
+	return nil
+}
+
+func (s *DependencyService) dependencies(taskID int64) (domain.TaskDependencies, error) {
+	blockedBy, err := s.storage.SelectTaskBlockers(taskID)
+	if err != nil {
+		return domain.TaskDependencies{}, err
+	}
+	blocks, err := s.storage.SelectBlockedTasks(taskID)
+	if err != nil {
+		return domain.TaskDependencies{}, err
+	}
+	return domain.TaskDependencies{BlockedBy: blockedBy, Blocks: blocks}, nil
+}
+
+// publishTask sends the task with its new blockers to the board.
+func (s *DependencyService) publishTask(userID, boardID, taskID int64) {
+	s.events.publishLatest(userID, boardID, domain.BoardEventTaskUpdated, taskLoader(s.storage, userID, taskID))
+}
+
+func (s *DependencyService) task(userID, taskID int64, minRole string) (domain.Task, error) {
+	task, err := s.storage.SelectTask(userID, taskID)
+	if errors.Is(err, psql.ErrTaskNotFound) {
+		return domain.Task{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	if _, err := boardAccess(s.storage, userID, task.BoardID, minRole); err != nil {
+		return domain.Task{}, err
+	}
+	return task, nil
+}
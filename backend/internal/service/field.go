@@ -0,0 +1,515 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+const (
+	maxFieldOptions    = 50
+	maxFieldOptionLen  = 50
+	maxFieldTextLength = 1000
+	fieldDateLayout    = "2006-01-02"
+	defaultLabelColor  = "#6b7280"
+	maxLabelNameLength = 50
+	maxFieldNameLength = 100
+	fieldFilterEqual   = "eq"
+	fieldFilterAtLeast = "gte"
+	fieldFilterAtMost  = "lte"
+)
+
+var (
+	ErrLabelNotFound = errors.New("label not found")
+	ErrLabelExists   = errors.New("a label with this name already exists on the board")
+	ErrFieldNotFound = errors.New("custom field not found")
+	ErrFieldExists   = errors.New("a custom field with this name already exists on the board")
+)
+
+var labelColor = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+var priorities = map[string]bool{
+	domain.PriorityNone:   true,
+	domain.PriorityLow:    true,
+	domain.PriorityMedium: true,
+	domain.PriorityHigh:   true,
+	domain.PriorityUrgent: true,
+}
+
+var fieldTypes = map[string]bool{
+	domain.FieldTypeText:        true,
+	domain.FieldTypeNumber:      true,
+	domain.FieldTypeDate:        true,
+	domain.FieldTypeSelect:      true,
+	domain.FieldTypeMultiSelect: true,
+	domain.FieldTypeUser:        true,
+}
+
+type FieldStorage interface {
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+	InsertLabel(l domain.Label) (domain.Label, error)
+	SelectLabels(boardID int64) ([]domain.Label, error)
+	SelectLabel(labelID int64) (domain.Label, error)
+	UpdateLabel(labelID int64, req domain.LabelRequest) (bool, error)
+	DeleteLabel(labelID int64) (bool, error)
+	InsertCustomField(f domain.CustomField) (domain.CustomField, error)
+	SelectCustomFields(boardID int64) ([]domain.CustomField, error)
+	SelectCustomField(fieldID int64) (domain.CustomField, error)
+	UpdateCustomField(fieldID int64, req domain.CustomFieldRequest) (bool, error)
+	DeleteCustomField(fieldID int64) (bool, error)
+	BoardRevisionStorage
+}
+
+// FieldService manages the labels and custom fields of boards. Any member
+// can read them; editors manage labels, while custom fields, which shape
+// every task of the board, are left to admins.
+type FieldService struct {
+	storage FieldStorage
+	events  boardEvents
+	logger  *logging.Logger
+}
+
+func NewFieldService(storage FieldStorage, events BoardEventPublisher, logger *logging.Logger) *FieldService {
+	return &FieldService{
+		storage: storage,
+		events:  boardEvents{storage: storage, publisher: events, logger: logger},
+		logger:  logger,
+	}
+}
+
+func (s *FieldService) Labels(userID, boardID int64) ([]domain.Label, error) {
+	if _, err := boardAccess(s.storage, userID, boardID, domain.BoardRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectLabels(boardID)
+}
+
+func (s *FieldService) CreateLabel(userID, boardID int64, req domain.LabelRequest) (domain.Label, error) {
+	if req.Name == nil {
+		return domain.Label{}, errors.New("label name is required")
+	}
+	if req.Color == nil {
+		color := defaultLabelColor
+		req.Color = &color
+	}
+	if err := validateLabelRequest(&req); err != nil {
+		return domain.Label{}, err
+	}
+	if _, err := boardAccess(s.storage, userID, boardID, domain.BoardRoleEditor); err != nil {
+		return domain.Label{}, err
+	}
+
+	label, err := s.storage.InsertLabel(domain.Label{
+		BoardID: boardID,
+		Name:    *req.Name,
+		Color:   *req.Color,
+	})
+	if errors.Is(err, psql.ErrLabelExists) {
+		return domain.Label{}, ErrLabelExists
+	}
+	if err != nil {
+		return domain.Label{}, err
+	}
+
+	s.events.publishLatest(userID, boardID, domain.BoardEventLabelCreated, s.labelLoader(label.ID))
+	return label, nil
+}
+
+func (s *FieldService) UpdateLabel(userID, labelID int64, req domain.LabelRequest) (domain.Label, error) {
+	if err := validateLabelRequest(&req); err != nil {
+		return domain.Label{}, err
+	}
+	label, err := s.label(userID, labelID, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.Label{}, err
+	}
+
+	updated, err := s.storage.UpdateLabel(labelID, req)
+	if errors.Is(err, psql.ErrLabelExists) {
+		return domain.Label{}, ErrLabelExists
+	}
+	if err != nil {
+		return domain.Label{}, err
+	}
+	if !updated {
+		return domain.Label{}, ErrLabelNotFound
+	}
+
+	label, err = s.label(userID, labelID, domain.BoardRoleViewer)
+	if err != nil {
+		return domain.Label{}, err
+	}
+	s.events.publishLatest(userID, label.BoardID, domain.BoardEventLabelUpdated, s.labelLoader(labelID))
+	return label, nil
+}
+
+// DeleteLabel removes the label from the board and from all of its tasks.
+func (s *FieldService) DeleteLabel(userID, labelID int64) error {
+	label, err := s.label(userID, labelID, domain.BoardRoleEditor)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.storage.DeleteLabel(labelID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrLabelNotFound
+	}
+
+	s.events.publish(userID, label.BoardID, domain.BoardEventLabelDeleted, domain.LabelDeletedEvent{ID: labelID})
+	return nil
+}
+
+func (s *FieldService) Fields(userID, boardID int64) ([]domain.CustomField, error) {
+	if _, err := boardAccess(s.storage, userID, boardID, domain.BoardRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectCustomFields(boardID)
+}
+
+func (s *FieldService) CreateField(userID, boardID int64, req domain.CustomFieldRequest) (domain.CustomField, error) {
+	if req.Name == nil {
+		return domain.CustomField{}, errors.New("field name is required")
+	}
+	if !fieldTypes[req.Type] {
+		return domain.CustomField{}, errors.New("field type must be one of text, number, date, select, multi_select, user")
+	}
+	if err := validateFieldRequest(req.Type, &req); err != nil {
+		return domain.CustomField{}, err
+	}
+	if _, err := boardAccess(s.storage, userID, boardID, domain.BoardRoleAdmin); err != nil {
+		return domain.CustomField{}, err
+	}
+
+	field := domain.CustomField{
+		BoardID: boardID,
+		Name:    *req.Name,
+		Type:    req.Type,
+		Options: []string{},
+	}
+	if req.Options != nil {
+		field.Options = *req.Options
+	}
+	if selectable(field.Type) && len(field.Options) == 0 {
+		return domain.CustomField{}, errors.New("select fields need at least one option")
+	}
+
+	field, err := s.storage.InsertCustomField(field)
+	if errors.Is(err, psql.ErrFieldExists) {
+		return domain.CustomField{}, ErrFieldExists
+	}
+	if err != nil {
+		return domain.CustomField{}, err
+	}
+
+	s.events.publishLatest(userID, boardID, domain.BoardEventFieldCreated, s.fieldLoader(field.ID))
+	return field, nil
+}
+
+// UpdateField renames a field or replaces its options. Tasks lose the
+// options that were removed.
+func (s *FieldService) UpdateField(userID, fieldID int64, req domain.CustomFieldRequest) (domain.CustomField, error) {
+	field, err := s.field(userID, fieldID, domain.BoardRoleAdmin)
+	if err != nil {
+		return domain.CustomField{}, err
+	}
+	if req.Type != "" && req.Type != field.Type {
+		return domain.CustomField{}, errors.New("the type of a field cannot be changed")
+	}
+	if err := validateFieldRequest(field.Type, &req); err != nil {
+		return domain.CustomField{}, err
+	}
+
+	updated, err := s.storage.UpdateCustomField(fieldID, req)
+	if errors.Is(err, psql.ErrFieldExists) {
+		return domain.CustomField{}, ErrFieldExists
+	}
+	if err != nil {
+		return domain.CustomField{}, err
+	}
+	if !updated {
+		return domain.CustomField{}, ErrFieldNotFound
+	}
+
+	field, err = s.field(userID, fieldID, domain.BoardRoleViewer)
+	if err != nil {
+		return domain.CustomField{}, err
+	}
+	s.events.publishLatest(userID, field.BoardID, domain.BoardEventFieldUpdated, s.fieldLoader(fieldID))
+	return field, nil
+}
+
+// DeleteField removes the field and its values on all tasks.
+func (s *FieldService) DeleteField(userID, fieldID int64) error {
+	field, err := s.field(userID, fieldID, domain.BoardRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.storage.DeleteCustomField(fieldID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrFieldNotFound
+	}
+
+	s.events.publish(userID, field.BoardID, domain.BoardEventFieldDeleted, domain.FieldDeletedEvent{ID: fieldID})
+	return nil
+}
+
+// label loads a label and checks the user's role on its board. Labels of
+// boards the user cannot open are reported as not found.
+func (s *FieldService) labelLoader(labelID int64) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		return s.storage.SelectLabel(labelID)
+	}
+}
+
+func (s *FieldService) fieldLoader(fieldID int64) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		return s.storage.SelectCustomField(fieldID)
+	}
+}
+
+func (s *FieldService) label(userID, labelID int64, minRole string) (domain.Label, error) {
+	label, err := s.storage.SelectLabel(labelID)
+	if errors.Is(err, psql.ErrLabelNotFound) {
+		return domain.Label{}, ErrLabelNotFound
+	}
+	if err != nil {
+		return domain.Label{}, err
+	}
+	if _, err := boardAccess(s.storage, userID, label.BoardID, minRole); err != nil {
+		if errors.Is(err, ErrBoardNotFound) {
+			return domain.Label{}, ErrLabelNotFound
+		}
+		return domain.Label{}, err
+	}
+	return label, nil
+}
+
+func (s *FieldService) field(userID, fieldID int64, minRole string) (domain.CustomField, error) {
+	field, err := s.storage.SelectCustomField(fieldID)
+	if errors.Is(err, psql.ErrFieldNotFound) {
+		return domain.CustomField{}, ErrFieldNotFound
+	}
+	if err != nil {
+		return domain.CustomField{}, err
+	}
+	if _, err := boardAccess(s.storage, userID, field.BoardID, minRole); err != nil {
+		if errors.Is(err, ErrBoardNotFound) {
+			return domain.CustomField{}, ErrFieldNotFound
+		}
+		return domain.CustomField{}, err
+	}
+	return field, nil
+}
+
+func validateLabelRequest(req *domain.LabelRequest) error {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len(name) > maxLabelNameLength {
+			return fmt.Errorf("label name is required and must be at most %d characters", maxLabelNameLength)
+		}
+		req.Name = &name
+	}
+	if req.Color != nil {
+		color := strings.ToLower(strings.TrimSpace(*req.Color))
+		if !labelColor.MatchString(color) {
+			return errors.New("label color must be a hex color like #1f883d")
+		}
+		req.Color = &color
+	}
+	return nil
+}
+
+// validateFieldRequest checks req against a field of type fieldType. Only
+// select fields take options, and a list given must not be empty.
+func validateFieldRequest(fieldType string, req *domain.CustomFieldRequest) error {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len(name) > maxFieldNameLength {
+			return fmt.Errorf("field name is required and must be at most %d characters", maxFieldNameLength)
+		}
+		req.Name = &name
+	}
+
+	if req.Options == nil {
+		return nil
+	}
+	if !selectable(fieldType) {
+		return errors.New("only select and multi_select fields have options")
+	}
+
+	options := make([]string, 0, len(*req.Options))
+	seen := make(map[string]bool, len(*req.Options))
+	for _, option := range *req.Options {
+		option = strings.TrimSpace(option)
+		if option == "" || len(option) > maxFieldOptionLen {
+			return fmt.Errorf("field options must be non-empty and at most %d characters", maxFieldOptionLen)
+		}
+		if seen[option] {
+			return fmt.Errorf("field option %q is listed twice", option)
+		}
+		seen[option] = true
+		options = append(options, option)
+	}
+	if len(options) == 0 || len(options) > maxFieldOptions {
+		return fmt.Errorf("select fields need between 1 and %d options", maxFieldOptions)
+	}
+	req.Options = &options
+	return nil
+}
+
+// parseFieldValue validates raw for field. JSON null, an empty string or an
+// empty list clears the value. User fields are checked for board membership
+// by the caller.
+func parseFieldValue(field domain.CustomField, raw json.RawMessage) (domain.FieldValue, error) {
+	value := domain.FieldValue{FieldID: field.ID}
+	if len(raw) == 0 || string(raw) == "null" {
+		return value, nil
+	}
+
+	invalid := func(expected string) error {
+		return fmt.Errorf("field %q expects %s", field.Name, expected)
+	}
+
+	switch field.Type {
+	case domain.FieldTypeNumber:
+		var number float64
+		if err := json.Unmarshal(raw, &number); err != nil {
+			return value, invalid("a number")
+		}
+		value.Number = &number
+	case domain.FieldTypeUser:
+		var userID int64
+		if err := json.Unmarshal(raw, &userID); err != nil || userID <= 0 {
+			return value, invalid("a user id")
+		}
+		value.UserID = &userID
+	case domain.FieldTypeMultiSelect:
+		var options []string
+		if err := json.Unmarshal(raw, &options); err != nil {
+			return value, invalid("a list of options")
+		}
+		seen := make(map[string]bool, len(options))
+		for _, option := range options {
+			if !hasOption(field, option) {
+				return value, invalid("options from its list")
+			}
+			if !seen[option] {
+				seen[option] = true
+				value.Options = append(value.Options, option)
+			}
+		}
+	default:
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			return value, invalid("a string")
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return value, nil
+		}
+		return parseFieldText(field, text)
+	}
+	return value, nil
+}
+
+// parseFieldText parses a non-empty string value of a text, date or select
+// field, or of any field when it comes from a query string.
+func parseFieldText(field domain.CustomField, text string) (domain.FieldValue, error) {
+	value := domain.FieldValue{FieldID: field.ID}
+	invalid := func(expected string) error {
+		return fmt.Errorf("field %q expects %s", field.Name, expected)
+	}
+
+	switch field.Type {
+	case domain.FieldTypeText:
+		if len(text) > maxFieldTextLength {
+			return value, invalid(fmt.Sprintf("at most %d characters", maxFieldTextLength))
+		}
+		value.Text = &text
+	case domain.FieldTypeNumber:
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return value, invalid("a number")
+		}
+		value.Number = &number
+	case domain.FieldTypeDate:
+		date, err := time.Parse(fieldDateLayout, text)
+		if err != nil {
+			return value, invalid("a date as YYYY-MM-DD")
+		}
+		value.Date = &date
+	case domain.FieldTypeUser:
+		userID, err := strconv.ParseInt(text, 10, 64)
+		if err != nil || userID <= 0 {
+			return value, invalid("a user id")
+		}
+		value.UserID = &userID
+	case domain.FieldTypeSelect, domain.FieldTypeMultiSelect:
+		if !hasOption(field, text) {
+			return value, invalid("one of its options")
+		}
+		value.Options = []string{text}
+	}
+	return value, nil
+}
+
+// parseFieldFilter resolves f.Value for field into f.Typed. Select options
+// are matched through Typed.Text, which the query compares with the chosen
+// options.
+func parseFieldFilter(field domain.CustomField, f *domain.FieldFilter) error {
+	if f.Op == "" {
+		f.Op = fieldFilterEqual
+	}
+	switch f.Op {
+	case fieldFilterEqual:
+	case fieldFilterAtLeast, fieldFilterAtMost:
+		if field.Type != domain.FieldTypeNumber && field.Type != domain.FieldTypeDate {
+			return fmt.Errorf("field %q can only be filtered by equality", field.Name)
+		}
+	default:
+		return fmt.Errorf("unknown filter operator %q", f.Op)
+	}
+
+	text := strings.TrimSpace(f.Value)
+	if text == "" {
+		return fmt.Errorf("a filter on field %q needs a value", field.Name)
+	}
+	value, err := parseFieldText(field, text)
+	if err != nil {
+		return err
+	}
+	if len(value.Options) > 0 {
+		value.Text = &value.Options[0]
+		value.Options = nil
+	}
+	f.Typed = value
+	return nil
+}
+
+func selectable(fieldType string) bool {
+	return fieldType == domain.FieldTypeSelect || fieldType == domain.FieldTypeMultiSelect
+}
+
+func hasOption(field domain.CustomField, option string) bool {
+	for _, o := range field.Options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/pkg/config"
+)
+
+type CaptchaVerifier interface {
+	Verify(token, ip string) (bool, error)
+}
+
+type NotificationCreator interface {
+	CreateNotification(n domain.Notification) (domain.Notification, error)
+}
+
+// LoginProtection holds the brute-force thresholds. Failures are counted per
+// account and per client IP inside Window; every lockout of the same account
+// doubles the previous lockout, starting at BaseLockout and capped at
+// MaxLockout. A nil Captcha disables the CAPTCHA step.
+type LoginProtection struct {
+	Window             time.Duration
+	MaxAccountFailures int
+	MaxIPFailures      int
+	CaptchaAfter       int
+	BaseLockout        time.Duration
+	MaxLockout         time.Duration
+	Captcha            CaptchaVerifier
+}
+
+func NewLoginProtection(cfg config.LoginProtectionConfig, captcha CaptchaVerifier) LoginProtection {
+	return LoginProtection{
+		Window:             cfg.LoginFailureWindow,
+		MaxAccountFailures: cfg.LoginMaxAccountFailures,
+		MaxIPFailures:      cfg.LoginMaxIPFailures,
+		CaptchaAfter:       cfg.LoginCaptchaAfter,
+		BaseLockout:        cfg.LoginBaseLockout,
+		MaxLockout:         cfg.LoginMaxLockout,
+		Captcha:            captcha,
+	}
+}
+
+// LoginError is returned by UserLogin when the failure carries state the
+// client has to act on: wait RetryAfter, solve a CAPTCHA on the next try, or
+// set a new password with ResetToken.
+type LoginError struct {
+	Message         string
+	CaptchaRequired bool
+	RetryAfter      time.Duration
+	ResetToken      string
+}
+
+func (e *LoginError) Error() string {
+	return e.Message
+}
+
+func (p LoginProtection) lockoutDuration(lockouts int) time.Duration {
+	if lockouts < 1 {
+		lockouts = 1
+	}
+
+	d := time.Duration(float64(p.BaseLockout) * math.Pow(2, float64(lockouts-1)))
+	if d <= 0 || d > p.MaxLockout {
+		return p.MaxLockout
+	}
+	return d
+}
+
+func (s *User) ipLockRemaining(ip string) (time.Duration, error) {
+	if ip == "" || s.protection.MaxIPFailures <= 0 {
+		return 0, nil
+	}
+
+	failures, lastFailure, err := s.storage.GetFailedIPAttempts(ip, time.Now().UTC().Add(-s.protection.Window))
+	if err != nil {
+		return 0, err
+	}
+
+	if failures < s.protection.MaxIPFailures {
+		return 0, nil
+	}
+
+	until := lastFailure.Add(s.protection.lockoutDuration(failures / s.protection.MaxIPFailures))
+	return time.Until(until), nil
+}
+
+func (s *User) captchaRequired(email, ip string) (bool, error) {
+	if s.protection.Captcha == nil || s.protection.CaptchaAfter <= 0 {
+		return false, nil
+	}
+
+	accountFailures, err := s.GetFailedAttempts(email)
+	if err != nil {
+		return false, err
+	}
+	if accountFailures >= int64(s.protection.CaptchaAfter) {
+		return true, nil
+	}
+
+	if ip == "" {
+		return false, nil
+	}
+
+	ipFailures, _, err := s.storage.GetFailedIPAttempts(ip, time.Now().UTC().Add(-s.protection.Window))
+	if err != nil {
+		return false, err
+	}
+	return ipFailures >= s.protection.CaptchaAfter, nil
+}
+
+// registerFailure logs the failed attempt and locks the account once it has
+// collected too many failures in the window. dbUser is nil for unknown emails.
+func (s *User) registerFailure(email string, dbUser *domain.User, reason string, client domain.ClientInfo) error {
+	s.LogLoginAttempt(email, false, client)
+
+	var userID int64
+	if dbUser != nil {
+		userID = dbUser.ID
+	}
+	s.recordLoginFailure(email, userID, reason, client)
+
+	failures, err := s.GetFailedAttempts(email)
+	if err != nil {
+		return err
+	}
+
+	if dbUser != nil && failures >= int64(s.protection.MaxAccountFailures) {
+		lockouts, err := s.storage.GetLockoutCount(email)
+		if err != nil {
+			return err
+		}
+
+		duration := s.protection.lockoutDuration(lockouts + 1)
+		s.BlockUser(email, duration)
+		s.notifyLockout(*dbUser, failures, duration, client)
+		s.recordEvent(domain.AuditAccountLocked, 0, dbUser.ID, client, map[string]interface{}{
+			"failures":         failures,
+			"lockout":          lockouts + 1,
+			"duration_seconds": int64(duration.Seconds()),
+		})
+
+		return &LoginError{
+			Message:    "too many failed attempts, account blocked",
+			RetryAfter: duration,
+		}
+	}
+
+	captcha, err := s.captchaRequired(email, client.IP)
+	if err != nil {
+		return err
+	}
+
+	return &LoginError{Message: "invalid credentials", CaptchaRequired: captcha}
+}
+
+func (s *User) notifyLockout(user domain.User, failures int64, duration time.Duration, client domain.ClientInfo) {
+	if s.notifications == nil {
+		return
+	}
+
+	_, err := s.notifications.CreateNotification(domain.Notification{
+		UserID: user.ID,
+		Title:  "Account temporarily locked",
+		Message: fmt.Sprintf(
+			"We locked your account for %s after %d failed sign-in attempts. The last attempt came from %s. If this was not you, change your password.",
+			duration.Round(time.Second), failures, client.IP,
+		),
+		Type:      "security",
+		ExpiresAt: time.Now().Add(30 * 24 * time.Hour),
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to notify user %d of a login lock: %v", user.ID, err)
+	}
+}
+
+// UnlockAccount lifts an account and/or IP lock and forgets the failures that
+// led to it. Either argument may be empty.
+func (s *User) UnlockAccount(actor domain.AdminActor, email, ip string) error {
+	if email == "" && ip == "" {
+		return fmt.Errorf("email or ip is required")
+	}
+
+	if email != "" {
+		if err := s.storage.ResetFailedAttempts(email); err != nil {
+			return err
+		}
+	}
+
+	if err := s.storage.ClearLoginAttempts(email, ip); err != nil {
+		return err
+	}
+
+	return s.audit.Record(newAuditEvent(domain.AuditAdminUnlockLogin, actor.UserID, 0, actor.Client, map[string]interface{}{
+		"email": email,
+		"ip":    ip,
+	}))
+}
+
+// recordEvent writes a security event on behalf of a user. The operation it
+// describes has already happened, so a failed write is only logged by the
+// recorder.
+func (s *User) recordEvent(eventType string, actorID, targetUserID int64, client domain.ClientInfo, details map[string]interface{}) {
+	_ = s.audit.Record(newAuditEvent(eventType, actorID, targetUserID, client, details))
+}
+
+// recordLoginFailure keeps the attempted email in the details: for unknown
+// emails there is no user to attach the event to.
+func (s *User) recordLoginFailure(email string, userID int64, reason string, client domain.ClientInfo) {
+	s.recordEvent(domain.AuditLoginFailed, 0, userID, client, map[string]interface{}{
+		"email":  email,
+		"reason": reason,
+	})
+}
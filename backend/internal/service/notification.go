@@ -11,12 +11,14 @@ import (
 
 type NotificationService struct {
 	storage *psql.Storage
+	tasks   taskNotifier
 	logger  *logging.Logger
 }
 
 func NewNotificationService(storage *psql.Storage, logger *logging.Logger) *NotificationService {
 	return &NotificationService{
 		storage: storage,
+		tasks:   taskNotifier{storage: storage, notifications: storage, logger: logger},
 		logger:  logger,
 	}
 }
@@ -59,16 +61,14 @@ func (s *NotificationService) checkDeadlines() {
 	}
 
 	for _, task := range tasks {
-		_, err := s.storage.CreateNotification(domain.Notification{
-			UserID:  task.UserID,
+		sent := s.tasks.notify(domain.Notification{
 			TaskID:  task.ID,
 			Title:   "Task Deadline Approaching",
 			Message: "Task '" + task.Title + "' is due soon.",
 			Type:    "deadline",
 		})
-		if err != nil {
-			continue
+		if sent > 0 {
+			s.logger.Infof("Created deadline notifications for task %d for %d users", task.ID, sent)
 		}
-		s.logger.Infof("Created deadline notification for task %d user %d", task.ID, task.UserID)
 	}
 }
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/config"
+	"github.com/your-team/taskmanager-chat/backend/pkg/imaging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/utils"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+	maxAvatarPixels    = 40_000_000
+)
+
+var (
+	ErrUserNotFound  = errors.New("user not found")
+	ErrUsernameTaken = errors.New("username already taken")
+	ErrAvatarInvalid = errors.New("avatar must be a JPEG, PNG or GIF image")
+	ErrAvatarTooBig  = errors.New("avatar file is too large")
+
+	usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,50}$`)
+	localePattern   = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+)
+
+type ProfileStorage interface {
+	SelectProfile(userID int64) (domain.UserProfile, error)
+	UpdateProfile(userID int64, update domain.ProfileUpdate) (domain.UserProfile, error)
+	UpdateAvatar(userID int64, avatarURL string) error
+	SearchUsers(viewerID, workspaceID int64, query string, limit int) ([]domain.PublicProfile, error)
+}
+
+type ProfileService struct {
+	storage ProfileStorage
+	cfg     config.ProfileConfig
+}
+
+func NewProfileService(storage ProfileStorage, cfg config.ProfileConfig) *ProfileService {
+	return &ProfileService{storage: storage, cfg: cfg}
+}
+
+func (s *ProfileService) Get(userID int64) (domain.UserProfile, error) {
+	profile, err := s.storage.SelectProfile(userID)
+	if err != nil {
+		return domain.UserProfile{}, mapProfileError(err)
+	}
+	return profile, nil
+}
+
+func (s *ProfileService) GetPublic(userID int64) (domain.PublicProfile, error) {
+	profile, err := s.Get(userID)
+	if err != nil {
+		return domain.PublicProfile{}, err
+	}
+
+	return domain.PublicProfile{
+		ID:        profile.ID,
+		Username:  profile.Username,
+		Firstname: profile.Firstname,
+		Lastname:  profile.Lastname,
+		AvatarURL: profile.AvatarURL,
+	}, nil
+}
+
+func (s *ProfileService) Update(userID int64, update domain.ProfileUpdate) (domain.UserProfile, error) {
+	if err := validateProfileUpdate(&update); err != nil {
+		return domain.UserProfile{}, err
+	}
+
+	profile, err := s.storage.UpdateProfile(userID, update)
+	if err != nil {
+		return domain.UserProfile{}, mapProfileError(err)
+	}
+	return profile, nil
+}
+
+// UploadAvatar decodes the uploaded image, crops it to a square of
+// AvatarSize pixels and stores it as PNG, replacing the previous avatar.
+func (s *ProfileService) UploadAvatar(userID int64, r io.Reader) (domain.UserProfile, error) {
+	profile, err := s.Get(userID)
+	if err != nil {
+		return domain.UserProfile{}, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, s.cfg.AvatarMaxBytes+1))
+	if err != nil {
+		return domain.UserProfile{}, err
+	}
+	if int64(len(data)) > s.cfg.AvatarMaxBytes {
+		return domain.UserProfile{}, ErrAvatarTooBig
+	}
+
+	imgCfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return domain.UserProfile{}, ErrAvatarInvalid
+	}
+	if imgCfg.Width*imgCfg.Height > maxAvatarPixels {
+		return domain.UserProfile{}, ErrAvatarTooBig
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return domain.UserProfile{}, ErrAvatarInvalid
+	}
+
+	if err := os.MkdirAll(s.cfg.AvatarDir, 0o755); err != nil {
+		return domain.UserProfile{}, err
+	}
+
+	suffix, err := utils.GenerateRandomString(8)
+	if err != nil {
+		return domain.UserProfile{}, err
+	}
+	name := fmt.Sprintf("%d-%s.png", userID, suffix)
+
+	file, err := os.Create(filepath.Join(s.cfg.AvatarDir, name))
+	if err != nil {
+		return domain.UserProfile{}, err
+	}
+	if err := png.Encode(file, imaging.Square(img, s.cfg.AvatarSize)); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return domain.UserProfile{}, err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return domain.UserProfile{}, err
+	}
+
+	avatarURL := strings.TrimRight(s.cfg.AvatarURLPath, "/") + "/" + name
+	if err := s.storage.UpdateAvatar(userID, avatarURL); err != nil {
+		os.Remove(file.Name())
+		return domain.UserProfile{}, mapProfileError(err)
+	}
+
+	s.removeAvatar(profile.AvatarURL)
+	profile.AvatarURL = avatarURL
+	return profile, nil
+}
+
+func (s *ProfileService) DeleteAvatar(userID int64) error {
+	profile, err := s.Get(userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.storage.UpdateAvatar(userID, ""); err != nil {
+		return mapProfileError(err)
+	}
+
+	s.removeAvatar(profile.AvatarURL)
+	return nil
+}
+
+// Search matches the query as a case-insensitive prefix of the username,
+// the full name or the last name. It backs mention autocomplete and
+// assignee pickers, so it only returns public fields. Results are limited to
+// members of the workspace, and only a member of it can search.
+func (s *ProfileService) Search(viewerID, workspaceID int64, query string, limit int) ([]domain.PublicProfile, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []domain.PublicProfile{}, nil
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	return s.storage.SearchUsers(viewerID, workspaceID, query, limit)
+}
+
+func (s *ProfileService) removeAvatar(avatarURL string) {
+	prefix := strings.TrimRight(s.cfg.AvatarURLPath, "/") + "/"
+	if !strings.HasPrefix(avatarURL, prefix) {
+		return
+	}
+	os.Remove(filepath.Join(s.cfg.AvatarDir, filepath.Base(avatarURL)))
+}
+
+func validateProfileUpdate(update *domain.ProfileUpdate) error {
+	if update.Username != nil {
+		username := strings.TrimSpace(*update.Username)
+		if !usernamePattern.MatchString(username) {
+			return errors.New("username must be 3-50 characters of letters, digits, '.', '_' or '-'")
+		}
+		update.Username = &username
+	}
+
+	for _, name := range []*string{update.Firstname, update.Lastname} {
+		if name == nil {
+			continue
+		}
+		*name = strings.TrimSpace(*name)
+		if *name == "" || len(*name) > 100 {
+			return errors.New("first and last name must be 1-100 characters")
+		}
+	}
+
+	if update.TimeZone != nil {
+		if _, err := time.LoadLocation(*update.TimeZone); err != nil || *update.TimeZone == "" || *update.TimeZone == "Local" {
+			return errors.New("unknown time zone: " + *update.TimeZone)
+		}
+	}
+
+	if update.Locale != nil && !localePattern.MatchString(*update.Locale) {
+		return errors.New("locale must look like 'en' or 'en-US'")
+	}
+
+	return nil
+}
+
+// mapProfileError turns the storage sentinels a profile call can return
+// into the service errors the handlers know.
+func mapProfileError(err error) error {
+	switch {
+	case errors.Is(err, psql.ErrUserNotFound):
+		return ErrUserNotFound
+	case errors.Is(err, psql.ErrUsernameTaken):
+		return ErrUsernameTaken
+	}
+	return err
+}
@@ -0,0 +1,356 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/lexorank"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/rrule"
+)
+
+const (
+	recurrenceBatch     = 100
+	upcomingOccurrences = 5
+)
+
+var (
+	ErrRecurrenceNotFound      = errors.New("task recurrence not found")
+	ErrRecurrenceNeedsDeadline = errors.New("a recurring task needs a deadline")
+)
+
+type RecurrenceStorage interface {
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+	SelectTask(userID, taskID int64) (domain.Task, error)
+	SelectColumn(userID, columnID int64) (domain.Column, error)
+	SelectColumns(userID, boardID int64) ([]domain.Column, error)
+	SelectLastTaskPosition(columnID, excludeID int64) (string, error)
+	SelectProfile(userID int64) (domain.UserProfile, error)
+	UpsertTaskRecurrence(r domain.TaskRecurrence) (domain.TaskRecurrence, error)
+	SelectTaskRecurrence(taskID int64) (domain.TaskRecurrence, error)
+	DeleteTaskRecurrence(recurrenceID int64) (bool, error)
+	SelectDueRecurrences(limit int) ([]domain.TaskRecurrence, error)
+	PostponeTaskRecurrence(recurrenceID int64) (time.Time, error)
+	InsertRecurrenceInstance(r domain.TaskRecurrence, t domain.Task) (domain.Task, error)
+	BoardRevisionStorage
+	TaskActivityStorage
+}
+
+// RecurrenceService repeats tasks. A recurrence is set on a task with a
+// deadline, which becomes the first occurrence; the scheduler then creates
+// each next instance as a copy of the current one, acting as the member who
+// set the recurrence. Instances are not held back by work-in-progress limits.
+type RecurrenceService struct {
+	storage  RecurrenceStorage
+	events   boardEvents
+	activity taskActivity
+	logger   *logging.Logger
+}
+
+func NewRecurrenceService(storage RecurrenceStorage, events BoardEventPublisher, logger *logging.Logger) *RecurrenceService {
+	return &RecurrenceService{
+		storage:  storage,
+		events:   boardEvents{storage: storage, publisher: events, logger: logger},
+		activity: taskActivity{storage: storage, logger: logger},
+		logger:   logger,
+	}
+}
+
+// Get returns the recurrence whose current instance is taskID.
+func (s *RecurrenceService) Get(userID, taskID int64) (domain.TaskRecurrence, error) {
+	if _, err := s.task(userID, taskID, domain.BoardRoleViewer); err != nil {
+		return domain.TaskRecurrence{}, err
+	}
+	return s.recurrence(taskID)
+}
+
+// Set makes the task recur from its deadline, replacing any recurrence it
+// had. New instances go to the task's column, unless it is a done column.
+func (s *RecurrenceService) Set(userID, taskID int64, req domain.TaskRecurrenceRequest) (domain.TaskRecurrence, error) {
+	rule, err := rrule.Parse(req.RRule)
+	if err != nil {
+		return domain.TaskRecurrence{}, err
+	}
+	mode := req.Mode
+	if mode == "" {
+		mode = domain.RecurrenceOnCompletion
+	}
+	if mode != domain.RecurrenceOnCompletion && mode != domain.RecurrenceOnSchedule {
+		return domain.TaskRecurrence{}, fmt.Errorf("unknown recurrence mode %q", mode)
+	}
+
+	task, err := s.task(userID, taskID, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.TaskRecurrence{}, err
+	}
+	if task.Deadline == nil {
+		return domain.TaskRecurrence{}, ErrRecurrenceNeedsDeadline
+	}
+
+	zone := req.TimeZone
+	if zone == "" {
+		profile, err := s.storage.SelectProfile(userID)
+		if err != nil {
+			return domain.TaskRecurrence{}, err
+		}
+		zone = profile.TimeZone
+	}
+	if _, err := loadTimeZone(zone); err != nil {
+		return domain.TaskRecurrence{}, err
+	}
+
+	recurrence := domain.TaskRecurrence{
+		BoardID:   task.BoardID,
+		TaskID:    task.ID,
+		CreatedBy: userID,
+		RRule:     rule.String(),
+		TimeZone:  zone,
+		Mode:      mode,
+		Start:     *task.Deadline,
+	}
+	if !task.Done {
+		recurrence.ColumnID = &task.ColumnID
+	}
+	if _, err := s.storage.UpsertTaskRecurrence(recurrence); err != nil {
+		return domain.TaskRecurrence{}, err
+	}
+	return s.recurrence(taskID)
+}
+
+// Delete stops the task from recurring. Instances created already stay.
+func (s *RecurrenceService) Delete(userID, taskID int64) error {
+	if _, err := s.task(userID, taskID, domain.BoardRoleEditor); err != nil {
+		return err
+	}
+	recurrence, err := s.recurrence(taskID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.storage.DeleteTaskRecurrence(recurrence.ID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrRecurrenceNotFound
+	}
+	return nil
+}
+
+// StartScheduler creates the instances that are due every minute until ctx
+// is done.
+func (s *RecurrenceService) StartScheduler(ctx context.Context) {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.spawnDue()
+		}
+	}
+}
+
+func (s *RecurrenceService) spawnDue() {
+	recurrences, err := s.storage.SelectDueRecurrences(recurrenceBatch)
+	if err != nil {
+		s.logger.Errorf("Failed to list due task recurrences: %v", err)
+		return
+	}
+
+	for _, r := range recurrences {
+		if err := s.spawn(r); err != nil {
+			s.postpone(r, err)
+		}
+	}
+}
+
+// postpone holds back a recurrence whose next instance could not be
+// created, so that it does not come first in every batch.
+func (s *RecurrenceService) postpone(r domain.TaskRecurrence, cause error) {
+	next, err := s.storage.PostponeTaskRecurrence(r.ID)
+	if err != nil {
+		s.logger.Errorf("Failed to create the next instance of task %d: %v (and to postpone it: %v)", r.TaskID, cause, err)
+		return
+	}
+	s.logger.Errorf("Failed to create the next instance of task %d: %v; trying again at %s", r.TaskID, cause, next.Format(time.RFC3339))
+}
+
+// spawn creates the next instance of r. A recurrence ends when its rule has
+// no further occurrences or its creator can no longer edit the board.
+func (s *RecurrenceService) spawn(r domain.TaskRecurrence) error {
+	next, ok, err := nextOccurrence(r, time.Now())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return s.end(r, "its rule has no more occurrences")
+	}
+
+	current, err := s.storage.SelectTask(r.CreatedBy, r.TaskID)
+	if errors.Is(err, psql.ErrTaskNotFound) {
+		return s.end(r, "its creator can no longer open the task")
+	}
+	if err != nil {
+		return err
+	}
+	_, err = boardAccess(s.storage, r.CreatedBy, r.BoardID, domain.BoardRoleEditor)
+	if errors.Is(err, ErrBoardNotFound) || errors.Is(err, ErrBoardForbidden) {
+		return s.end(r, "its creator can no longer edit the board")
+	}
+	if err != nil {
+		return err
+	}
+
+	column, err := s.column(r)
+	if err != nil {
+		return err
+	}
+	last, err := s.storage.SelectLastTaskPosition(column.ID, 0)
+	if err != nil {
+		return err
+	}
+	position, err := lexorank.Between(last, "")
+	if err != nil {
+		return err
+	}
+
+	created, err := s.storage.InsertRecurrenceInstance(r, domain.Task{
+		ColumnID:    column.ID,
+		UserID:      r.CreatedBy,
+		Title:       current.Title,
+		Description: current.Description,
+		Deadline:    &next,
+		Position:    position,
+		Priority:    current.Priority,
+		ParentID:    current.ParentID,
+		LabelIDs:    current.LabelIDs,
+	})
+	if errors.Is(err, psql.ErrRecurrenceAdvanced) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	task, err := s.storage.SelectTask(r.CreatedBy, created.ID)
+	if err != nil {
+		return err
+	}
+	s.activity.record(activityEntry(0, task.ID, task.BoardID, domain.ActivityTaskCreated, "", nil, taskStatus(task)))
+	s.events.publishLatest(0, task.BoardID, domain.BoardEventTaskCreated, taskLoader(s.storage, r.CreatedBy, task.ID))
+	s.logger.Infof("Created task %d due %s as the next instance of task %d", task.ID, next.Format(time.RFC3339), r.TaskID)
+	return nil
+}
+
+func (s *RecurrenceService) end(r domain.TaskRecurrence, reason string) error {
+	if _, err := s.storage.DeleteTaskRecurrence(r.ID); err != nil {
+		return err
+	}
+	s.logger.Infof("Ended the recurrence of task %d: %s", r.TaskID, reason)
+	return nil
+}
+
+// column picks the column for a new instance: the recurrence's own, or the
+// board's first open column once that is gone or done.
+func (s *RecurrenceService) column(r domain.TaskRecurrence) (domain.Column, error) {
+	if r.ColumnID != nil {
+		column, err := s.storage.SelectColumn(r.CreatedBy, *r.ColumnID)
+		if err == nil && !column.Done {
+			return column, nil
+		}
+		if err != nil && !errors.Is(err, psql.ErrColumnNotFound) {
+			return domain.Column{}, err
+		}
+	}
+
+	columns, err := s.storage.SelectColumns(r.CreatedBy, r.BoardID)
+	if err != nil {
+		return domain.Column{}, err
+	}
+	for _, column := range columns {
+		if !column.Done {
+			return column, nil
+		}
+	}
+	return domain.Column{}, fmt.Errorf("board %d has no open column", r.BoardID)
+}
+
+// recurrence loads the recurrence of a task with a preview of its next
+// occurrences.
+func (s *RecurrenceService) recurrence(taskID int64) (domain.TaskRecurrence, error) {
+	recurrence, err := s.storage.SelectTaskRecurrence(taskID)
+	if errors.Is(err, psql.ErrRecurrenceNotFound) {
+		return domain.TaskRecurrence{}, ErrRecurrenceNotFound
+	}
+	if err != nil {
+		return domain.TaskRecurrence{}, err
+	}
+
+	recurrence.Upcoming = []time.Time{}
+	after := time.Now()
+	for len(recurrence.Upcoming) < upcomingOccurrences {
+		next, ok, err := nextOccurrence(recurrence, after)
+		if err != nil || !ok {
+			break
+		}
+		recurrence.Upcoming = append(recurrence.Upcoming, next)
+		after = next
+	}
+	return recurrence, nil
+}
+
+func (s *RecurrenceService) task(userID, taskID int64, minRole string) (domain.Task, error) {
+	task, err := s.storage.SelectTask(userID, taskID)
+	if errors.Is(err, psql.ErrTaskNotFound) {
+		return domain.Task{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	if _, err := boardAccess(s.storage, userID, task.BoardID, minRole); err != nil {
+		return domain.Task{}, err
+	}
+	return task, nil
+}
+
+// nextOccurrence returns the first occurrence of r after both its current
+// occurrence and now, so occurrences missed while an instance stayed open
+// are skipped.
+func nextOccurrence(r domain.TaskRecurrence, now time.Time) (time.Time, bool, error) {
+	rule, err := rrule.Parse(r.RRule)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	loc, err := loadTimeZone(r.TimeZone)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	after := r.Occurrence
+	if now.After(after) {
+		after = now
+	}
+	next, ok := rule.Next(r.Start.In(loc), after)
+	return next, ok, nil
+}
+
+// loadTimeZone accepts IANA names such as "Europe/Berlin" and "UTC", but
+// not "Local", which would depend on the server.
+func loadTimeZone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return loc, nil
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+const (
+	defaultSearchResults = 20
+	maxSearchResults     = 50
+	maxSearchQueryLength = 200
+	snippetWords         = 30
+)
+
+// Raw snippets mark matches with private-use characters, which survive HTML
+// escaping unchanged and are turned into <mark> tags afterwards.
+const (
+	snippetStart = "\uE000"
+	snippetStop  = "\uE001"
+)
+
+var headlineOptions = "StartSel=" + snippetStart + ", StopSel=" + snippetStop + ", MaxWords=30, MinWords=12, MaxFragments=2"
+
+var (
+	ErrSearchQueryRequired = errors.New("search query is required")
+	ErrSearchQueryTooLong  = errors.New("search query must be at most 200 characters")
+)
+
+type SearchStorage interface {
+	SelectAccessibleBoardIDs(userID int64) ([]int64, error)
+	SearchTasks(query, headlineOptions string, boardIDs []int64, limit int) ([]domain.SearchResult, error)
+	SearchComments(query, headlineOptions string, boardIDs []int64, limit int) ([]domain.SearchResult, error)
+}
+
+type MessageSearcher interface {
+	SearchMessages(ctx context.Context, boardIDs []int64, query string, limit int64) ([]domain.MessageMatch, error)
+}
+
+// SearchService searches tasks and comments in Postgres and chat messages in
+// Mongo, limited to the boards the user can open. Both stores' scores are
+// mapped to score/(score+1), so the results can be merged into one ranking.
+type SearchService struct {
+	storage  SearchStorage
+	messages MessageSearcher
+	logger   *logging.Logger
+}
+
+func NewSearchService(storage SearchStorage, messages MessageSearcher, logger *logging.Logger) *SearchService {
+	return &SearchService{storage: storage, messages: messages, logger: logger}
+}
+
+// Search returns the best matches first. If the chat store fails, the task
+// results are still returned.
+func (s *SearchService) Search(ctx context.Context, userID int64, req domain.SearchRequest) (domain.SearchResults, error) {
+	query := strings.TrimSpace(req.Query)
+	if query == "" {
+		return domain.SearchResults{}, ErrSearchQueryRequired
+	}
+	if len([]rune(query)) > maxSearchQueryLength {
+		return domain.SearchResults{}, ErrSearchQueryTooLong
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchResults
+	}
+	if limit > maxSearchResults {
+		limit = maxSearchResults
+	}
+
+	boardIDs, err := s.storage.SelectAccessibleBoardIDs(userID)
+	if err != nil {
+		return domain.SearchResults{}, err
+	}
+	if req.BoardID != 0 {
+		if !containsID(boardIDs, req.BoardID) {
+			return domain.SearchResults{}, ErrBoardNotFound
+		}
+		boardIDs = []int64{req.BoardID}
+	}
+
+	results := domain.SearchResults{Query: query, Results: []domain.SearchResult{}}
+	if len(boardIDs) == 0 {
+		return results, nil
+	}
+
+	tasks, err := s.storage.SearchTasks(query, headlineOptions, boardIDs, limit)
+	if err != nil {
+		return domain.SearchResults{}, err
+	}
+	comments, err := s.storage.SearchComments(query, headlineOptions, boardIDs, limit)
+	if err != nil {
+		return domain.SearchResults{}, err
+	}
+	results.Results = append(append(results.Results, tasks...), comments...)
+
+	matches, err := s.messages.SearchMessages(ctx, boardIDs, query, int64(limit))
+	if err != nil {
+		s.logger.Errorf("Failed to search chat messages for user %d: %v", userID, err)
+	}
+	terms := searchTerms(query)
+	for _, m := range matches {
+		results.Results = append(results.Results, domain.SearchResult{
+			Type:      domain.SearchResultMessage,
+			BoardID:   m.BoardID,
+			MessageID: m.ID,
+			Username:  m.Username,
+			Snippet:   messageSnippet(m.Content, terms),
+			Score:     m.Score / (m.Score + 1),
+			CreatedAt: m.CreatedAt,
+		})
+	}
+
+	sort.SliceStable(results.Results, func(i, j int) bool {
+		a, b := results.Results[i], results.Results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+	if len(results.Results) > limit {
+		results.Results = results.Results[:limit]
+	}
+	for i := range results.Results {
+		results.Results[i].Snippet = markSnippet(results.Results[i].Snippet)
+	}
+	return results, nil
+}
+
+// markSnippet escapes a raw snippet for HTML and turns its match markers
+// into <mark> tags.
+func markSnippet(raw string) string {
+	escaped := html.EscapeString(raw)
+	escaped = strings.ReplaceAll(escaped, snippetStart, "<mark>")
+	return strings.ReplaceAll(escaped, snippetStop, "</mark>")
+}
+
+// searchTerms lists the lowercased words of a query, leaving out the ones
+// excluded with a leading minus.
+func searchTerms(query string) []string {
+	var terms []string
+	for _, field := range strings.Fields(query) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+		for _, word := range strings.FieldsFunc(field, isNotWordRune) {
+			terms = append(terms, strings.ToLower(word))
+		}
+	}
+	return terms
+}
+
+// messageSnippet cuts about snippetWords words of content around the first
+// match and marks the matching words. Mongo does not report which words
+// matched, so a word matches a term it starts with; for longer terms the last
+// two letters are dropped first, which is close enough to its stemming for
+// highlighting.
+func messageSnippet(content string, terms []string) string {
+	type word struct {
+		start, end int
+		match      bool
+	}
+
+	var words []word
+	start := -1
+	for i, r := range content {
+		if !isNotWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			words = append(words, word{start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words = append(words, word{start: start, end: len(content)})
+	}
+
+	first := -1
+	for i := range words {
+		if matchesTerm(strings.ToLower(content[words[i].start:words[i].end]), terms) {
+			words[i].match = true
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+
+	from, to := 0, len(words)
+	if len(words) > snippetWords {
+		from = max(0, first-snippetWords/3)
+		to = min(len(words), from+snippetWords)
+	}
+	begin, end := 0, len(content)
+	if from > 0 {
+		begin = words[from].start
+	}
+	if to < len(words) {
+		end = words[to-1].end
+	}
+
+	var b strings.Builder
+	if from > 0 {
+		b.WriteString("… ")
+	}
+	pos := begin
+	for _, w := range words[from:to] {
+		if !w.match {
+			continue
+		}
+		b.WriteString(content[pos:w.start])
+		b.WriteString(snippetStart)
+		b.WriteString(content[w.start:w.end])
+		b.WriteString(snippetStop)
+		pos = w.end
+	}
+	b.WriteString(content[pos:end])
+	if to < len(words) {
+		b.WriteString(" …")
+	}
+	return b.String()
+}
+
+func matchesTerm(word string, terms []string) bool {
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+		if runes := []rune(term); len(runes) >= 5 && strings.HasPrefix(word, string(runes[:len(runes)-2])) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
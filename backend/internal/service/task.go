@@ -0,0 +1,819 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/config"
+	"github.com/your-team/taskmanager-chat/backend/pkg/lexorank"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+var (
+	ErrTaskNotFound     = errors.New("task not found")
+	ErrColumnNotFound   = errors.New("column not found")
+	ErrTaskMoveConflict = errors.New("the column changed in the meantime, reload it and try again")
+	ErrInvalidParent    = errors.New("a subtask's parent must be another top-level task on the same board")
+	ErrWIPLimitReached  = errors.New("the column is at its work-in-progress limit")
+)
+
+type TaskStorage interface {
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+	InsertColumn(userID int64, c domain.Column) (domain.Column, error)
+	SelectColumns(userID, boardID int64) ([]domain.Column, error)
+	SelectColumn(userID, columnID int64) (domain.Column, error)
+	SelectLastColumnPosition(boardID int64) (string, error)
+	UpdateColumn(columnID int64, req domain.ColumnUpdateRequest) (bool, error)
+	CountColumnTasks(columnID, excludeID int64) (int64, error)
+	InsertTask(t domain.Task, values []domain.FieldValue) (domain.Task, error)
+	SelectBoardTasks(userID, boardID int64, filter domain.TaskFilter) ([]domain.Task, error)
+	SelectTask(userID, taskID int64) (domain.Task, error)
+	SelectSubtasks(userID, taskID int64) ([]domain.Task, error)
+	SelectTaskBlockers(taskID int64) ([]domain.TaskRef, error)
+	SelectBlockedTasks(taskID int64) ([]domain.TaskRef, error)
+	UpdateTask(userID, boardID, taskID, version int64, req domain.TaskRequest, values []domain.FieldValue) (bool, error)
+	DeleteTask(userID, taskID, version int64) (bool, error)
+	MoveTask(userID, taskID, version, columnID int64, position string) (bool, error)
+	SelectLastTaskPosition(columnID, excludeID int64) (string, error)
+	SelectNextTaskPosition(columnID int64, position string, excludeID int64) (string, error)
+	SelectPrevTaskPosition(columnID int64, position string, excludeID int64) (string, error)
+	SelectColumnsNeedingRebalance(maxLength int) ([]int64, error)
+	RebalanceColumn(columnID int64) error
+	SelectLabels(boardID int64) ([]domain.Label, error)
+	SelectCustomFields(boardID int64) ([]domain.CustomField, error)
+	SelectBoardView(viewID int64) (domain.BoardView, error)
+	IsBoardMember(boardID, userID int64) (bool, error)
+	BoardRevisionStorage
+	TaskActivityStorage
+}
+
+// VersionConflictError is returned when a write names a version that is no
+// longer current. Current is the record as it is now, so the client can merge
+// its change into it and retry.
+type VersionConflictError struct {
+	Version int64
+	Current interface{}
+}
+
+func (e *VersionConflictError) Error() string {
+	return "the record was changed in the meantime"
+}
+
+// TaskService manages columns and tasks. Reading needs any role on the
+// board, changes need editor or admin. Every change is published as a
+// board event and recorded in the task's activity.
+type TaskService struct {
+	storage  TaskStorage
+	events   boardEvents
+	activity taskActivity
+	cfg      config.TaskConfig
+	logger   *logging.Logger
+}
+
+func NewTaskService(storage TaskStorage, events BoardEventPublisher, listener ActivityListener, cfg config.TaskConfig, logger *logging.Logger) *TaskService {
+	return &TaskService{
+		storage:  storage,
+		events:   boardEvents{storage: storage, publisher: events, logger: logger},
+		activity: taskActivity{storage: storage, listener: listener, logger: logger},
+		cfg:      cfg,
+		logger:   logger,
+	}
+}
+
+// Board returns the board's columns with the tasks matching the query,
+// sorted and grouped by it, along with the board's labels and custom fields.
+// A non-zero viewID applies a saved view of the board, with the criteria
+// given in query overriding the view's.
+func (s *TaskService) Board(userID, boardID, viewID int64, query domain.BoardQuery) (domain.BoardTasks, error) {
+	board, err := boardAccess(s.storage, userID, boardID, domain.BoardRoleViewer)
+	if err != nil {
+		return domain.BoardTasks{}, err
+	}
+
+	labels, err := s.storage.SelectLabels(boardID)
+	if err != nil {
+		return domain.BoardTasks{}, err
+	}
+	fields, err := s.storage.SelectCustomFields(boardID)
+	if err != nil {
+		return domain.BoardTasks{}, err
+	}
+	if viewID != 0 {
+		view, err := s.storage.SelectBoardView(viewID)
+		if errors.Is(err, psql.ErrViewNotFound) {
+			return domain.BoardTasks{}, ErrViewNotFound
+		}
+		if err != nil {
+			return domain.BoardTasks{}, err
+		}
+		if view.BoardID != boardID || (view.UserID != userID && !view.Shared) {
+			return domain.BoardTasks{}, ErrViewNotFound
+		}
+		view.Query.Filter.Fields = existingFieldFilters(view.Query.Filter.Fields, fields)
+		query = mergeBoardQuery(view.Query, query)
+	}
+	if err := validateBoardQuery(&query); err != nil {
+		return domain.BoardTasks{}, err
+	}
+	filter := query.Filter
+	filter.Fields = append([]domain.FieldFilter{}, filter.Fields...)
+	if err := resolveTaskFilter(&filter, fields); err != nil {
+		return domain.BoardTasks{}, err
+	}
+	if filter.AssignedToMe {
+		filter.AssigneeIDs = append(append([]int64{}, filter.AssigneeIDs...), userID)
+	}
+
+	columns, err := s.storage.SelectColumns(userID, boardID)
+	if err != nil {
+		return domain.BoardTasks{}, err
+	}
+	tasks, err := s.storage.SelectBoardTasks(userID, boardID, filter)
+	if err != nil {
+		return domain.BoardTasks{}, err
+	}
+	sortTasks(tasks, query.SortBy, query.SortDesc)
+
+	result := domain.BoardTasks{
+		BoardID:  boardID,
+		Revision: board.Revision,
+		ViewID:   viewID,
+		Query:    query,
+		Columns:  columns,
+		Labels:   labels,
+		Fields:   fields,
+	}
+	if query.GroupBy != domain.TaskGroupStatus {
+		result.Groups = groupTasks(tasks, query.GroupBy, labels)
+		return result, nil
+	}
+
+	index := make(map[int64]int, len(columns))
+	for i, column := range columns {
+		index[column.ID] = i
+	}
+	for _, task := range tasks {
+		if i, ok := index[task.ColumnID]; ok {
+			columns[i].Tasks = append(columns[i].Tasks, task)
+		}
+	}
+	return result, nil
+}
+
+// CreateColumn appends a column to the board.
+func (s *TaskService) CreateColumn(userID, boardID int64, req domain.ColumnRequest) (domain.Column, error) {
+	title := strings.TrimSpace(req.Title)
+	if title == "" || len(title) > 100 {
+		return domain.Column{}, errors.New("column title is required and must be at most 100 characters")
+	}
+	kind := req.Kind
+	if kind == "" {
+		kind = domain.ColumnKindInProgress
+		if req.Done {
+			kind = domain.ColumnKindDone
+		}
+	}
+	policy := req.WIPPolicy
+	if policy == "" {
+		policy = domain.WIPPolicyWarn
+	}
+	if err := validateColumnPolicy(&kind, req.WIPLimit, &policy); err != nil {
+		return domain.Column{}, err
+	}
+
+	if _, err := boardAccess(s.storage, userID, boardID, domain.BoardRoleEditor); err != nil {
+		return domain.Column{}, err
+	}
+
+	last, err := s.storage.SelectLastColumnPosition(boardID)
+	if err != nil {
+		return domain.Column{}, err
+	}
+	position, err := lexorank.Between(last, "")
+	if err != nil {
+		return domain.Column{}, err
+	}
+
+	column, err := s.storage.InsertColumn(userID, domain.Column{
+		BoardID:   boardID,
+		Title:     title,
+		Position:  position,
+		Kind:      kind,
+		WIPLimit:  req.WIPLimit,
+		WIPPolicy: policy,
+	})
+	if errors.Is(err, psql.ErrBoardNotFound) {
+		return domain.Column{}, ErrBoardNotFound
+	}
+	if err != nil {
+		return domain.Column{}, err
+	}
+
+	s.events.publishLatest(userID, boardID, domain.BoardEventColumnCreated, s.columnLoader(userID, column.ID))
+	return column, nil
+}
+
+// UpdateColumn changes a column's title, kind or work-in-progress limit.
+// Changing the kind to or from done completes or reopens the tasks in it.
+func (s *TaskService) UpdateColumn(userID, columnID int64, req domain.ColumnUpdateRequest) (domain.Column, error) {
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" || len(title) > 100 {
+			return domain.Column{}, errors.New("column title is required and must be at most 100 characters")
+		}
+		req.Title = &title
+	}
+	if err := validateColumnPolicy(req.Kind, req.WIPLimit, req.WIPPolicy); err != nil {
+		return domain.Column{}, err
+	}
+
+	column, err := s.column(userID, columnID)
+	if err != nil {
+		return domain.Column{}, err
+	}
+	if _, err := boardAccess(s.storage, userID, column.BoardID, domain.BoardRoleEditor); err != nil {
+		return domain.Column{}, err
+	}
+
+	updated, err := s.storage.UpdateColumn(columnID, req)
+	if err != nil {
+		return domain.Column{}, err
+	}
+	if !updated {
+		return domain.Column{}, ErrColumnNotFound
+	}
+
+	column, err = s.column(userID, columnID)
+	if err != nil {
+		return domain.Column{}, err
+	}
+	s.events.publishLatest(userID, column.BoardID, domain.BoardEventColumnUpdated, s.columnLoader(userID, columnID))
+	return column, nil
+}
+
+// Create adds a task at the end of req.ColumnID, as a subtask when
+// req.ParentID is set.
+func (s *TaskService) Create(userID int64, req domain.TaskRequest) (domain.Task, error) {
+	if req.Title == nil {
+		return domain.Task{}, errors.New("task title is required")
+	}
+	if err := validateTaskRequest(&req); err != nil {
+		return domain.Task{}, err
+	}
+
+	column, err := s.column(userID, req.ColumnID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if _, err := boardAccess(s.storage, userID, column.BoardID, domain.BoardRoleEditor); err != nil {
+		return domain.Task{}, err
+	}
+	values, err := s.attributes(column.BoardID, req)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	last, err := s.storage.SelectLastTaskPosition(column.ID, 0)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	position, err := lexorank.Between(last, "")
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	task := domain.Task{
+		BoardID:  column.BoardID,
+		ColumnID: column.ID,
+		UserID:   userID,
+		Title:    *req.Title,
+		Deadline: req.Deadline,
+		Position: position,
+		Priority: domain.PriorityNone,
+		ParentID: req.ParentID,
+	}
+	if req.Description != nil {
+		task.Description = *req.Description
+	}
+	if req.Priority != nil {
+		task.Priority = *req.Priority
+	}
+	if req.LabelIDs != nil {
+		task.LabelIDs = *req.LabelIDs
+	}
+
+	task, err = s.storage.InsertTask(task, values)
+	if errors.Is(err, psql.ErrColumnNotFound) {
+		return domain.Task{}, ErrColumnNotFound
+	}
+	if errors.Is(err, psql.ErrColumnFull) {
+		return domain.Task{}, ErrWIPLimitReached
+	}
+	if errors.Is(err, psql.ErrInvalidParent) {
+		return domain.Task{}, ErrInvalidParent
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	// Re-read for the status, labels and field values.
+	task, err = s.Get(userID, task.ID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	s.activity.record(activityEntry(userID, task.ID, task.BoardID, domain.ActivityTaskCreated, "", nil, taskStatus(task)))
+	s.events.publishLatest(userID, task.BoardID, domain.BoardEventTaskCreated, taskLoader(s.storage, userID, task.ID))
+	s.publishTasks(userID, task.BoardID, task.ParentID)
+	return task, nil
+}
+
+// Subtasks returns the subtasks of a task, oldest first.
+func (s *TaskService) Subtasks(userID, taskID int64) ([]domain.Task, error) {
+	if _, err := s.task(userID, taskID, 0, domain.BoardRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectSubtasks(userID, taskID)
+}
+
+func (s *TaskService) Get(userID, taskID int64) (domain.Task, error) {
+	task, err := s.storage.SelectTask(userID, taskID)
+	if errors.Is(err, psql.ErrTaskNotFound) {
+		return domain.Task{}, ErrTaskNotFound
+	}
+	return task, err
+}
+
+// Update, Delete and Move refuse to write when version is set and the task
+// has moved past it; version 0 writes unconditionally.
+func (s *TaskService) Update(userID, taskID, version int64, req domain.TaskRequest) (domain.Task, error) {
+	if err := validateTaskRequest(&req); err != nil {
+		return domain.Task{}, err
+	}
+	current, err := s.task(userID, taskID, version, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	values, err := s.attributes(current.BoardID, req)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	updated, err := s.storage.UpdateTask(userID, current.BoardID, taskID, version, req, values)
+	if errors.Is(err, psql.ErrInvalidParent) {
+		return domain.Task{}, ErrInvalidParent
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if !updated {
+		return domain.Task{}, s.lostWrite(userID, taskID, version)
+	}
+
+	task, err := s.Get(userID, taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	s.activity.record(taskChanges(userID, current, task)...)
+	s.events.publishLatest(userID, task.BoardID, domain.BoardEventTaskUpdated, taskLoader(s.storage, userID, task.ID))
+	if !sameID(current.ParentID, task.ParentID) {
+		s.publishTasks(userID, task.BoardID, current.ParentID, task.ParentID)
+	}
+	return task, nil
+}
+
+// Delete removes the task. Its subtasks become plain tasks and the tasks it
+// blocked lose the dependency; they are published as updated.
+func (s *TaskService) Delete(userID, taskID, version int64) error {
+	task, err := s.task(userID, taskID, version, domain.BoardRoleEditor)
+	if err != nil {
+		return err
+	}
+	related, err := s.relatedTasks(userID, task)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.storage.DeleteTask(userID, taskID, version)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return s.lostWrite(userID, taskID, version)
+	}
+
+	s.events.publish(userID, task.BoardID, domain.BoardEventTaskDeleted, domain.TaskDeletedEvent{
+		ID:       task.ID,
+		ColumnID: task.ColumnID,
+	})
+	s.publishTasks(userID, task.BoardID, related...)
+	return nil
+}
+
+// Move puts the task into req.ColumnID between the neighbours the client
+// saw. Only the moved task's row is written: its new position is a key
+// between the neighbours' keys. When the neighbours' keys collide, the column
+// is rebalanced once and the move retried. Moving a task into a done column
+// while its blockers are open, or past a column's work-in-progress limit
+// that is not enforced, succeeds with a warning.
+func (s *TaskService) Move(userID, taskID, version int64, req domain.TaskMoveRequest) (domain.TaskMoveResult, error) {
+	if req.ColumnID == 0 {
+		return domain.TaskMoveResult{}, errors.New("column_id is required")
+	}
+
+	task, err := s.task(userID, taskID, version, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.TaskMoveResult{}, err
+	}
+	column, err := s.column(userID, req.ColumnID)
+	if err != nil {
+		return domain.TaskMoveResult{}, err
+	}
+	if column.BoardID != task.BoardID {
+		return domain.TaskMoveResult{}, ErrColumnNotFound
+	}
+
+	var warnings []string
+	if column.ID != task.ColumnID {
+		warning, err := s.wipWarning(column, task.ID)
+		if err != nil {
+			return domain.TaskMoveResult{}, err
+		}
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+	if column.Done && !task.Done {
+		blockers, err := s.blockerWarnings(task.ID)
+		if err != nil {
+			return domain.TaskMoveResult{}, err
+		}
+		warnings = append(warnings, blockers...)
+	}
+
+	for attempt := 0; ; attempt++ {
+		position, err := s.positionBetween(userID, task.ID, column.ID, req)
+		if errors.Is(err, lexorank.ErrInvalidRange) && attempt == 0 {
+			if err := s.storage.RebalanceColumn(column.ID); err != nil {
+				return domain.TaskMoveResult{}, err
+			}
+			continue
+		}
+		if errors.Is(err, lexorank.ErrInvalidRange) {
+			return domain.TaskMoveResult{}, ErrTaskMoveConflict
+		}
+		if err != nil {
+			return domain.TaskMoveResult{}, err
+		}
+
+		moved, err := s.storage.MoveTask(userID, task.ID, version, column.ID, position)
+		if errors.Is(err, psql.ErrColumnFull) {
+			return domain.TaskMoveResult{}, ErrWIPLimitReached
+		}
+		if err != nil {
+			return domain.TaskMoveResult{}, err
+		}
+		if !moved {
+			return domain.TaskMoveResult{}, s.lostWrite(userID, task.ID, version)
+		}
+
+		movedTask, err := s.Get(userID, task.ID)
+		if err != nil {
+			return domain.TaskMoveResult{}, err
+		}
+		if movedTask.ColumnID != task.ColumnID {
+			s.activity.record(fieldChange(userID, movedTask, domain.ActivityFieldStatus, taskStatus(task), taskStatus(movedTask)))
+		}
+		s.events.publishLatest(userID, task.BoardID, domain.BoardEventTaskMoved, func() (interface{}, error) {
+			latest, err := s.storage.SelectTask(userID, task.ID)
+			return domain.TaskMovedEvent{Task: latest, FromColumnID: task.ColumnID}, err
+		})
+		if movedTask.Done != task.Done {
+			s.publishTasks(userID, task.BoardID, task.ParentID)
+		}
+		return domain.TaskMoveResult{Task: movedTask, Warnings: warnings}, nil
+	}
+}
+
+// blockerWarnings describes the blockers of the task that are not done yet.
+func (s *TaskService) blockerWarnings(taskID int64) ([]string, error) {
+	blockers, err := s.storage.SelectTaskBlockers(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var warnings []string
+	for _, blocker := range blockers {
+		if !blocker.Done {
+			warnings = append(warnings, fmt.Sprintf("the task is blocked by #%d %q, which is not done yet", blocker.ID, blocker.Title))
+		}
+	}
+	return warnings, nil
+}
+
+// wipWarning checks whether one more task, other than taskID, fits in the
+// column. Past a limit that only warns it returns a warning; enforced limits
+// are checked by the storage as it writes the task, under a lock on the
+// column.
+func (s *TaskService) wipWarning(column domain.Column, taskID int64) (string, error) {
+	if column.WIPLimit == nil || column.WIPPolicy == domain.WIPPolicyEnforce {
+		return "", nil
+	}
+	count, err := s.storage.CountColumnTasks(column.ID, taskID)
+	if err != nil {
+		return "", err
+	}
+	if count < int64(*column.WIPLimit) {
+		return "", nil
+	}
+	return fmt.Sprintf("the column %q now holds more than its limit of %d tasks", column.Title, *column.WIPLimit), nil
+}
+
+// relatedTasks lists the tasks that change along with task when it is
+// deleted: its parent, its subtasks and the tasks it blocks.
+func (s *TaskService) relatedTasks(userID int64, task domain.Task) ([]*int64, error) {
+	related := []*int64{task.ParentID}
+
+	if task.Progress.SubtasksTotal > 0 {
+		subtasks, err := s.storage.SelectSubtasks(userID, task.ID)
+		if err != nil {
+			return nil, err
+		}
+		for i := range subtasks {
+			related = append(related, &subtasks[i].ID)
+		}
+	}
+
+	blocked, err := s.storage.SelectBlockedTasks(task.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range blocked {
+		related = append(related, &blocked[i].ID)
+	}
+	return related, nil
+}
+
+// publishTasks publishes the current state of other tasks on the board a
+// change touched, such as the parent whose progress moved. Nil ids are
+// skipped.
+func (s *TaskService) publishTasks(userID, boardID int64, taskIDs ...*int64) {
+	seen := make(map[int64]bool, len(taskIDs))
+	for _, id := range taskIDs {
+		if id == nil || seen[*id] {
+			continue
+		}
+		seen[*id] = true
+		s.events.publishLatest(userID, boardID, domain.BoardEventTaskUpdated, taskLoader(s.storage, userID, *id))
+	}
+}
+
+func sameID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// StartRebalancer periodically rewrites the positions of columns whose keys
+// grew too long or collided.
+func (s *TaskService) StartRebalancer(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.TaskRebalanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.rebalance()
+		}
+	}
+}
+
+func (s *TaskService) rebalance() {
+	columns, err := s.storage.SelectColumnsNeedingRebalance(s.cfg.TaskPositionMaxLength)
+	if err != nil {
+		s.logger.Errorf("Failed to list columns to rebalance: %v", err)
+		return
+	}
+
+	for _, columnID := range columns {
+		if err := s.storage.RebalanceColumn(columnID); err != nil {
+			s.logger.Errorf("Failed to rebalance column %d: %v", columnID, err)
+			continue
+		}
+		s.logger.Infof("Rebalanced task positions of column %d", columnID)
+	}
+}
+
+// positionBetween resolves the neighbours of a move into a new key. With one
+// neighbour the other bound is the task next to it, ignoring the moved task.
+func (s *TaskService) positionBetween(userID, taskID, columnID int64, req domain.TaskMoveRequest) (string, error) {
+	after, err := s.neighbour(userID, taskID, columnID, req.AfterID, "after_id")
+	if err != nil {
+		return "", err
+	}
+	before, err := s.neighbour(userID, taskID, columnID, req.BeforeID, "before_id")
+	if err != nil {
+		return "", err
+	}
+
+	lower, upper := after, before
+	switch {
+	case req.AfterID != nil && req.BeforeID == nil:
+		upper, err = s.storage.SelectNextTaskPosition(columnID, after, taskID)
+	case req.AfterID == nil && req.BeforeID != nil:
+		lower, err = s.storage.SelectPrevTaskPosition(columnID, before, taskID)
+	case req.AfterID == nil && req.BeforeID == nil:
+		lower, err = s.storage.SelectLastTaskPosition(columnID, taskID)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return lexorank.Between(lower, upper)
+}
+
+func (s *TaskService) neighbour(userID, taskID, columnID int64, id *int64, field string) (string, error) {
+	if id == nil {
+		return "", nil
+	}
+	if *id == taskID {
+		return "", errors.New(field + " must not be the moved task")
+	}
+
+	task, err := s.storage.SelectTask(userID, *id)
+	if errors.Is(err, psql.ErrTaskNotFound) || err == nil && task.ColumnID != columnID {
+		return "", errors.New(field + " must be a task in the target column")
+	}
+	if err != nil {
+		return "", err
+	}
+	return task.Position, nil
+}
+
+// task loads the task, checks the user's role on its board and, unless
+// version is 0, that the task is still at version.
+func (s *TaskService) task(userID, taskID, version int64, minRole string) (domain.Task, error) {
+	task, err := s.Get(userID, taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	if _, err := boardAccess(s.storage, userID, task.BoardID, minRole); err != nil {
+		return domain.Task{}, err
+	}
+	if version != 0 && task.Version != version {
+		return domain.Task{}, &VersionConflictError{Version: task.Version, Current: task}
+	}
+	return task, nil
+}
+
+// lostWrite explains a conditional write that matched no row: the task is
+// gone or another write got in first.
+func (s *TaskService) lostWrite(userID, taskID, version int64) error {
+	current, err := s.Get(userID, taskID)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		return ErrTaskNotFound
+	}
+	return &VersionConflictError{Version: current.Version, Current: current}
+}
+
+// attributes checks that the labels and field values of req belong to the
+// board and returns the values parsed for their fields' types.
+func (s *TaskService) attributes(boardID int64, req domain.TaskRequest) ([]domain.FieldValue, error) {
+	if req.LabelIDs != nil && len(*req.LabelIDs) > 0 {
+		labels, err := s.storage.SelectLabels(boardID)
+		if err != nil {
+			return nil, err
+		}
+		known := make(map[int64]bool, len(labels))
+		for _, label := range labels {
+			known[label.ID] = true
+		}
+		for _, id := range *req.LabelIDs {
+			if !known[id] {
+				return nil, fmt.Errorf("label %d does not belong to the board", id)
+			}
+		}
+	}
+
+	if len(req.Fields) == 0 {
+		return nil, nil
+	}
+	fields, err := s.storage.SelectCustomFields(boardID)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]domain.CustomField, len(fields))
+	for _, field := range fields {
+		byID[field.ID] = field
+	}
+
+	values := make([]domain.FieldValue, 0, len(req.Fields))
+	for id, raw := range req.Fields {
+		field, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("custom field %d does not belong to the board", id)
+		}
+		value, err := parseFieldValue(field, raw)
+		if err != nil {
+			return nil, err
+		}
+		if value.UserID != nil {
+			member, err := s.storage.IsBoardMember(boardID, *value.UserID)
+			if err != nil {
+				return nil, err
+			}
+			if !member {
+				return nil, fmt.Errorf("field %q expects a member of the board", field.Name)
+			}
+		}
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].FieldID < values[j].FieldID })
+	return values, nil
+}
+
+func (s *TaskService) column(userID, columnID int64) (domain.Column, error) {
+	column, err := s.storage.SelectColumn(userID, columnID)
+	if errors.Is(err, psql.ErrColumnNotFound) {
+		return domain.Column{}, ErrColumnNotFound
+	}
+	return column, err
+}
+
+func (s *TaskService) columnLoader(userID, columnID int64) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		return s.column(userID, columnID)
+	}
+}
+
+var columnKinds = map[string]bool{
+	domain.ColumnKindBacklog:    true,
+	domain.ColumnKindInProgress: true,
+	domain.ColumnKindDone:       true,
+}
+
+// validateColumnPolicy checks the column settings that are given.
+func validateColumnPolicy(kind *string, wipLimit *int, wipPolicy *string) error {
+	if kind != nil && !columnKinds[*kind] {
+		return fmt.Errorf("unknown column kind %q", *kind)
+	}
+	if wipLimit != nil && *wipLimit < 1 {
+		return errors.New("wip_limit must be at least 1")
+	}
+	if wipPolicy != nil && *wipPolicy != domain.WIPPolicyWarn && *wipPolicy != domain.WIPPolicyEnforce {
+		return fmt.Errorf("unknown wip_policy %q", *wipPolicy)
+	}
+	return nil
+}
+
+func validateTaskRequest(req *domain.TaskRequest) error {
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" || len(title) > 255 {
+			return errors.New("task title is required and must be at most 255 characters")
+		}
+		req.Title = &title
+	}
+	if req.Description != nil && len(*req.Description) > 10000 {
+		return errors.New("task description must be at most 10000 characters")
+	}
+	if req.Priority != nil && !priorities[*req.Priority] {
+		return errors.New("task priority must be one of none, low, medium, high, urgent")
+	}
+	return nil
+}
+
+// resolveTaskFilter checks filter against the board's fields and parses the
+// field conditions for their types.
+func resolveTaskFilter(filter *domain.TaskFilter, fields []domain.CustomField) error {
+	for _, priority := range filter.Priorities {
+		if !priorities[priority] {
+			return fmt.Errorf("unknown priority %q", priority)
+		}
+	}
+
+	byID := make(map[int64]domain.CustomField, len(fields))
+	for _, field := range fields {
+		byID[field.ID] = field
+	}
+	for i := range filter.Fields {
+		field, ok := byID[filter.Fields[i].FieldID]
+		if !ok {
+			return fmt.Errorf("custom field %d does not belong to the board", filter.Fields[i].FieldID)
+		}
+		if err := parseFieldFilter(field, &filter.Fields[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+type TaskRecipientStorage interface {
+	SelectTaskRecipientIDs(taskID int64) ([]int64, error)
+}
+
+// taskNotifier fans the notifications of a task out to its assignees and
+// watchers.
+type taskNotifier struct {
+	storage       TaskRecipientStorage
+	notifications NotificationCreator
+	logger        *logging.Logger
+}
+
+// notify sends a copy of n to every assignee and watcher of n.TaskID except
+// the users in skip, and returns how many were sent.
+func (t taskNotifier) notify(n domain.Notification, skip ...int64) int {
+	userIDs, err := t.storage.SelectTaskRecipientIDs(n.TaskID)
+	if err != nil {
+		t.logger.Errorf("Failed to list recipients of task %d: %v", n.TaskID, err)
+		return 0
+	}
+
+	sent := 0
+	for _, userID := range userIDs {
+		if containsID(skip, userID) {
+			continue
+		}
+		n.UserID = userID
+		if _, err := t.notifications.CreateNotification(n); err != nil {
+			t.logger.Errorf("Failed to send %s notification of task %d to user %d: %v", n.Type, n.TaskID, userID, err)
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,551 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+const (
+	maxTemplateTasks     = 500
+	maxTemplateChecklist = 100
+)
+
+var (
+	ErrTemplateNotFound  = errors.New("template not found")
+	ErrTemplateForbidden = errors.New("only the template's creator or a workspace admin can delete it")
+	ErrTemplateExists    = errors.New("a template with this name already exists")
+)
+
+type TemplateStorage interface {
+	SelectWorkspaceRole(workspaceID, userID int64) (string, error)
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+	SelectTask(userID, taskID int64) (domain.Task, error)
+	SelectColumn(userID, columnID int64) (domain.Column, error)
+	SelectColumns(userID, boardID int64) ([]domain.Column, error)
+	SelectLabels(boardID int64) ([]domain.Label, error)
+	SelectCustomFields(boardID int64) ([]domain.CustomField, error)
+	SelectAutomationRules(boardID int64) ([]domain.AutomationRule, error)
+	SelectBoardTasks(userID, boardID int64, filter domain.TaskFilter) ([]domain.Task, error)
+	SelectChecklistItems(taskID int64) ([]domain.ChecklistItem, error)
+	InsertBoardTemplate(t domain.BoardTemplate) (domain.BoardTemplate, error)
+	SelectBoardTemplates(workspaceID int64) ([]domain.BoardTemplate, error)
+	SelectBoardTemplate(templateID int64) (domain.BoardTemplate, error)
+	DeleteBoardTemplate(templateID int64) (bool, error)
+	InsertBoardFromTemplate(b domain.Board, content domain.BoardTemplateContent) (domain.Board, error)
+	InsertTaskTemplate(t domain.TaskTemplate) (domain.TaskTemplate, error)
+	SelectTaskTemplates(boardID int64) ([]domain.TaskTemplate, error)
+	SelectTaskTemplate(templateID int64) (domain.TaskTemplate, error)
+	UpdateTaskTemplate(templateID int64, req domain.TaskTemplateRequest) (bool, error)
+	DeleteTaskTemplate(templateID int64) (bool, error)
+	InsertChecklist(taskID int64, items []string) error
+	BoardRevisionStorage
+}
+
+// TemplateService saves boards as templates of their workspace and starts
+// boards from them, and keeps the task templates of boards. Tasks made from
+// a template go through the task service like any other.
+type TemplateService struct {
+	storage TemplateStorage
+	tasks   *TaskService
+	events  boardEvents
+	logger  *logging.Logger
+}
+
+func NewTemplateService(storage TemplateStorage, tasks *TaskService, events BoardEventPublisher, logger *logging.Logger) *TemplateService {
+	return &TemplateService{
+		storage: storage,
+		tasks:   tasks,
+		events:  boardEvents{storage: storage, publisher: events, logger: logger},
+		logger:  logger,
+	}
+}
+
+// BoardTemplates lists the workspace's board templates. Guests do not see
+// them, as they cannot create boards.
+func (s *TemplateService) BoardTemplates(userID, workspaceID int64) ([]domain.BoardTemplate, error) {
+	if _, err := s.workspace(userID, workspaceID); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectBoardTemplates(workspaceID)
+}
+
+func (s *TemplateService) BoardTemplate(userID, templateID int64) (domain.BoardTemplate, error) {
+	template, _, err := s.boardTemplate(userID, templateID)
+	return template, err
+}
+
+// SaveBoard saves the setup of a board the user administers as a template
+// of its workspace. Automation rules that refer to columns or labels the
+// board no longer has are left out.
+func (s *TemplateService) SaveBoard(userID, boardID int64, req domain.BoardTemplateRequest) (domain.BoardTemplate, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return domain.BoardTemplate{}, errors.New("template name is required and must be at most 100 characters")
+	}
+	if len(req.Description) > 2000 {
+		return domain.BoardTemplate{}, errors.New("template description must be at most 2000 characters")
+	}
+
+	board, err := boardAccess(s.storage, userID, boardID, domain.BoardRoleAdmin)
+	if err != nil {
+		return domain.BoardTemplate{}, err
+	}
+	content, err := s.snapshot(userID, boardID, req.IncludeTasks)
+	if err != nil {
+		return domain.BoardTemplate{}, err
+	}
+
+	template, err := s.storage.InsertBoardTemplate(domain.BoardTemplate{
+		WorkspaceID: board.WorkspaceID,
+		CreatedBy:   &userID,
+		Name:        name,
+		Description: req.Description,
+		Content:     content,
+	})
+	if errors.Is(err, psql.ErrBoardTemplateExists) {
+		return domain.BoardTemplate{}, ErrTemplateExists
+	}
+	return template, err
+}
+
+func (s *TemplateService) DeleteBoardTemplate(userID, templateID int64) error {
+	template, role, err := s.boardTemplate(userID, templateID)
+	if err != nil {
+		return err
+	}
+	owner := template.CreatedBy != nil && *template.CreatedBy == userID
+	if !owner && role != domain.WorkspaceRoleOwner && role != domain.WorkspaceRoleAdmin {
+		return ErrTemplateForbidden
+	}
+
+	deleted, err := s.storage.DeleteBoardTemplate(templateID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+// CreateBoard starts a board in the template's workspace, named after the
+// template unless req says otherwise. The user becomes its admin and the
+// actor of its automation rules; rules that assign, notify or look for
+// anybody else are created disabled, since nobody else is on the board yet.
+func (s *TemplateService) CreateBoard(userID, templateID int64, req domain.BoardRequest) (domain.Board, error) {
+	if err := validateBoardRequest(&req); err != nil {
+		return domain.Board{}, err
+	}
+	template, _, err := s.boardTemplate(userID, templateID)
+	if err != nil {
+		return domain.Board{}, err
+	}
+
+	board := domain.Board{
+		WorkspaceID: template.WorkspaceID,
+		Name:        template.Name,
+		Description: template.Description,
+		CreatedBy:   userID,
+	}
+	if req.Name != nil {
+		board.Name = *req.Name
+	}
+	if req.Description != nil {
+		board.Description = *req.Description
+	}
+
+	content := template.Content
+	content.Automations = make([]domain.TemplateAutomation, len(template.Content.Automations))
+	for i, rule := range template.Content.Automations {
+		rule.Enabled = rule.Enabled && !refersToOthers(rule, userID)
+		content.Automations[i] = rule
+	}
+
+	board, err = s.storage.InsertBoardFromTemplate(board, content)
+	if errors.Is(err, psql.ErrWorkspaceNotFound) {
+		return domain.Board{}, ErrWorkspaceNotFound
+	}
+	if err != nil {
+		return domain.Board{}, err
+	}
+	s.logger.Infof("User %d created board %d from template %d", userID, board.ID, templateID)
+	return board, nil
+}
+
+func (s *TemplateService) TaskTemplates(userID, boardID int64) ([]domain.TaskTemplate, error) {
+	if _, err := boardAccess(s.storage, userID, boardID, domain.BoardRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectTaskTemplates(boardID)
+}
+
+func (s *TemplateService) CreateTaskTemplate(userID, boardID int64, req domain.TaskTemplateRequest) (domain.TaskTemplate, error) {
+	if req.Name == nil || req.Title == nil {
+		return domain.TaskTemplate{}, errors.New("template name and task title are required")
+	}
+	if _, err := boardAccess(s.storage, userID, boardID, domain.BoardRoleEditor); err != nil {
+		return domain.TaskTemplate{}, err
+	}
+	if err := s.validateTaskTemplate(boardID, &req); err != nil {
+		return domain.TaskTemplate{}, err
+	}
+
+	template := domain.TaskTemplate{
+		BoardID:   boardID,
+		CreatedBy: &userID,
+		Name:      *req.Name,
+		Title:     *req.Title,
+		Priority:  domain.PriorityNone,
+	}
+	if req.Description != nil {
+		template.Description = *req.Description
+	}
+	if req.Priority != nil {
+		template.Priority = *req.Priority
+	}
+	if req.LabelIDs != nil {
+		template.LabelIDs = *req.LabelIDs
+	}
+	if req.Checklist != nil {
+		template.Checklist = *req.Checklist
+	}
+
+	template, err := s.storage.InsertTaskTemplate(template)
+	if errors.Is(err, psql.ErrTaskTemplateExists) {
+		return domain.TaskTemplate{}, ErrTemplateExists
+	}
+	return template, err
+}
+
+func (s *TemplateService) UpdateTaskTemplate(userID, templateID int64, req domain.TaskTemplateRequest) (domain.TaskTemplate, error) {
+	template, err := s.taskTemplate(userID, templateID, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.TaskTemplate{}, err
+	}
+	if err := s.validateTaskTemplate(template.BoardID, &req); err != nil {
+		return domain.TaskTemplate{}, err
+	}
+
+	updated, err := s.storage.UpdateTaskTemplate(templateID, req)
+	if errors.Is(err, psql.ErrTaskTemplateExists) {
+		return domain.TaskTemplate{}, ErrTemplateExists
+	}
+	if err != nil {
+		return domain.TaskTemplate{}, err
+	}
+	if !updated {
+		return domain.TaskTemplate{}, ErrTemplateNotFound
+	}
+	return s.taskTemplate(userID, templateID, domain.BoardRoleEditor)
+}
+
+func (s *TemplateService) DeleteTaskTemplate(userID, templateID int64) error {
+	if _, err := s.taskTemplate(userID, templateID, domain.BoardRoleEditor); err != nil {
+		return err
+	}
+
+	deleted, err := s.storage.DeleteTaskTemplate(templateID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrTemplateNotFound
+	}
+	return nil
+}
+
+// CreateTask creates a task from the template in a column of the
+// template's board, then fills in its checklist.
+func (s *TemplateService) CreateTask(userID, templateID int64, req domain.TaskFromTemplateRequest) (domain.Task, error) {
+	template, err := s.taskTemplate(userID, templateID, domain.BoardRoleEditor)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	column, err := s.storage.SelectColumn(userID, req.ColumnID)
+	if errors.Is(err, psql.ErrColumnNotFound) || err == nil && column.BoardID != template.BoardID {
+		return domain.Task{}, ErrColumnNotFound
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	labels, err := s.storage.SelectLabels(template.BoardID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	labelIDs := []int64{}
+	for _, label := range labels {
+		if containsID(template.LabelIDs, label.ID) {
+			labelIDs = append(labelIDs, label.ID)
+		}
+	}
+
+	title := template.Title
+	if req.Title != nil {
+		title = *req.Title
+	}
+	task, err := s.tasks.Create(userID, domain.TaskRequest{
+		ColumnID:    column.ID,
+		Title:       &title,
+		Description: &template.Description,
+		Deadline:    req.Deadline,
+		ParentID:    req.ParentID,
+		Priority:    &template.Priority,
+		LabelIDs:    &labelIDs,
+	})
+	if err != nil || len(template.Checklist) == 0 {
+		return task, err
+	}
+
+	if err := s.storage.InsertChecklist(task.ID, template.Checklist); err != nil {
+		return domain.Task{}, err
+	}
+	task, err = s.tasks.Get(userID, task.ID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+	s.events.publishLatest(userID, task.BoardID, domain.BoardEventTaskUpdated, taskLoader(s.storage, userID, task.ID))
+	return task, nil
+}
+
+// snapshot reads the setup of a board, and its top-level tasks if asked.
+func (s *TemplateService) snapshot(userID, boardID int64, includeTasks bool) (domain.BoardTemplateContent, error) {
+	content := domain.BoardTemplateContent{
+		Columns:     []domain.TemplateColumn{},
+		Labels:      []domain.TemplateLabel{},
+		Fields:      []domain.TemplateField{},
+		Automations: []domain.TemplateAutomation{},
+		Tasks:       []domain.TemplateTask{},
+	}
+
+	columns, err := s.storage.SelectColumns(userID, boardID)
+	if err != nil {
+		return domain.BoardTemplateContent{}, err
+	}
+	columnIDs := make([]int64, 0, len(columns))
+	for _, c := range columns {
+		columnIDs = append(columnIDs, c.ID)
+		content.Columns = append(content.Columns, domain.TemplateColumn{
+			ID:        c.ID,
+			Title:     c.Title,
+			Kind:      c.Kind,
+			WIPLimit:  c.WIPLimit,
+			WIPPolicy: c.WIPPolicy,
+		})
+	}
+
+	labels, err := s.storage.SelectLabels(boardID)
+	if err != nil {
+		return domain.BoardTemplateContent{}, err
+	}
+	labelIDs := make([]int64, 0, len(labels))
+	for _, l := range labels {
+		labelIDs = append(labelIDs, l.ID)
+		content.Labels = append(content.Labels, domain.TemplateLabel{ID: l.ID, Name: l.Name, Color: l.Color})
+	}
+
+	fields, err := s.storage.SelectCustomFields(boardID)
+	if err != nil {
+		return domain.BoardTemplateContent{}, err
+	}
+	for _, f := range fields {
+		content.Fields = append(content.Fields, domain.TemplateField{ID: f.ID, Name: f.Name, Type: f.Type, Options: f.Options})
+	}
+
+	rules, err := s.storage.SelectAutomationRules(boardID)
+	if err != nil {
+		return domain.BoardTemplateContent{}, err
+	}
+	for _, r := range rules {
+		rule := domain.TemplateAutomation{
+			Name:       r.Name,
+			Enabled:    r.Enabled,
+			Trigger:    r.Trigger,
+			Conditions: r.Conditions,
+			Actions:    r.Actions,
+		}
+		if refersToKnown(rule, columnIDs, labelIDs) {
+			content.Automations = append(content.Automations, rule)
+		}
+	}
+
+	if !includeTasks {
+		return content, nil
+	}
+	tasks, err := s.storage.SelectBoardTasks(userID, boardID, domain.TaskFilter{})
+	if err != nil {
+		return domain.BoardTemplateContent{}, err
+	}
+	for _, t := range tasks {
+		if t.ParentID != nil {
+			continue
+		}
+		if len(content.Tasks) == maxTemplateTasks {
+			return domain.BoardTemplateContent{}, fmt.Errorf("a template can keep at most %d tasks", maxTemplateTasks)
+		}
+		items, err := s.storage.SelectChecklistItems(t.ID)
+		if err != nil {
+			return domain.BoardTemplateContent{}, err
+		}
+		checklist := make([]string, 0, len(items))
+		for _, item := range items {
+			checklist = append(checklist, item.Text)
+		}
+		content.Tasks = append(content.Tasks, domain.TemplateTask{
+			ColumnID:    t.ColumnID,
+			Title:       t.Title,
+			Description: t.Description,
+			Priority:    t.Priority,
+			LabelIDs:    t.LabelIDs,
+			Checklist:   checklist,
+		})
+	}
+	return content, nil
+}
+
+// validateTaskTemplate checks the parts of a task template that req sets
+// and trims its text.
+func (s *TemplateService) validateTaskTemplate(boardID int64, req *domain.TaskTemplateRequest) error {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len(name) > 100 {
+			return errors.New("template name is required and must be at most 100 characters")
+		}
+		req.Name = &name
+	}
+
+	task := domain.TaskRequest{Title: req.Title, Description: req.Description, Priority: req.Priority}
+	if err := validateTaskRequest(&task); err != nil {
+		return err
+	}
+	req.Title = task.Title
+
+	if req.LabelIDs != nil && len(*req.LabelIDs) > 0 {
+		labels, err := s.storage.SelectLabels(boardID)
+		if err != nil {
+			return err
+		}
+		known := make([]int64, 0, len(labels))
+		for _, label := range labels {
+			known = append(known, label.ID)
+		}
+		for _, id := range *req.LabelIDs {
+			if !containsID(known, id) {
+				return fmt.Errorf("label %d does not belong to the board", id)
+			}
+		}
+	}
+
+	if req.Checklist != nil {
+		if len(*req.Checklist) > maxTemplateChecklist {
+			return fmt.Errorf("a template can have at most %d checklist items", maxTemplateChecklist)
+		}
+		items := make([]string, len(*req.Checklist))
+		for i, item := range *req.Checklist {
+			text, err := checklistText(item)
+			if err != nil {
+				return err
+			}
+			items[i] = text
+		}
+		req.Checklist = &items
+	}
+	return nil
+}
+
+// boardTemplate loads a template of a workspace the user can create boards
+// in, with the user's role there.
+func (s *TemplateService) boardTemplate(userID, templateID int64) (domain.BoardTemplate, string, error) {
+	template, err := s.storage.SelectBoardTemplate(templateID)
+	if errors.Is(err, psql.ErrBoardTemplateNotFound) {
+		return domain.BoardTemplate{}, "", ErrTemplateNotFound
+	}
+	if err != nil {
+		return domain.BoardTemplate{}, "", err
+	}
+
+	role, err := s.workspace(userID, template.WorkspaceID)
+	if errors.Is(err, ErrWorkspaceNotFound) {
+		return domain.BoardTemplate{}, "", ErrTemplateNotFound
+	}
+	if err != nil {
+		return domain.BoardTemplate{}, "", err
+	}
+	return template, role, nil
+}
+
+func (s *TemplateService) taskTemplate(userID, templateID int64, minRole string) (domain.TaskTemplate, error) {
+	template, err := s.storage.SelectTaskTemplate(templateID)
+	if errors.Is(err, psql.ErrTaskTemplateNotFound) {
+		return domain.TaskTemplate{}, ErrTemplateNotFound
+	}
+	if err != nil {
+		return domain.TaskTemplate{}, err
+	}
+
+	if _, err := boardAccess(s.storage, userID, template.BoardID, minRole); err != nil {
+		if errors.Is(err, ErrBoardNotFound) {
+			return domain.TaskTemplate{}, ErrTemplateNotFound
+		}
+		return domain.TaskTemplate{}, err
+	}
+	return template, nil
+}
+
+// workspace returns the user's role in the workspace, refusing guests.
+func (s *TemplateService) workspace(userID, workspaceID int64) (string, error) {
+	role, err := s.storage.SelectWorkspaceRole(workspaceID, userID)
+	if errors.Is(err, psql.ErrWorkspaceNotFound) {
+		return "", ErrWorkspaceNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if role == domain.WorkspaceRoleGuest {
+		return "", ErrWorkspaceForbidden
+	}
+	return role, nil
+}
+
+// refersToKnown tells whether every column and label the rule names is one
+// of the given.
+func refersToKnown(rule domain.TemplateAutomation, columnIDs, labelIDs []int64) bool {
+	columns := append([]int64{rule.Trigger.ColumnID}, rule.Conditions.ColumnIDs...)
+	labels := append([]int64{rule.Trigger.LabelID}, rule.Conditions.LabelIDs...)
+	for _, action := range rule.Actions {
+		columns = append(columns, action.ColumnID)
+		labels = append(labels, action.LabelID)
+	}
+
+	for _, id := range columns {
+		if id != 0 && !containsID(columnIDs, id) {
+			return false
+		}
+	}
+	for _, id := range labels {
+		if id != 0 && !containsID(labelIDs, id) {
+			return false
+		}
+	}
+	return true
+}
+
+// refersToOthers tells whether the rule names a user other than userID.
+func refersToOthers(rule domain.TemplateAutomation, userID int64) bool {
+	for _, id := range rule.Conditions.AssigneeIDs {
+		if id != userID {
+			return true
+		}
+	}
+	for _, action := range rule.Actions {
+		if action.UserID != 0 && action.UserID != userID {
+			return true
+		}
+	}
+	return false
+}
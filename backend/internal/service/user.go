@@ -10,8 +10,9 @@ import (
 	"time"
 
 	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/pkg/auth"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
 
-	"github.com/golang-jwt/jwt/v5"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -23,10 +24,16 @@ type UserStorage interface {
 	RefreshGet(token string) (int64, error)
 	RefreshDelete(token string) error
 	UserBlocked(email string, windowStart time.Time) ([]map[string]interface{}, error)
-	LogAttempt(email string, result bool, attemptTime time.Time) error
+	LogAttempt(email string, result bool, attemptTime time.Time, client domain.ClientInfo) error
 	GetFailedLogAttempts(email string, windowStart time.Time) (int, error)
+	GetFailedIPAttempts(ip string, windowStart time.Time) (int, time.Time, error)
+	GetLockoutCount(email string) (int, error)
 	BlockUser(email, blockedUntil string) error
+	ResetFailedAttempts(email string) error
+	ClearLoginAttempts(email, ip string) error
 	RenovationTwoFAStatus(userID int64, enabled bool) error
+	ResetPassword(userID int64, passwordHash string) (bool, error)
+	RefreshDeleteByUserID(userID int64) error
 	InsertTwoFaCode(userID int64, code string, expiresAt time.Time) error
 	SelectTwoFaCodeByUserID(userID int64) (domain.TwoFaCode, error)
 	RenovationTwoFaCodeAttempts(codeID int64, attempts int) error
@@ -36,12 +43,16 @@ type UserStorage interface {
 }
 
 type User struct {
-	storage      UserStorage
-	jwtSecret    string
+	storage       UserStorage
+	notifications NotificationCreator
+	tokens        *auth.Manager
+	protection    LoginProtection
+	audit         AuditRecorder
+	logger        *logging.Logger
 }
 
-func NewUser(storage UserStorage, jwt string) *User{
-	return &User{storage: storage, jwtSecret: jwt}
+func NewUser(storage UserStorage, notifications NotificationCreator, tokens *auth.Manager, protection LoginProtection, audit AuditRecorder, logger *logging.Logger) *User{
+	return &User{storage: storage, notifications: notifications, tokens: tokens, protection: protection, audit: audit, logger: logger}
 }
 
 func (s *User) UserRegister(user domain.User) (domain.User, error) {
@@ -51,16 +62,8 @@ func (s *User) UserRegister(user domain.User) (domain.User, error) {
 		return domain.User{}, errors.New("Invalid input: all fields are required")
 	}
 	
-	if user.Password == "" || len(user.Password) < 8 {
-		return domain.User{}, errors.New("Invalid password input: password must br at least 8 characters")
-	}
-	
-	hasLetters, _ := regexp.MatchString(`[a-zA-Zа-яА-Я]`, user.Password)
-	hasDigits, _ := regexp.MatchString(`[0-9]`, user.Password)
-	hasSpecial, _ := regexp.MatchString(`[^a-zA-Zа-яА-Я0-9\s]`, user.Password)
-	
-	if !hasLetters || !hasDigits || !hasSpecial {
-		return domain.User{}, errors.New("Invalid password input: password must contain letters, digits and special characters")
+	if err := validatePassword(user.Password); err != nil {
+		return domain.User{}, err
 	}
 	
 	hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
@@ -99,89 +102,111 @@ func (s *User) UserRegister(user domain.User) (domain.User, error) {
 	return createdUser, nil
 }
 
-func (s *User) StudentsLogin(user domain.User) (domain.TokenResponse, domain.TwoFaCodes, error) {
-    fmt.Printf("DEBUG LOGIN: Attempting login for email: '%s'\n", user.Email)
-    fmt.Printf("DEBUG LOGIN: Password provided: '%s'\n", user.Password)
-    fmt.Printf("DEBUG LOGIN: TwoFA enabled: '%v'\n", user.TwoFAEnabled)
-    
+func (s *User) StudentsLogin(user domain.User, client domain.ClientInfo) (domain.TokenResponse, domain.TwoFaCodes, error) {
     if user.Email == "" || user.Password == "" {
-        fmt.Printf("DEBUG LOGIN: Email or password empty\n")
         return domain.TokenResponse{}, domain.TwoFaCodes{}, errors.New("email and password are required")
     }
     
+    ipLock, err := s.ipLockRemaining(client.IP)
+    if err != nil {
+        s.logger.Errorf("Failed to check login lock of %s: %v", client.IP, err)
+        return domain.TokenResponse{}, domain.TwoFaCodes{}, err
+    }
+    
+    if ipLock > 0 {
+        s.recordLoginFailure(user.Email, 0, "ip_locked", client)
+        return domain.TokenResponse{}, domain.TwoFaCodes{}, &LoginError{
+            Message:    "too many failed attempts from this address",
+            RetryAfter: ipLock,
+        }
+    }
+    
     blocked, minutesLeft, err := s.IsUserBlocked(user.Email)
     if err != nil {
-        fmt.Printf("DEBUG LOGIN: Error checking block status: %v\n", err)
+        s.logger.Errorf("Failed to check login lock of an account: %v", err)
         return domain.TokenResponse{}, domain.TwoFaCodes{}, err
     }
     
     if blocked {
-        fmt.Printf("DEBUG LOGIN: User is blocked for %d minutes\n", minutesLeft)
-        return domain.TokenResponse{}, domain.TwoFaCodes{}, fmt.Errorf("your account is blocked for %d minutes", minutesLeft)
+        s.recordLoginFailure(user.Email, 0, "account_locked", client)
+        return domain.TokenResponse{}, domain.TwoFaCodes{}, &LoginError{
+            Message:    fmt.Sprintf("your account is blocked for %d minutes", minutesLeft),
+            RetryAfter: time.Duration(minutesLeft) * time.Minute,
+        }
     }
     
-    fmt.Printf("DEBUG LOGIN: Searching user in database...\n")
-    dbUser, err := s.storage.SelectUser(user.Email)
+    captchaRequired, err := s.captchaRequired(user.Email, client.IP)
     if err != nil {
-        fmt.Printf("DEBUG LOGIN: Database error or user not found: %v\n", err)
-        s.LogLoginAttempt(user.Email, false)
-        return domain.TokenResponse{}, domain.TwoFaCodes{}, errors.New("invalid credentials")
+        return domain.TokenResponse{}, domain.TwoFaCodes{}, err
     }
     
-    fmt.Printf("DEBUG LOGIN: User found - ID: %d, Email: %s\n", dbUser.ID, dbUser.Email)
-    fmt.Printf("DEBUG LOGIN: Stored password hash: %s\n", dbUser.PasswordHash)
-    fmt.Printf("DEBUG LOGIN: Provided password: %s\n", user.Password)
+    if captchaRequired {
+        solved, err := s.protection.Captcha.Verify(user.CaptchaToken, client.IP)
+        if err != nil {
+            s.logger.Errorf("Failed to verify CAPTCHA: %v", err)
+        }
+        if !solved {
+            s.recordLoginFailure(user.Email, 0, "captcha_failed", client)
+            return domain.TokenResponse{}, domain.TwoFaCodes{}, &LoginError{
+                Message:         "captcha required",
+                CaptchaRequired: true,
+            }
+        }
+    }
     
-    fmt.Printf("DEBUG LOGIN: Comparing passwords...\n")
-    err = bcrypt.CompareHashAndPassword([]byte(dbUser.PasswordHash), []byte(user.Password))
+    dbUser, err := s.storage.SelectUser(user.Email)
     if err != nil {
-        fmt.Printf("DEBUG LOGIN: Password comparison failed: %v\n", err)
-        s.LogLoginAttempt(user.Email, false)
-        return domain.TokenResponse{}, domain.TwoFaCodes{}, errors.New("invalid credentials")
+        return domain.TokenResponse{}, domain.TwoFaCodes{}, s.registerFailure(user.Email, nil, "unknown_email", client)
     }
     
-    fmt.Printf("DEBUG LOGIN: Password correct!\n")
-    
-    attempts, err := s.GetFailedAttempts(user.Email)
+    err = bcrypt.CompareHashAndPassword([]byte(dbUser.PasswordHash), []byte(user.Password))
     if err != nil {
-        fmt.Printf("DEBUG LOGIN: Error getting failed attempts: %v\n", err)
-        return domain.TokenResponse{}, domain.TwoFaCodes{}, err
+        return domain.TokenResponse{}, domain.TwoFaCodes{}, s.registerFailure(user.Email, &dbUser, "invalid_password", client)
     }
     
-    maxAttempts := int64(5)
-    if attempts >= maxAttempts {
-        fmt.Printf("DEBUG LOGIN: Too many failed attempts: %d\n", attempts)
-        s.BlockUser(user.Email)
-        return domain.TokenResponse{}, domain.TwoFaCodes{}, errors.New("too many failed attempts, account blocked")
+    s.LogLoginAttempt(user.Email, true, client)
+    if err := s.storage.ResetFailedAttempts(user.Email); err != nil {
+        s.logger.Errorf("Failed to reset failed logins of user %d: %v", dbUser.ID, err)
     }
 
+    // The second factor comes before a forced password reset; VerifyCode
+    // asks for the reset once the code checks out.
     if dbUser.TwoFAEnabled != false {
         tempToken, err := s.GenerateTempToken(dbUser.ID)
         if err != nil {
-            fmt.Printf("DEBUG LOGIN: Error generating temp token: %v\n", err)
+            s.logger.Errorf("Failed to issue 2FA token for user %d: %v", dbUser.ID, err)
             return domain.TokenResponse{}, domain.TwoFaCodes{}, err
         }
         return domain.TokenResponse{}, domain.TwoFaCodes{RequiresTwoFa: true, TempToken: tempToken}, nil
     }
+
+    if dbUser.PasswordResetRequired {
+        return domain.TokenResponse{}, domain.TwoFaCodes{}, s.passwordResetError(dbUser.ID)
+    }
     
     accessToken, err := s.GenerateAccessToken(dbUser.ID)
     if err != nil {
-        fmt.Printf("DEBUG LOGIN: Error generating access token: %v\n", err)
+        s.logger.Errorf("Failed to issue access token for user %d: %v", dbUser.ID, err)
         return domain.TokenResponse{}, domain.TwoFaCodes{}, err
     }
     
     refreshToken, err := s.GenerateRefreshToken(dbUser.ID)
     if err != nil {
-        fmt.Printf("DEBUG LOGIN: Error generating refresh token: %v\n", err)
+        s.logger.Errorf("Failed to issue refresh token for user %d: %v", dbUser.ID, err)
         return domain.TokenResponse{}, domain.TwoFaCodes{}, err
     }
     
-    s.LogLoginAttempt(user.Email, true)
-    fmt.Printf("DEBUG LOGIN: Login successful for user ID: %d\n", dbUser.ID)
+    s.recordEvent(domain.AuditLoginSucceeded, dbUser.ID, dbUser.ID, client, map[string]interface{}{
+        "method": "password",
+    })
     return domain.TokenResponse{AccessToken: accessToken, RefreshToken: refreshToken}, domain.TwoFaCodes{}, nil
 }
 
 func (s *User) StudentsRefresh(refreshToken string) (domain.TokenResponse, error) {
+	if _, err := s.tokens.ParseToken(refreshToken, auth.TokenTypeRefresh); err != nil {
+		return domain.TokenResponse{}, errors.New("Invalid refresh token")
+	}
+	
 	userID, err := s.storage.RefreshGet(refreshToken)
 	if err != nil {
 		return  domain.TokenResponse{}, errors.New("Invalid refresh token")
@@ -201,31 +226,15 @@ func (s *User) StudentsRefresh(refreshToken string) (domain.TokenResponse, error
 }
 
 func (s *User) GenerateAccessToken(id int64) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id": id,
-		"exp":     time.Now().Add(15 * time.Minute).Unix(),
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+	return s.tokens.GenerateToken(id, auth.TokenTypeAccess, 15*time.Minute)
 }
 
 func (s *User) GenerateTempToken(id int64) (string, error) {
-	claims := jwt.MapClaims{
-		"user_id": id,
-		"exp":     time.Now().Add(10 * time.Minute).Unix(),
-	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.jwtSecret))
+	return s.tokens.GenerateToken(id, auth.TokenTypeTemp, 10*time.Minute)
 }
 
 func (s *User) GenerateRefreshToken(id int64) (string, error) {
-	token := jwt.New(jwt.SigningMethodHS256)
-	
-	claims := token.Claims.(jwt.MapClaims)
-	claims["user_id"] = id
-	claims["exp"] = time.Now().Add(7 *24 * time.Hour).Unix()
-	
-	signed, err := token.SignedString([]byte(s.jwtSecret))
+	signed, err := s.tokens.GenerateToken(id, auth.TokenTypeRefresh, 7*24*time.Hour)
 	if err != nil {
 		return "", err
 	}
@@ -246,14 +255,14 @@ func (s *User) IsUserBlocked(email string) (bool, int64, error) {
 	}
 	
 	if len(result) > 0 {
-		blockedUntilStr, ok := result[0]["blocked_until"].(string)
-		if !ok {
-			return false, 0, errors.New("invalid format for blocked_until")
+		isBlocked, _ := result[0]["is_blocked"].(bool)
+		if !isBlocked {
+			return false, 0, nil
 		}
 	
-		blockedUntil, err := time.Parse(time.RFC3339, blockedUntilStr)
-		if err != nil {
-			return false, 0, err
+		blockedUntil, ok := result[0]["blocked_until"].(time.Time)
+		if !ok {
+			return false, 0, errors.New("invalid format for blocked_until")
 		}
 	
 		minutesLeft := math.Ceil(time.Until(blockedUntil).Minutes())
@@ -267,10 +276,10 @@ func (s *User) IsUserBlocked(email string) (bool, int64, error) {
 	return false, 0, nil
 }
 
-func (s *User) LogLoginAttempt(email string, result bool) {
+func (s *User) LogLoginAttempt(email string, result bool, client domain.ClientInfo) {
 	attemptTime := time.Now().UTC()
 
-	err := s.storage.LogAttempt(email, result, attemptTime)
+	err := s.storage.LogAttempt(email, result, attemptTime, client)
 	if err != nil {
 		fmt.Printf("Ошибка логирования: %v\n", err)
 	}
@@ -278,7 +287,7 @@ func (s *User) LogLoginAttempt(email string, result bool) {
 
 func (s *User) GetFailedAttempts(email string) (int64, error) {
 	now := time.Now().UTC()
-	windowStart := now.Add(-1 * time.Minute)
+	windowStart := now.Add(-s.protection.Window)
 	
 	count, err := s.storage.GetFailedLogAttempts(email, windowStart)
 	if err != nil {
@@ -289,11 +298,9 @@ func (s *User) GetFailedAttempts(email string) (int64, error) {
 	return int64(count), err
 }
 
-func (s *User) BlockUser(email string) {
+func (s *User) BlockUser(email string, duration time.Duration) {
 	now := time.Now()
-	blockedUntil := now.Add(1 * time.Minute).Format(time.RFC3339)
-
-	s.LogLoginAttempt(email, false)
+	blockedUntil := now.Add(duration).Format(time.RFC3339)
 
 	err := s.storage.BlockUser(email, blockedUntil)
 	if err != nil {
@@ -302,7 +309,7 @@ func (s *User) BlockUser(email string) {
 }
 
 func (s *User) StudentsSendEmailCode(tempToken string) error {
-	userID, err := s.extractUserIDFromToken(tempToken)
+	userID, err := s.extractUserIDFromTempToken(tempToken)
 	if err != nil {
 		return errors.New("Invalid temp token")
 	}
@@ -336,8 +343,8 @@ func (s *User) StudentsSendEmailCode(tempToken string) error {
 	return nil
 }
 
-func (s *User) VerifyCode(code domain.Code) (domain.TokenResponse, error) {
-	userID, err := s.extractUserIDFromToken(code.TempToken)
+func (s *User) VerifyCode(code domain.Code, client domain.ClientInfo) (domain.TokenResponse, error) {
+	userID, err := s.extractUserIDFromTempToken(code.TempToken)
 	if err != nil {
 		return domain.TokenResponse{}, errors.New("invalid temp token")
 	}
@@ -375,6 +382,10 @@ func (s *User) VerifyCode(code domain.Code) (domain.TokenResponse, error) {
 			return domain.TokenResponse{}, err
 		}
 		
+		s.recordEvent(domain.AuditLoginFailed, 0, userID, client, map[string]interface{}{
+			"reason": "invalid_2fa_code",
+		})
+
 		remainingAttempts := 3 - (twoFaCode.Attempts + 1)
 		return domain.TokenResponse{}, fmt.Errorf("invalid code, %d attempts remaining", remainingAttempts)
 	}
@@ -383,6 +394,14 @@ func (s *User) VerifyCode(code domain.Code) (domain.TokenResponse, error) {
 	if err != nil {
 		return domain.TokenResponse{}, err
 	}
+
+	dbUser, err := s.storage.SelectUserByID(twoFaCode.UserID)
+	if err != nil {
+		return domain.TokenResponse{}, err
+	}
+	if dbUser.PasswordResetRequired {
+		return domain.TokenResponse{}, s.passwordResetError(dbUser.ID)
+	}
 	
 	accessToken, err := s.GenerateAccessToken(twoFaCode.UserID)
 	if err != nil {
@@ -393,6 +412,10 @@ func (s *User) VerifyCode(code domain.Code) (domain.TokenResponse, error) {
 	if err != nil {
 		return domain.TokenResponse{}, err
 	}
+
+	s.recordEvent(domain.AuditLoginSucceeded, twoFaCode.UserID, twoFaCode.UserID, client, map[string]interface{}{
+		"method": "2fa",
+	})
 	
 	return domain.TokenResponse{
 		AccessToken: accessToken,
@@ -400,6 +423,19 @@ func (s *User) VerifyCode(code domain.Code) (domain.TokenResponse, error) {
 	}, nil
 }
 
+// passwordResetError hands out a password reset token in place of a
+// session, once the user has passed every login factor.
+func (s *User) passwordResetError(userID int64) error {
+	resetToken, err := s.tokens.GenerateToken(userID, auth.TokenTypeReset, 15*time.Minute)
+	if err != nil {
+		return err
+	}
+	return &LoginError{
+		Message:    "password reset required",
+		ResetToken: resetToken,
+	}
+}
+
 func (s *User) generateSixDigitCode() (string, error) {
 	max := big.NewInt(899999)
 	n, err := rand.Int(rand.Reader, max)
@@ -414,11 +450,16 @@ func (s *User) sendEmail(userID int64, code string) error {
 	return nil
 }
 
-func (s *User) EnableTwoFA(userID int64) error {
-	return s.storage.RenovationTwoFAStatus(userID, true)
+func (s *User) EnableTwoFA(userID int64, client domain.ClientInfo) error {
+	if err := s.storage.RenovationTwoFAStatus(userID, true); err != nil {
+		return err
+	}
+
+	s.recordEvent(domain.AuditTwoFAEnabled, userID, userID, client, nil)
+	return nil
 }
 
-func (s *User) DisableTwoFA(userID int64, password string) error {
+func (s *User) DisableTwoFA(userID int64, password string, client domain.ClientInfo) error {
 	student, err := s.storage.SelectUserByID(userID)
 	if err != nil {
 		return errors.New("user not found")
@@ -429,26 +470,64 @@ func (s *User) DisableTwoFA(userID int64, password string) error {
 		return errors.New("Invalid password")
 	}
 	
-	return s.storage.RenovationTwoFAStatus(userID, false)
+	if err := s.storage.RenovationTwoFAStatus(userID, false); err != nil {
+		return err
+	}
+
+	s.recordEvent(domain.AuditTwoFADisabled, userID, userID, client, nil)
+	return nil
 }
 
-func (s *User) extractUserIDFromToken(tokenString string) (int64, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte(s.jwtSecret), nil
-	})
+// ResetPassword sets a new password with the reset token handed out by
+// UserLogin or VerifyCode when an admin forced a password reset. All refresh
+// tokens are dropped, so the user has to sign in again with the new password.
+func (s *User) ResetPassword(req domain.PasswordResetRequest, client domain.ClientInfo) error {
+	userID, err := s.tokens.ExtractUserIDFromToken(req.ResetToken, auth.TokenTypeReset)
 	if err != nil {
-		return 0, err
+		return errors.New("invalid reset token")
 	}
-	
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return 0, errors.New("Invalid token claims")
+
+	if err := validatePassword(req.NewPassword); err != nil {
+		return err
 	}
-	
-	userIDFloat, ok := claims["user_id"].(float64)
-	if !ok {
-		return 0, errors.New("Invalid user_id in token")
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return errors.New("Error hashing password")
 	}
-	
-	return int64(userIDFloat), nil
+
+	reset, err := s.storage.ResetPassword(userID, string(hash))
+	if err != nil {
+		return err
+	}
+	if !reset {
+		return errors.New("invalid reset token")
+	}
+
+	if err := s.storage.RefreshDeleteByUserID(userID); err != nil {
+		return err
+	}
+
+	s.recordEvent(domain.AuditPasswordReset, userID, userID, client, nil)
+	return nil
+}
+
+func validatePassword(password string) error {
+	if password == "" || len(password) < 8 {
+		return errors.New("Invalid password input: password must br at least 8 characters")
+	}
+
+	hasLetters, _ := regexp.MatchString(`[a-zA-Zа-яА-Я]`, password)
+	hasDigits, _ := regexp.MatchString(`[0-9]`, password)
+	hasSpecial, _ := regexp.MatchString(`[^a-zA-Zа-яА-Я0-9\s]`, password)
+
+	if !hasLetters || !hasDigits || !hasSpecial {
+		return errors.New("Invalid password input: password must contain letters, digits and special characters")
+	}
+
+	return nil
+}
+
+func (s *User) extractUserIDFromTempToken(tokenString string) (int64, error) {
+	return s.tokens.ExtractUserIDFromToken(tokenString, auth.TokenTypeTemp)
 }
\ No newline at end of file
@@ -0,0 +1,385 @@
+package service
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+)
+
+var (
+	ErrViewNotFound  = errors.New("view not found")
+	ErrViewForbidden = errors.New("only the owner of the view can change it")
+	ErrViewExists    = errors.New("you already have a view with this name on the board")
+)
+
+var taskSorts = map[string]bool{
+	domain.TaskSortPosition: true,
+	domain.TaskSortDeadline: true,
+	domain.TaskSortPriority: true,
+	domain.TaskSortCreated:  true,
+	domain.TaskSortUpdated:  true,
+	domain.TaskSortTitle:    true,
+}
+
+var taskGroups = map[string]bool{
+	domain.TaskGroupStatus:   true,
+	domain.TaskGroupAssignee: true,
+	domain.TaskGroupPriority: true,
+	domain.TaskGroupLabel:    true,
+	domain.TaskGroupNone:     true,
+}
+
+var priorityRank = map[string]int{
+	domain.PriorityNone:   0,
+	domain.PriorityLow:    1,
+	domain.PriorityMedium: 2,
+	domain.PriorityHigh:   3,
+	domain.PriorityUrgent: 4,
+}
+
+type ViewStorage interface {
+	SelectBoard(userID, boardID int64) (domain.Board, error)
+	SelectCustomFields(boardID int64) ([]domain.CustomField, error)
+	InsertBoardView(v domain.BoardView) (domain.BoardView, error)
+	SelectBoardViews(userID, boardID int64) ([]domain.BoardView, error)
+	SelectBoardView(viewID int64) (domain.BoardView, error)
+	UpdateBoardView(viewID int64, req domain.BoardViewRequest) (bool, error)
+	DeleteBoardView(viewID int64) (bool, error)
+}
+
+// ViewService manages saved board views. Any member can save private views;
+// sharing a view with the board needs editor or admin. Only the owner
+// changes a view, and the owner or a board admin deletes it.
+type ViewService struct {
+	storage ViewStorage
+	logger  *logging.Logger
+}
+
+func NewViewService(storage ViewStorage, logger *logging.Logger) *ViewService {
+	return &ViewService{storage: storage, logger: logger}
+}
+
+// List returns the user's views of the board first, then the shared ones.
+func (s *ViewService) List(userID, boardID int64) ([]domain.BoardView, error) {
+	if _, err := boardAccess(s.storage, userID, boardID, domain.BoardRoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectBoardViews(userID, boardID)
+}
+
+func (s *ViewService) Create(userID, boardID int64, req domain.BoardViewRequest) (domain.BoardView, error) {
+	if req.Name == nil {
+		return domain.BoardView{}, errors.New("view name is required")
+	}
+
+	minRole := domain.BoardRoleViewer
+	if req.Shared != nil && *req.Shared {
+		minRole = domain.BoardRoleEditor
+	}
+	if _, err := boardAccess(s.storage, userID, boardID, minRole); err != nil {
+		return domain.BoardView{}, err
+	}
+	if err := s.validate(&req, boardID); err != nil {
+		return domain.BoardView{}, err
+	}
+
+	view := domain.BoardView{
+		BoardID: boardID,
+		UserID:  userID,
+		Name:    *req.Name,
+		Shared:  req.Shared != nil && *req.Shared,
+	}
+	if req.Query != nil {
+		view.Query = *req.Query
+	}
+	view, err := s.storage.InsertBoardView(view)
+	if errors.Is(err, psql.ErrViewExists) {
+		return domain.BoardView{}, ErrViewExists
+	}
+	return view, err
+}
+
+func (s *ViewService) Update(userID, viewID int64, req domain.BoardViewRequest) (domain.BoardView, error) {
+	view, err := s.view(userID, viewID)
+	if err != nil {
+		return domain.BoardView{}, err
+	}
+	if view.UserID != userID {
+		return domain.BoardView{}, ErrViewForbidden
+	}
+	if err := s.validate(&req, view.BoardID); err != nil {
+		return domain.BoardView{}, err
+	}
+	if req.Shared != nil && *req.Shared && !view.Shared {
+		if _, err := boardAccess(s.storage, userID, view.BoardID, domain.BoardRoleEditor); err != nil {
+			return domain.BoardView{}, err
+		}
+	}
+
+	ok, err := s.storage.UpdateBoardView(viewID, req)
+	if errors.Is(err, psql.ErrViewExists) {
+		return domain.BoardView{}, ErrViewExists
+	}
+	if err != nil {
+		return domain.BoardView{}, err
+	}
+	if !ok {
+		return domain.BoardView{}, ErrViewNotFound
+	}
+	return s.view(userID, viewID)
+}
+
+func (s *ViewService) Delete(userID, viewID int64) error {
+	view, err := s.view(userID, viewID)
+	if err != nil {
+		return err
+	}
+	if view.UserID != userID {
+		if _, err := boardAccess(s.storage, userID, view.BoardID, domain.BoardRoleAdmin); err != nil {
+			if errors.Is(err, ErrBoardForbidden) {
+				return ErrViewForbidden
+			}
+			return err
+		}
+	}
+
+	ok, err := s.storage.DeleteBoardView(viewID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrViewNotFound
+	}
+	return nil
+}
+
+// validate trims the name and checks the query against the board's fields.
+func (s *ViewService) validate(req *domain.BoardViewRequest, boardID int64) error {
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if name == "" || len([]rune(name)) > 100 {
+			return errors.New("view name is required and must be at most 100 characters")
+		}
+		req.Name = &name
+	}
+	if req.Query == nil {
+		return nil
+	}
+
+	if err := validateBoardQuery(req.Query); err != nil {
+		return err
+	}
+	fields, err := s.storage.SelectCustomFields(boardID)
+	if err != nil {
+		return err
+	}
+	filter := req.Query.Filter
+	filter.Fields = append([]domain.FieldFilter{}, filter.Fields...)
+	return resolveTaskFilter(&filter, fields)
+}
+
+// view loads a view the user can see: their own, or a shared one on a board
+// they can open.
+func (s *ViewService) view(userID, viewID int64) (domain.BoardView, error) {
+	view, err := s.storage.SelectBoardView(viewID)
+	if errors.Is(err, psql.ErrViewNotFound) {
+		return domain.BoardView{}, ErrViewNotFound
+	}
+	if err != nil {
+		return domain.BoardView{}, err
+	}
+	if _, err := boardAccess(s.storage, userID, view.BoardID, domain.BoardRoleViewer); err != nil {
+		if errors.Is(err, ErrBoardNotFound) {
+			return domain.BoardView{}, ErrViewNotFound
+		}
+		return domain.BoardView{}, err
+	}
+	if view.UserID != userID && !view.Shared {
+		return domain.BoardView{}, ErrViewNotFound
+	}
+	return view, nil
+}
+
+// validateBoardQuery checks the sorting and grouping, filling in the
+// defaults, and that the due range is not reversed.
+func validateBoardQuery(query *domain.BoardQuery) error {
+	if query.SortBy == "" {
+		query.SortBy = domain.TaskSortPosition
+	}
+	if !taskSorts[query.SortBy] {
+		return errors.New("unknown sort " + strconv.Quote(query.SortBy))
+	}
+	if query.GroupBy == "" {
+		query.GroupBy = domain.TaskGroupStatus
+	}
+	if !taskGroups[query.GroupBy] {
+		return errors.New("unknown grouping " + strconv.Quote(query.GroupBy))
+	}
+
+	filter := query.Filter
+	if filter.DueFrom != nil && filter.DueTo != nil && !filter.DueFrom.Before(*filter.DueTo) {
+		return errors.New("due_from must be before due_to")
+	}
+	return nil
+}
+
+// mergeBoardQuery applies the criteria given with a request over those of a
+// saved view: each one given replaces the view's.
+func mergeBoardQuery(view, req domain.BoardQuery) domain.BoardQuery {
+	merged := view
+	f, r := &merged.Filter, req.Filter
+	if len(r.Priorities) > 0 {
+		f.Priorities = r.Priorities
+	}
+	if len(r.LabelIDs) > 0 {
+		f.LabelIDs = r.LabelIDs
+	}
+	if len(r.AssigneeIDs) > 0 || r.AssignedToMe {
+		f.AssigneeIDs = r.AssigneeIDs
+		f.AssignedToMe = r.AssignedToMe
+	}
+	if len(r.ColumnIDs) > 0 {
+		f.ColumnIDs = r.ColumnIDs
+	}
+	if r.Done != nil {
+		f.Done = r.Done
+	}
+	if r.DueFrom != nil || r.DueTo != nil {
+		f.DueFrom, f.DueTo = r.DueFrom, r.DueTo
+	}
+	if r.Text != "" {
+		f.Text = r.Text
+	}
+	if len(r.Fields) > 0 {
+		f.Fields = r.Fields
+	}
+	if req.SortBy != "" {
+		merged.SortBy, merged.SortDesc = req.SortBy, req.SortDesc
+	}
+	if req.GroupBy != "" {
+		merged.GroupBy = req.GroupBy
+	}
+	return merged
+}
+
+// existingFieldFilters drops the conditions on fields deleted since a view
+// was saved, so the view keeps working without them.
+func existingFieldFilters(filters []domain.FieldFilter, fields []domain.CustomField) []domain.FieldFilter {
+	exists := make(map[int64]bool, len(fields))
+	for _, field := range fields {
+		exists[field.ID] = true
+	}
+	kept := make([]domain.FieldFilter, 0, len(filters))
+	for _, f := range filters {
+		if exists[f.FieldID] {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// sortTasks orders tasks in place. Tasks without a deadline come last in
+// either direction; ties keep the board's order.
+func sortTasks(tasks []domain.Task, sortBy string, desc bool) {
+	if sortBy == domain.TaskSortPosition {
+		if desc {
+			for i, j := 0, len(tasks)-1; i < j; i, j = i+1, j-1 {
+				tasks[i], tasks[j] = tasks[j], tasks[i]
+			}
+		}
+		return
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		var cmp int
+		switch sortBy {
+		case domain.TaskSortDeadline:
+			if a.Deadline == nil || b.Deadline == nil {
+				return a.Deadline != nil && b.Deadline == nil
+			}
+			cmp = a.Deadline.Compare(*b.Deadline)
+		case domain.TaskSortPriority:
+			// Most urgent first when ascending.
+			cmp = priorityRank[b.Priority] - priorityRank[a.Priority]
+		case domain.TaskSortCreated:
+			cmp = a.CreatedAt.Compare(b.CreatedAt)
+		case domain.TaskSortUpdated:
+			cmp = a.UpdatedAt.Compare(b.UpdatedAt)
+		case domain.TaskSortTitle:
+			cmp = strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+}
+
+// groupTasks splits sorted tasks by the grouping, keeping their order within
+// each group. Groups follow the board's labels, the priorities from most
+// urgent, or assignee ids; the group of tasks without a value comes last.
+func groupTasks(tasks []domain.Task, groupBy string, labels []domain.Label) []domain.TaskGroup {
+	if groupBy == domain.TaskGroupNone {
+		return []domain.TaskGroup{{Key: "", Tasks: append([]domain.Task{}, tasks...)}}
+	}
+
+	byKey := make(map[string][]domain.Task)
+	add := func(key string, task domain.Task) {
+		byKey[key] = append(byKey[key], task)
+	}
+	for _, task := range tasks {
+		switch groupBy {
+		case domain.TaskGroupPriority:
+			add(task.Priority, task)
+		case domain.TaskGroupLabel:
+			if len(task.LabelIDs) == 0 {
+				add("", task)
+			}
+			for _, id := range task.LabelIDs {
+				add(strconv.FormatInt(id, 10), task)
+			}
+		case domain.TaskGroupAssignee:
+			if len(task.AssigneeIDs) == 0 {
+				add("", task)
+			}
+			for _, id := range task.AssigneeIDs {
+				add(strconv.FormatInt(id, 10), task)
+			}
+		}
+	}
+
+	var keys []string
+	switch groupBy {
+	case domain.TaskGroupPriority:
+		keys = []string{domain.PriorityUrgent, domain.PriorityHigh, domain.PriorityMedium, domain.PriorityLow, domain.PriorityNone}
+	case domain.TaskGroupLabel:
+		for _, label := range labels {
+			keys = append(keys, strconv.FormatInt(label.ID, 10))
+		}
+	case domain.TaskGroupAssignee:
+		var ids []int64
+		for key := range byKey {
+			if id, err := strconv.ParseInt(key, 10, 64); err == nil {
+				ids = append(ids, id)
+			}
+		}
+		for _, id := range sortedIDs(ids) {
+			keys = append(keys, strconv.FormatInt(id, 10))
+		}
+	}
+	keys = append(keys, "")
+
+	groups := make([]domain.TaskGroup, 0, len(keys))
+	for _, key := range keys {
+		if len(byKey[key]) > 0 {
+			groups = append(groups, domain.TaskGroup{Key: key, Tasks: byKey[key]})
+		}
+	}
+	return groups
+}
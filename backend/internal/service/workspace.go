@@ -0,0 +1,401 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	"github.com/your-team/taskmanager-chat/backend/internal/storage/psql"
+	"github.com/your-team/taskmanager-chat/backend/pkg/config"
+	"github.com/your-team/taskmanager-chat/backend/pkg/hash"
+	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
+	"github.com/your-team/taskmanager-chat/backend/pkg/utils"
+)
+
+const invitationTokenLength = 32
+
+var (
+	ErrWorkspaceNotFound   = errors.New("workspace not found")
+	ErrWorkspaceForbidden  = errors.New("your role in this workspace does not allow this")
+	ErrLastWorkspaceOwner  = errors.New("a workspace needs at least one owner")
+	ErrMemberNotFound      = errors.New("member not found")
+	ErrInvitationNotFound  = errors.New("invitation not found")
+	ErrInvitationInvalid   = errors.New("invitation has expired or was already used")
+	ErrInvitationForeignTo = errors.New("invitation was sent to a different email address")
+)
+
+var workspaceRoleRank = map[string]int{
+	domain.WorkspaceRoleGuest:  0,
+	domain.WorkspaceRoleMember: 1,
+	domain.WorkspaceRoleAdmin:  2,
+	domain.WorkspaceRoleOwner:  3,
+}
+
+type WorkspaceStorage interface {
+	CreateWorkspace(userID int64, name string) (domain.Workspace, error)
+	SelectWorkspaces(userID int64) ([]domain.Workspace, error)
+	SelectWorkspace(userID, workspaceID int64) (domain.Workspace, error)
+	RenameWorkspace(userID, workspaceID int64, name string) (bool, error)
+	DeleteWorkspace(userID, workspaceID int64) (bool, error)
+	SelectWorkspaceMembers(viewerID, workspaceID int64) ([]domain.WorkspaceMember, error)
+	SelectWorkspaceRole(workspaceID, userID int64) (string, error)
+	CountWorkspaceOwners(workspaceID int64) (int64, error)
+	UpdateWorkspaceMemberRole(workspaceID, userID int64, role string) (bool, error)
+	RemoveWorkspaceMember(workspaceID, userID int64) (bool, error)
+	InsertWorkspaceInvitation(inv domain.WorkspaceInvitation, tokenHash string) (domain.WorkspaceInvitation, error)
+	SelectWorkspaceInvitations(workspaceID int64) ([]domain.WorkspaceInvitation, error)
+	RevokeWorkspaceInvitation(workspaceID, invitationID int64) (bool, error)
+	SelectWorkspaceInvitationByToken(tokenHash string) (domain.WorkspaceInvitation, error)
+	AcceptWorkspaceInvitation(inv domain.WorkspaceInvitation, userID int64) (bool, error)
+	SelectUserByID(userID int64) (domain.User, error)
+	SelectUserIDByEmail(email string) (int64, error)
+}
+
+type WorkspaceService struct {
+	storage       WorkspaceStorage
+	notifications NotificationCreator
+	audit         AuditRecorder
+	cfg           config.WorkspaceConfig
+	logger        *logging.Logger
+}
+
+func NewWorkspaceService(storage WorkspaceStorage, notifications NotificationCreator, audit AuditRecorder, cfg config.WorkspaceConfig, logger *logging.Logger) *WorkspaceService {
+	return &WorkspaceService{
+		storage:       storage,
+		notifications: notifications,
+		audit:         audit,
+		cfg:           cfg,
+		logger:        logger,
+	}
+}
+
+func (s *WorkspaceService) Create(userID int64, req domain.WorkspaceRequest) (domain.Workspace, error) {
+	name, err := workspaceName(req.Name)
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+	return s.storage.CreateWorkspace(userID, name)
+}
+
+func (s *WorkspaceService) List(userID int64) ([]domain.Workspace, error) {
+	return s.storage.SelectWorkspaces(userID)
+}
+
+func (s *WorkspaceService) Get(userID, workspaceID int64) (domain.Workspace, error) {
+	workspace, err := s.storage.SelectWorkspace(userID, workspaceID)
+	if errors.Is(err, psql.ErrWorkspaceNotFound) {
+		return domain.Workspace{}, ErrWorkspaceNotFound
+	}
+	return workspace, err
+}
+
+func (s *WorkspaceService) Rename(userID, workspaceID int64, req domain.WorkspaceRequest) (domain.Workspace, error) {
+	name, err := workspaceName(req.Name)
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+
+	if _, err := s.requireRole(userID, workspaceID, domain.WorkspaceRoleAdmin); err != nil {
+		return domain.Workspace{}, err
+	}
+
+	if _, err := s.storage.RenameWorkspace(userID, workspaceID, name); err != nil {
+		return domain.Workspace{}, err
+	}
+	return s.Get(userID, workspaceID)
+}
+
+func (s *WorkspaceService) Delete(userID, workspaceID int64) error {
+	if _, err := s.requireRole(userID, workspaceID, domain.WorkspaceRoleOwner); err != nil {
+		return err
+	}
+
+	deleted, err := s.storage.DeleteWorkspace(userID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrWorkspaceNotFound
+	}
+	return nil
+}
+
+func (s *WorkspaceService) Members(userID, workspaceID int64) ([]domain.WorkspaceMember, error) {
+	if _, err := s.requireRole(userID, workspaceID, domain.WorkspaceRoleMember); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectWorkspaceMembers(userID, workspaceID)
+}
+
+// SetMemberRole changes the role of a member. Admins manage members and
+// admins; only owners may grant or take away ownership, and the last owner
+// cannot be demoted.
+func (s *WorkspaceService) SetMemberRole(userID, workspaceID, memberID int64, role string, client domain.ClientInfo) error {
+	if _, ok := workspaceRoleRank[role]; !ok {
+		return errors.New("role must be 'owner', 'admin', 'member' or 'guest'")
+	}
+
+	actorRole, err := s.requireRole(userID, workspaceID, domain.WorkspaceRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.memberRole(workspaceID, memberID)
+	if err != nil {
+		return err
+	}
+	if current == role {
+		return nil
+	}
+
+	if (role == domain.WorkspaceRoleOwner || current == domain.WorkspaceRoleOwner) && actorRole != domain.WorkspaceRoleOwner {
+		return ErrWorkspaceForbidden
+	}
+	if current == domain.WorkspaceRoleOwner {
+		if err := s.ensureAnotherOwner(workspaceID); err != nil {
+			return err
+		}
+	}
+
+	updated, err := s.storage.UpdateWorkspaceMemberRole(workspaceID, memberID, role)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrMemberNotFound
+	}
+
+	_ = s.audit.Record(newAuditEvent(domain.AuditWorkspaceRole, userID, memberID, client, map[string]interface{}{
+		"workspace_id": workspaceID,
+		"from":         current,
+		"to":           role,
+	}))
+	return nil
+}
+
+// RemoveMember removes memberID from the workspace. Every member may leave
+// on their own; removing somebody else needs an admin, and only owners can
+// remove owners.
+func (s *WorkspaceService) RemoveMember(userID, workspaceID, memberID int64, client domain.ClientInfo) error {
+	actorRole, err := s.requireRole(userID, workspaceID, domain.WorkspaceRoleMember)
+	if err != nil {
+		return err
+	}
+
+	current, err := s.memberRole(workspaceID, memberID)
+	if err != nil {
+		return err
+	}
+
+	if memberID != userID {
+		if workspaceRoleRank[actorRole] < workspaceRoleRank[domain.WorkspaceRoleAdmin] {
+			return ErrWorkspaceForbidden
+		}
+		if current == domain.WorkspaceRoleOwner && actorRole != domain.WorkspaceRoleOwner {
+			return ErrWorkspaceForbidden
+		}
+	}
+	if current == domain.WorkspaceRoleOwner {
+		if err := s.ensureAnotherOwner(workspaceID); err != nil {
+			return err
+		}
+	}
+
+	removed, err := s.storage.RemoveWorkspaceMember(workspaceID, memberID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrMemberNotFound
+	}
+
+	_ = s.audit.Record(newAuditEvent(domain.AuditWorkspaceRemoved, userID, memberID, client, map[string]interface{}{
+		"workspace_id": workspaceID,
+		"role":         current,
+	}))
+	return nil
+}
+
+// Invite creates an invitation bound to an email address. The token is only
+// part of the returned link; if the address belongs to an existing account,
+// the user also gets the link as a notification.
+func (s *WorkspaceService) Invite(userID, workspaceID int64, req domain.WorkspaceInvitationRequest) (domain.CreatedWorkspaceInvitation, error) {
+	address, err := mail.ParseAddress(strings.TrimSpace(req.Email))
+	if err != nil {
+		return domain.CreatedWorkspaceInvitation{}, errors.New("a valid email is required")
+	}
+	email := strings.ToLower(address.Address)
+
+	role := req.Role
+	if role == "" {
+		role = domain.WorkspaceRoleMember
+	}
+	if role != domain.WorkspaceRoleMember && role != domain.WorkspaceRoleAdmin {
+		return domain.CreatedWorkspaceInvitation{}, errors.New("role must be 'admin' or 'member'")
+	}
+
+	workspace, err := s.Get(userID, workspaceID)
+	if err != nil {
+		return domain.CreatedWorkspaceInvitation{}, err
+	}
+	if workspaceRoleRank[workspace.Role] < workspaceRoleRank[domain.WorkspaceRoleAdmin] {
+		return domain.CreatedWorkspaceInvitation{}, ErrWorkspaceForbidden
+	}
+
+	token, err := utils.GenerateRandomString(invitationTokenLength)
+	if err != nil {
+		return domain.CreatedWorkspaceInvitation{}, errors.New("failed to generate invitation token")
+	}
+
+	invitation, err := s.storage.InsertWorkspaceInvitation(domain.WorkspaceInvitation{
+		WorkspaceID: workspaceID,
+		Email:       email,
+		Role:        role,
+		InvitedBy:   userID,
+		ExpiresAt:   time.Now().Add(s.cfg.WorkspaceInvitationTTL),
+	}, hash.HashToken(token))
+	if err != nil {
+		return domain.CreatedWorkspaceInvitation{}, err
+	}
+	invitation.WorkspaceName = workspace.Name
+
+	created := domain.CreatedWorkspaceInvitation{
+		WorkspaceInvitation: invitation,
+		Token:               token,
+		URL:                 strings.TrimRight(s.cfg.WorkspaceInvitationURL, "/") + "/" + token,
+	}
+	s.notifyInvitee(created)
+
+	return created, nil
+}
+
+func (s *WorkspaceService) Invitations(userID, workspaceID int64) ([]domain.WorkspaceInvitation, error) {
+	if _, err := s.requireRole(userID, workspaceID, domain.WorkspaceRoleAdmin); err != nil {
+		return nil, err
+	}
+	return s.storage.SelectWorkspaceInvitations(workspaceID)
+}
+
+func (s *WorkspaceService) RevokeInvitation(userID, workspaceID, invitationID int64) error {
+	if _, err := s.requireRole(userID, workspaceID, domain.WorkspaceRoleAdmin); err != nil {
+		return err
+	}
+
+	revoked, err := s.storage.RevokeWorkspaceInvitation(workspaceID, invitationID)
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrInvitationNotFound
+	}
+	return nil
+}
+
+// AcceptInvitation adds the user to the invitation's workspace. The
+// invitation only works for the account with the invited email address.
+func (s *WorkspaceService) AcceptInvitation(userID int64, token string, client domain.ClientInfo) (domain.Workspace, error) {
+	invitation, err := s.storage.SelectWorkspaceInvitationByToken(hash.HashToken(token))
+	if errors.Is(err, psql.ErrInvitationNotFound) {
+		return domain.Workspace{}, ErrInvitationNotFound
+	}
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+
+	if invitation.AcceptedAt != nil || invitation.RevokedAt != nil || time.Now().After(invitation.ExpiresAt) {
+		return domain.Workspace{}, ErrInvitationInvalid
+	}
+
+	user, err := s.storage.SelectUserByID(userID)
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return domain.Workspace{}, ErrInvitationForeignTo
+	}
+
+	accepted, err := s.storage.AcceptWorkspaceInvitation(invitation, userID)
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+	if !accepted {
+		return domain.Workspace{}, ErrInvitationInvalid
+	}
+
+	_ = s.audit.Record(newAuditEvent(domain.AuditWorkspaceJoined, userID, userID, client, map[string]interface{}{
+		"workspace_id":  invitation.WorkspaceID,
+		"invitation_id": invitation.ID,
+		"role":          invitation.Role,
+	}))
+
+	return s.Get(userID, invitation.WorkspaceID)
+}
+
+// requireRole returns the user's role in the workspace. Non-members get
+// ErrWorkspaceNotFound, members below minRole ErrWorkspaceForbidden.
+func (s *WorkspaceService) requireRole(userID, workspaceID int64, minRole string) (string, error) {
+	role, err := s.storage.SelectWorkspaceRole(workspaceID, userID)
+	if errors.Is(err, psql.ErrWorkspaceNotFound) {
+		return "", ErrWorkspaceNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if workspaceRoleRank[role] < workspaceRoleRank[minRole] {
+		return role, ErrWorkspaceForbidden
+	}
+	return role, nil
+}
+
+func (s *WorkspaceService) memberRole(workspaceID, memberID int64) (string, error) {
+	role, err := s.storage.SelectWorkspaceRole(workspaceID, memberID)
+	if errors.Is(err, psql.ErrWorkspaceNotFound) {
+		return "", ErrMemberNotFound
+	}
+	return role, err
+}
+
+func (s *WorkspaceService) ensureAnotherOwner(workspaceID int64) error {
+	owners, err := s.storage.CountWorkspaceOwners(workspaceID)
+	if err != nil {
+		return err
+	}
+	if owners < 2 {
+		return ErrLastWorkspaceOwner
+	}
+	return nil
+}
+
+func (s *WorkspaceService) notifyInvitee(invitation domain.CreatedWorkspaceInvitation) {
+	if s.notifications == nil {
+		return
+	}
+
+	inviteeID, err := s.storage.SelectUserIDByEmail(invitation.Email)
+	if err != nil {
+		return
+	}
+
+	_, err = s.notifications.CreateNotification(domain.Notification{
+		UserID:    inviteeID,
+		Title:     "Workspace invitation",
+		Message:   fmt.Sprintf("You were invited to join %q. Open %s to accept.", invitation.WorkspaceName, invitation.URL),
+		Type:      "workspace_invitation",
+		ExpiresAt: invitation.ExpiresAt,
+	})
+	if err != nil {
+		s.logger.Errorf("Failed to notify invitee of workspace %d: %v", invitation.WorkspaceID, err)
+	}
+}
+
+func workspaceName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > 100 {
+		return "", errors.New("workspace name is required and must be at most 100 characters")
+	}
+	return name, nil
+}
@@ -0,0 +1,26 @@
+// Package blob keeps attachment contents, either on the local filesystem or
+// in an S3-compatible object store. Keys are slash-separated relative paths.
+package blob
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// validKey refuses keys that could leave the store's root.
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
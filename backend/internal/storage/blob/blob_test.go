@@ -0,0 +1,78 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type store interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func TestInvalidKeys(t *testing.T) {
+	s3, fake := newTestS3Store(t, true)
+	stores := map[string]store{
+		"local": NewLocalStore(t.TempDir()),
+		"s3":    s3,
+	}
+	keys := []string{"", "../x", "a/../../x", "./a", "a/./b", "a//b", "a/", "/etc/passwd", `a\..\x`}
+
+	ctx := context.Background()
+	for name, s := range stores {
+		for _, key := range keys {
+			if err := s.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("%s: Put(%q): err = %v, want ErrInvalidKey", name, key, err)
+			}
+			if _, err := s.Get(ctx, key); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("%s: Get(%q): err = %v, want ErrInvalidKey", name, key, err)
+			}
+			if err := s.Delete(ctx, key); !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("%s: Delete(%q): err = %v, want ErrInvalidKey", name, key, err)
+			}
+		}
+	}
+	if len(fake.requests) != 0 {
+		t.Errorf("invalid keys reached the object store: %q", fake.requests)
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	dir := t.TempDir()
+	s := NewLocalStore(dir)
+	ctx := context.Background()
+
+	if err := s.Put(ctx, "boards/1/a.txt", strings.NewReader("hello"), 5, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, "boards", "1"))
+	if err != nil || len(entries) != 1 || entries[0].Name() != "a.txt" {
+		t.Fatalf("files after Put = %v (%v), want only a.txt", entries, err)
+	}
+
+	body, err := s.Get(ctx, "boards/1/a.txt")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, _ := io.ReadAll(body)
+	body.Close()
+	if string(got) != "hello" {
+		t.Errorf("Get = %q, want hello", got)
+	}
+
+	if err := s.Delete(ctx, "boards/1/a.txt"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := s.Get(ctx, "boards/1/a.txt"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get after Delete: err = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, "boards/1/a.txt"); err != nil {
+		t.Errorf("Delete of a missing blob: %v", err)
+	}
+}
@@ -0,0 +1,251 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testAccessKey = "AKIDEXAMPLE"
+	testSecretKey = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	testRegion    = "us-east-1"
+	testBucket    = "attachments"
+)
+
+var authorization = regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=([^/]+)/(\d{8})/([^/]+)/s3/aws4_request, SignedHeaders=([^,]+), Signature=([0-9a-f]{64})$`)
+
+// fakeS3 stands in for MinIO: it keeps objects in memory and refuses
+// requests that are not signed with the test credentials.
+type fakeS3 struct {
+	pathStyle bool
+
+	mu       sync.Mutex
+	objects  map[string][]byte
+	types    map[string]string
+	requests []string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.Host+r.URL.EscapedPath())
+
+	if msg := checkSignature(r); msg != "" {
+		http.Error(w, "<Error><Code>SignatureDoesNotMatch</Code><Message>"+msg+"</Message></Error>", http.StatusForbidden)
+		return
+	}
+
+	bucket, key := "", strings.TrimPrefix(r.URL.Path, "/")
+	if f.pathStyle {
+		bucket, key, _ = strings.Cut(key, "/")
+	} else {
+		bucket, _, _ = strings.Cut(r.Host, ".")
+	}
+	if bucket != testBucket {
+		http.Error(w, "<Error><Code>NoSuchBucket</Code></Error>", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.objects[key] = body
+		f.types[key] = r.Header.Get("Content-Type")
+	case http.MethodGet:
+		body, ok := f.objects[key]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", f.types[key])
+		w.Write(body)
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// checkSignature verifies the Signature Version 4 header the way the
+// server side does, returning what is wrong with it or "".
+func checkSignature(r *http.Request) string {
+	m := authorization.FindStringSubmatch(r.Header.Get("Authorization"))
+	if m == nil {
+		return "malformed authorization header"
+	}
+	accessKey, date, region, signedHeaders, signature := m[1], m[2], m[3], m[4], m[5]
+	amzDate := r.Header.Get("X-Amz-Date")
+	if accessKey != testAccessKey || region != testRegion || !strings.HasPrefix(amzDate, date) {
+		return "wrong credential scope"
+	}
+
+	payloadHash := r.Header.Get("X-Amz-Content-Sha256")
+	want := emptyPayloadHash
+	if r.Method == http.MethodPut {
+		want = "UNSIGNED-PAYLOAD"
+	}
+	if payloadHash != want {
+		return "unexpected payload hash " + payloadHash
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range strings.Split(signedHeaders, ";") {
+		value := r.Header.Get(name)
+		if name == "host" {
+			value = r.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	canonicalRequest := r.Method + "\n" + r.URL.EscapedPath() + "\n" + r.URL.RawQuery + "\n" +
+		canonicalHeaders.String() + "\n" + signedHeaders + "\n" + payloadHash
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+	key := []byte("AWS4" + testSecretKey)
+	for _, part := range []string{date, region, "s3", "aws4_request", stringToSign} {
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(part))
+		key = mac.Sum(nil)
+	}
+	if hex.EncodeToString(key) != signature {
+		return "signature does not match"
+	}
+	return ""
+}
+
+// newTestS3Store starts a fakeS3 and returns a store talking to it. The
+// endpoint host does not resolve; every connection goes to the test server,
+// so virtual-host bucket names work too.
+func newTestS3Store(t *testing.T, pathStyle bool) (*S3Store, *fakeS3) {
+	t.Helper()
+
+	fake := &fakeS3{pathStyle: pathStyle, objects: map[string][]byte{}, types: map[string]string{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	store, err := NewS3Store(S3Config{
+		Endpoint:  "http://s3.test:9000",
+		Region:    testRegion,
+		Bucket:    testBucket,
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+		PathStyle: pathStyle,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.client = &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, srv.Listener.Addr().String())
+		},
+	}}
+	return store, fake
+}
+
+func TestS3Store(t *testing.T) {
+	tests := []struct {
+		name      string
+		pathStyle bool
+		wantPut   string
+	}{
+		{"path style", true, "PUT s3.test:9000/attachments/boards/1/report%20v2.pdf"},
+		{"virtual host", false, "PUT attachments.s3.test:9000/boards/1/report%20v2.pdf"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, fake := newTestS3Store(t, tt.pathStyle)
+			ctx := context.Background()
+			const key, content = "boards/1/report v2.pdf", "%PDF-1.7 hello"
+
+			if err := store.Put(ctx, key, strings.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+				t.Fatalf("Put: %v", err)
+			}
+			if fake.requests[0] != tt.wantPut {
+				t.Errorf("request = %q, want %q", fake.requests[0], tt.wantPut)
+			}
+			if got := fake.types["boards/1/report v2.pdf"]; got != "application/pdf" {
+				t.Errorf("stored content type = %q, want application/pdf", got)
+			}
+
+			body, err := store.Get(ctx, key)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			got, _ := io.ReadAll(body)
+			body.Close()
+			if string(got) != content {
+				t.Errorf("Get = %q, want %q", got, content)
+			}
+
+			if err := store.Delete(ctx, key); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			if _, err := store.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get after Delete: err = %v, want ErrNotFound", err)
+			}
+			if err := store.Delete(ctx, key); err != nil {
+				t.Errorf("Delete of a missing blob: %v", err)
+			}
+		})
+	}
+}
+
+func TestS3StoreSign(t *testing.T) {
+	store, err := NewS3Store(S3Config{
+		Endpoint:  "https://s3.example.com",
+		Region:    testRegion,
+		Bucket:    testBucket,
+		AccessKey: testAccessKey,
+		SecretKey: testSecretKey,
+		PathStyle: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, err := store.request(context.Background(), http.MethodGet, "boards/1/report v2.pdf", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.sign(req, emptyPayloadHash, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240501/us-east-1/s3/aws4_request, " +
+		"SignedHeaders=host;x-amz-content-sha256;x-amz-date, " +
+		"Signature=dc47679fdc0f9c90412b6438ff6f8cad2f774564f0a1848937e4f56700a01e97"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization = %q\nwant %q", got, want)
+	}
+	if got := req.Header.Get("X-Amz-Date"); got != "20240501T120000Z" {
+		t.Errorf("X-Amz-Date = %q", got)
+	}
+}
+
+func TestS3StoreErrors(t *testing.T) {
+	store, _ := newTestS3Store(t, true)
+	store.cfg.SecretKey = "wrong"
+
+	err := store.Put(context.Background(), "a.txt", strings.NewReader("x"), 1, "text/plain")
+	if err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Put with a wrong secret: err = %v, want a 403 error", err)
+	}
+	if _, err := store.Get(context.Background(), "a.txt"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("Get with a wrong secret: err = %v, want a 403 error", err)
+	}
+}
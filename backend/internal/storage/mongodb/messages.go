@@ -3,6 +3,7 @@ package mongodb
 import (
 	"context"
 	"time"
+	"unicode"
 
 	"github.com/your-team/taskmanager-chat/backend/internal/domain"
 	"go.mongodb.org/mongo-driver/bson"
@@ -21,16 +22,92 @@ func NewMessageStorage(client *mongo.Client, dbName string) *MessageStorage {
 	}
 }
 
-func (s *MessageStorage) SaveMessage(ctx context.Context, msg domain.Message) error {
+// messageDocument is a message as stored. SearchLanguage picks the stemmer
+// of the text index for the message; messages saved before it existed are
+// indexed as English.
+type messageDocument struct {
+	domain.Message `bson:",inline"`
+	SearchLanguage string `bson:"search_language"`
+}
+
+// EnsureIndexes creates the text index SearchMessages relies on. Creating it
+// again is a no-op.
+func (s *MessageStorage) EnsureIndexes(ctx context.Context) error {
+	_, err := s.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "content", Value: "text"}},
+		Options: options.Index().
+			SetName("content_text").
+			SetDefaultLanguage("english").
+			SetLanguageOverride("search_language"),
+	})
+	return err
+}
+
+// SaveMessage stores the message and returns it with its id and time.
+func (s *MessageStorage) SaveMessage(ctx context.Context, msg domain.Message) (domain.Message, error) {
 	msg.ID = primitive.NewObjectID().Hex()
 	msg.CreatedAt = time.Now()
-	
-	_, err := s.collection.InsertOne(ctx, msg)
-	return err
+
+	_, err := s.collection.InsertOne(ctx, messageDocument{
+		Message:        msg,
+		SearchLanguage: searchLanguage(msg.Content),
+	})
+	return msg, err
 }
 
-func (s *MessageStorage) GetMessagesByBoardID(ctx context.Context, boardID int64, limit int64) ([]domain.Message, error) {
-	filter := bson.M{"board_id": boardID}
+// SearchMessages runs a text search over the messages of the boards, best
+// matches first. Board ids are unique across workspaces, so they alone scope
+// the search.
+func (s *MessageStorage) SearchMessages(ctx context.Context, boardIDs []int64, query string, limit int64) ([]domain.MessageMatch, error) {
+	if len(boardIDs) == 0 {
+		return nil, nil
+	}
+
+	filter := bson.M{
+		"$text":    bson.M{"$search": query, "$language": searchLanguage(query)},
+		"board_id": bson.M{"$in": boardIDs},
+	}
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score}).
+		SetSort(bson.D{{Key: "score", Value: score}, {Key: "created_at", Value: -1}}).
+		SetLimit(limit)
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		domain.Message `bson:",inline"`
+		Score          float64 `bson:"score"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	matches := make([]domain.MessageMatch, 0, len(docs))
+	for _, doc := range docs {
+		matches = append(matches, domain.MessageMatch{Message: doc.Message, Score: doc.Score})
+	}
+	return matches, nil
+}
+
+// searchLanguage tells Russian text, written in Cyrillic, from English.
+func searchLanguage(text string) string {
+	for _, r := range text {
+		if unicode.Is(unicode.Cyrillic, r) {
+			return "russian"
+		}
+	}
+	return "english"
+}
+
+// GetMessagesByBoardID filters on the workspace as well, so a board id from
+// another tenant never matches.
+func (s *MessageStorage) GetMessagesByBoardID(ctx context.Context, workspaceID, boardID int64, limit int64) ([]domain.Message, error) {
+	filter := bson.M{"workspace_id": workspaceID, "board_id": boardID}
 	opts := options.Find().SetSort(bson.D{bson.E{Key: "created_at", Value: -1}}).SetLimit(limit)
 	
 	cursor, err := s.collection.Find(ctx, filter, opts)
@@ -51,3 +128,30 @@ func (s *MessageStorage) GetMessagesByBoardID(ctx context.Context, boardID int64
 	return messages, nil
 }
 
+func (s *MessageStorage) GetMessagesByUserID(ctx context.Context, userID int64) ([]domain.Message, error) {
+	filter := bson.M{"user_id": userID}
+	opts := options.Find().SetSort(bson.D{bson.E{Key: "created_at", Value: 1}})
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	messages := []domain.Message{}
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// AnonymizeUserMessages replaces the author name on every message of the
+// user. The messages stay in their boards so conversations keep making sense.
+func (s *MessageStorage) AnonymizeUserMessages(ctx context.Context, userID int64, username string) error {
+	filter := bson.M{"user_id": userID}
+	update := bson.M{"$set": bson.M{"username": username}}
+
+	_, err := s.collection.UpdateMany(ctx, filter, update)
+	return err
+}
@@ -0,0 +1,90 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+func (s *Storage) InsertAccessToken(t domain.PersonalAccessToken) (domain.PersonalAccessToken, error) {
+	var expiresAt pgtype.Timestamptz
+	if !t.ExpiresAt.IsZero() {
+		expiresAt = pgtype.Timestamptz{Time: t.ExpiresAt, Valid: true}
+	}
+
+	res, err := s.queries.CreatePersonalAccessToken(context.Background(), database.CreatePersonalAccessTokenParams{
+		UserID:      t.UserID,
+		Name:        t.Name,
+		TokenHash:   t.TokenHash,
+		TokenPrefix: t.Prefix,
+		Scopes:      t.Scopes,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return domain.PersonalAccessToken{}, err
+	}
+
+	return accessTokenFromRow(res), nil
+}
+
+func (s *Storage) SelectAccessTokenByHash(tokenHash string) (domain.PersonalAccessToken, error) {
+	res, err := s.queries.GetPersonalAccessTokenByHash(context.Background(), tokenHash)
+	if err != nil {
+		return domain.PersonalAccessToken{}, err
+	}
+
+	return accessTokenFromRow(res), nil
+}
+
+func (s *Storage) SelectAccessTokens(userID int64) ([]domain.PersonalAccessToken, error) {
+	rows, err := s.queries.ListPersonalAccessTokensByUserID(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]domain.PersonalAccessToken, 0, len(rows))
+	for _, row := range rows {
+		tokens = append(tokens, accessTokenFromRow(row))
+	}
+	return tokens, nil
+}
+
+func (s *Storage) TouchAccessToken(id int64, ip string) error {
+	return s.queries.TouchPersonalAccessToken(context.Background(), database.TouchPersonalAccessTokenParams{
+		ID:         id,
+		LastUsedIp: pgtype.Text{String: ip, Valid: ip != ""},
+	})
+}
+
+func (s *Storage) RevokeAccessToken(id, userID int64) (bool, error) {
+	affected, err := s.queries.RevokePersonalAccessToken(context.Background(), database.RevokePersonalAccessTokenParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (s *Storage) RevokeAllAccessTokens(userID int64) error {
+	return s.queries.RevokeAllPersonalAccessTokens(context.Background(), userID)
+}
+
+func accessTokenFromRow(row database.PersonalAccessToken) domain.PersonalAccessToken {
+	return domain.PersonalAccessToken{
+		ID:         row.ID,
+		UserID:     row.UserID,
+		Name:       row.Name,
+		Prefix:     row.TokenPrefix,
+		TokenHash:  row.TokenHash,
+		Scopes:     row.Scopes,
+		ExpiresAt:  row.ExpiresAt.Time,
+		LastUsedAt: row.LastUsedAt.Time,
+		LastUsedIP: row.LastUsedIp.String,
+		RevokedAt:  row.RevokedAt.Time,
+		CreatedAt:  row.CreatedAt.Time,
+	}
+}
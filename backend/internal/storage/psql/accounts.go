@@ -0,0 +1,128 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+func (s *Storage) ScheduleDeletion(userID int64, at time.Time) error {
+	return s.queries.ScheduleUserDeletion(context.Background(), database.ScheduleUserDeletionParams{
+		ID:                  userID,
+		DeletionScheduledAt: pgtype.Timestamptz{Time: at, Valid: true},
+	})
+}
+
+func (s *Storage) CancelDeletion(userID int64) (bool, error) {
+	affected, err := s.queries.CancelUserDeletion(context.Background(), userID)
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (s *Storage) SelectUsersDueForDeletion(now time.Time) ([]domain.User, error) {
+	rows, err := s.queries.ListUsersDueForDeletion(context.Background(), pgtype.Timestamptz{Time: now, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]domain.User, 0, len(rows))
+	for _, row := range rows {
+		users = append(users, domain.User{ID: row.ID, Email: row.Email})
+	}
+	return users, nil
+}
+
+// RevokeSessions drops every refresh token and personal access token of the
+// user. Access JWTs that are already issued run out on their own.
+func (s *Storage) RevokeSessions(userID int64) error {
+	if err := s.queries.RefreshDeleteByUserID(context.Background(), userID); err != nil {
+		return err
+	}
+	return s.queries.RevokeAllPersonalAccessTokens(context.Background(), userID)
+}
+
+// AnonymizeUser removes the personal data of a user. The users row itself
+// is kept with scrubbed values, so tasks and other rows that point at it stay
+// intact but no longer identify anybody. The row is scrubbed last: if any
+// step fails, the user is still due and the next run starts over.
+func (s *Storage) AnonymizeUser(user domain.User) error {
+	ctx := context.Background()
+
+	if err := s.RevokeSessions(user.ID); err != nil {
+		return err
+	}
+	if err := s.queries.DeleteTwoFaCodesByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.queries.DeleteNotificationsByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+	if err := s.queries.DeleteLoginAttemptsByEmail(ctx, user.Email); err != nil {
+		return err
+	}
+	if err := s.leaveWorkspaces(ctx, user.ID); err != nil {
+		return err
+	}
+
+	return s.queries.AnonymizeUser(ctx, user.ID)
+}
+
+func (s *Storage) SelectTasksByUserID(userID int64) ([]domain.Task, error) {
+	query := `
+		SELECT t.id, t.board_id, t.column_id, t.user_id, t.title, t.description, c.title, t.deadline, t.position, t.created_at, t.updated_at
+		FROM tasks t
+		JOIN board_columns c ON c.id = t.column_id
+		WHERE t.user_id = $1
+		ORDER BY t.created_at
+	`
+	rows, err := s.queries.GetDB().Query(context.Background(), query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := []domain.Task{}
+	for rows.Next() {
+		var t domain.Task
+		if err := rows.Scan(
+			&t.ID,
+			&t.BoardID,
+			&t.ColumnID,
+			&t.UserID,
+			&t.Title,
+			&t.Description,
+			&t.Status,
+			&t.Deadline,
+			&t.Position,
+			&t.CreatedAt,
+			&t.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
+
+// leaveWorkspaces removes the user from every workspace. Workspaces that
+// would be left without an owner get a successor; workspaces nobody else is
+// in are deleted with their boards.
+func (s *Storage) leaveWorkspaces(ctx context.Context, userID int64) error {
+	return s.inTx(ctx, func(q *database.Queries) error {
+		if err := q.PromoteWorkspaceSuccessors(ctx, userID); err != nil {
+			return err
+		}
+		if err := q.DeleteSoleMemberWorkspaces(ctx, userID); err != nil {
+			return err
+		}
+		if err := q.DeleteBoardMembershipsByUserID(ctx, userID); err != nil {
+			return err
+		}
+		return q.DeleteWorkspaceMembershipsByUserID(ctx, userID)
+	})
+}
@@ -0,0 +1,69 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+// InsertTaskActivity appends the entries to their tasks' timelines in one
+// transaction and returns them with their ids and times.
+func (s *Storage) InsertTaskActivity(entries []domain.TaskActivity) ([]domain.TaskActivity, error) {
+	inserted := make([]domain.TaskActivity, 0, len(entries))
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		for _, e := range entries {
+			row, err := q.CreateTaskActivity(context.Background(), database.CreateTaskActivityParams{
+				TaskID:       e.TaskID,
+				BoardID:      e.BoardID,
+				ActorID:      pgtype.Int8{Int64: e.ActorID, Valid: e.ActorID != 0},
+				ActivityType: e.Type,
+				Field:        pgtype.Text{String: e.Field, Valid: e.Field != ""},
+				BeforeValue:  []byte(e.Before),
+				AfterValue:   []byte(e.After),
+			})
+			if err != nil {
+				return err
+			}
+			e.ID = row.ID
+			e.CreatedAt = row.CreatedAt.Time
+			inserted = append(inserted, e)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inserted, nil
+}
+
+// SelectTaskActivity returns up to limit entries of the task's timeline,
+// newest first, older than beforeID unless it is 0.
+func (s *Storage) SelectTaskActivity(taskID, beforeID int64, limit int) ([]domain.TaskActivity, error) {
+	rows, err := s.queries.ListTaskActivity(context.Background(), database.ListTaskActivityParams{
+		TaskID:     taskID,
+		BeforeID:   pgtype.Int8{Int64: beforeID, Valid: beforeID != 0},
+		MaxResults: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]domain.TaskActivity, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, domain.TaskActivity{
+			ID:            row.ID,
+			TaskID:        row.TaskID,
+			BoardID:       row.BoardID,
+			ActorID:       row.ActorID.Int64,
+			ActorUsername: row.ActorUsername.String,
+			Type:          row.ActivityType,
+			Field:         row.Field.String,
+			Before:        row.BeforeValue,
+			After:         row.AfterValue,
+			CreatedAt:     row.CreatedAt.Time,
+		})
+	}
+	return entries, nil
+}
@@ -0,0 +1,144 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+func (s *Storage) SelectUsersForAdmin(query string, limit, offset int) (domain.AdminUserList, error) {
+	var pattern pgtype.Text
+	if query != "" {
+		pattern = pgtype.Text{String: "%" + escapeLike(strings.ToLower(query)) + "%", Valid: true}
+	}
+
+	rows, err := s.queries.ListUsers(context.Background(), database.ListUsersParams{
+		Pattern:    pattern,
+		MaxResults: int32(limit),
+		Skip:       int32(offset),
+	})
+	if err != nil {
+		return domain.AdminUserList{}, err
+	}
+
+	list := domain.AdminUserList{Users: make([]domain.AdminUser, 0, len(rows))}
+	for _, row := range rows {
+		list.Total = row.Total
+		list.Users = append(list.Users, adminUserFromRow(database.GetAdminUserRow{
+			ID:                    row.ID,
+			Username:              row.Username,
+			Firstname:             row.Firstname,
+			Lastname:              row.Lastname,
+			Email:                 row.Email,
+			Role:                  row.Role,
+			TwoFaEnabled:          row.TwoFaEnabled,
+			BlockedUntil:          row.BlockedUntil,
+			PasswordResetRequired: row.PasswordResetRequired,
+			DeletionScheduledAt:   row.DeletionScheduledAt,
+			DeletedAt:             row.DeletedAt,
+			CreatedAt:             row.CreatedAt,
+		}))
+	}
+	return list, nil
+}
+
+func (s *Storage) SelectUserForAdmin(userID int64) (domain.AdminUser, error) {
+	row, err := s.queries.GetAdminUser(context.Background(), userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.AdminUser{}, ErrUserNotFound
+	}
+	if err != nil {
+		return domain.AdminUser{}, err
+	}
+
+	return adminUserFromRow(row), nil
+}
+
+func (s *Storage) IsAdmin(userID int64) (bool, error) {
+	role, err := s.queries.GetUserRole(context.Background(), userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return role == domain.RoleAdmin, nil
+}
+
+func (s *Storage) UpdateRole(userID int64, role string) error {
+	return s.queries.UpdateUserRole(context.Background(), database.UpdateUserRoleParams{
+		ID:   userID,
+		Role: role,
+	})
+}
+
+func (s *Storage) RequirePasswordReset(userID int64) error {
+	return s.queries.RequirePasswordReset(context.Background(), userID)
+}
+
+// ResetPassword only succeeds while a reset is still pending, so a reset
+// token cannot be replayed once the new password is set.
+func (s *Storage) ResetPassword(userID int64, passwordHash string) (bool, error) {
+	affected, err := s.queries.ResetPassword(context.Background(), database.ResetPasswordParams{
+		ID:           userID,
+		PasswordHash: passwordHash,
+	})
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (s *Storage) SelectLoginAttempts(email string, limit int) ([]domain.LoginAttempt, error) {
+	rows, err := s.queries.ListLoginAttemptsByEmail(context.Background(), database.ListLoginAttemptsByEmailParams{
+		Email: email,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := make([]domain.LoginAttempt, 0, len(rows))
+	for _, row := range rows {
+		attempts = append(attempts, domain.LoginAttempt{
+			ID:          row.ID,
+			Email:       row.Email,
+			Success:     row.Success,
+			IP:          row.IpAddress.String,
+			UserAgent:   row.UserAgent.String,
+			Cleared:     row.Cleared,
+			AttemptedAt: row.AttemptedAt.Time,
+		})
+	}
+	return attempts, nil
+}
+
+func adminUserFromRow(row database.GetAdminUserRow) domain.AdminUser {
+	return domain.AdminUser{
+		ID:                    row.ID,
+		Username:              row.Username,
+		Firstname:             row.Firstname,
+		Lastname:              row.Lastname,
+		Email:                 row.Email,
+		Role:                  row.Role,
+		TwoFAEnabled:          row.TwoFaEnabled.Bool,
+		BlockedUntil:          optionalTime(row.BlockedUntil),
+		PasswordResetRequired: row.PasswordResetRequired,
+		DeletionScheduledAt:   optionalTime(row.DeletionScheduledAt),
+		DeletedAt:             optionalTime(row.DeletedAt),
+		CreatedAt:             row.CreatedAt.Time,
+	}
+}
+
+func optionalTime(t pgtype.Timestamptz) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,103 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+// Assignees and watchers are looked up by task id alone; callers check
+// access to the task.
+
+// AddTaskAssignee reports false when the user cannot open the task's board
+// or is already assigned.
+func (s *Storage) AddTaskAssignee(taskID, userID, assignedBy int64) (bool, error) {
+	affected, err := s.queries.AddTaskAssignee(context.Background(), database.AddTaskAssigneeParams{
+		TaskID:     taskID,
+		UserID:     userID,
+		AssignedBy: pgtype.Int8{Int64: assignedBy, Valid: assignedBy != 0},
+	})
+	return affected > 0, err
+}
+
+func (s *Storage) RemoveTaskAssignee(taskID, userID int64) (bool, error) {
+	affected, err := s.queries.RemoveTaskAssignee(context.Background(), database.RemoveTaskAssigneeParams{
+		TaskID: taskID,
+		UserID: userID,
+	})
+	return affected > 0, err
+}
+
+func (s *Storage) SelectTaskAssignees(taskID int64) ([]domain.TaskAssignee, error) {
+	rows, err := s.queries.ListTaskAssignees(context.Background(), taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignees := make([]domain.TaskAssignee, 0, len(rows))
+	for _, row := range rows {
+		assignees = append(assignees, domain.TaskAssignee{
+			UserID:     row.UserID,
+			Username:   row.Username,
+			AssignedBy: optionalInt64(row.AssignedBy),
+			AssignedAt: row.CreatedAt.Time,
+		})
+	}
+	return assignees, nil
+}
+
+// AddTaskWatcher does nothing when the user cannot open the task's board or
+// already watches it.
+func (s *Storage) AddTaskWatcher(taskID, userID int64) error {
+	return s.queries.AddTaskWatcher(context.Background(), database.AddTaskWatcherParams{
+		TaskID: taskID,
+		UserID: userID,
+	})
+}
+
+func (s *Storage) RemoveTaskWatcher(taskID, userID int64) (bool, error) {
+	affected, err := s.queries.RemoveTaskWatcher(context.Background(), database.RemoveTaskWatcherParams{
+		TaskID: taskID,
+		UserID: userID,
+	})
+	return affected > 0, err
+}
+
+func (s *Storage) SelectTaskWatchers(taskID int64) ([]domain.TaskWatcher, error) {
+	rows, err := s.queries.ListTaskWatchers(context.Background(), taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	watchers := make([]domain.TaskWatcher, 0, len(rows))
+	for _, row := range rows {
+		watchers = append(watchers, domain.TaskWatcher{
+			UserID:   row.UserID,
+			Username: row.Username,
+			Since:    row.CreatedAt.Time,
+		})
+	}
+	return watchers, nil
+}
+
+// SelectTaskRecipientIDs returns the assignees and watchers of the task who
+// can still open its board.
+func (s *Storage) SelectTaskRecipientIDs(taskID int64) ([]int64, error) {
+	return s.queries.ListTaskRecipientIDs(context.Background(), taskID)
+}
+
+func optionalInt64(v pgtype.Int8) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int64
+}
+
+func optionalInt8(v *int64) pgtype.Int8 {
+	if v == nil {
+		return pgtype.Int8{}
+	}
+	return pgtype.Int8{Int64: *v, Valid: true}
+}
@@ -0,0 +1,150 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+var ErrAttachmentNotFound = &StorageError{"attachment not found"}
+
+// Attachments are looked up by id alone; callers check access to their
+// board.
+
+// TouchAttachmentBlob reports whether content with the hash is stored
+// already, keeping it from being cleaned up for a while if so.
+func (s *Storage) TouchAttachmentBlob(sha256 string) (bool, error) {
+	affected, err := s.queries.TouchAttachmentBlob(context.Background(), sha256)
+	return affected > 0, err
+}
+
+// InsertAttachment records the attachment together with its blob, whose
+// content has been stored already.
+func (s *Storage) InsertAttachment(a domain.Attachment) (domain.Attachment, error) {
+	var id int64
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		if err := q.UpsertAttachmentBlob(context.Background(), database.UpsertAttachmentBlobParams{
+			Sha256:       a.SHA256,
+			Size:         a.Size,
+			ContentType:  a.ContentType,
+			HasThumbnail: a.HasThumbnail,
+		}); err != nil {
+			return err
+		}
+
+		var err error
+		id, err = q.CreateAttachment(context.Background(), database.CreateAttachmentParams{
+			BoardID:    a.BoardID,
+			TaskID:     optionalInt8(a.TaskID),
+			Sha256:     a.SHA256,
+			Filename:   a.Filename,
+			UploadedBy: optionalInt8(a.UploadedBy),
+		})
+		return err
+	})
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	return s.SelectAttachment(id)
+}
+
+func (s *Storage) SelectAttachment(attachmentID int64) (domain.Attachment, error) {
+	row, err := s.queries.GetAttachment(context.Background(), attachmentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Attachment{}, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return domain.Attachment{}, err
+	}
+	return attachmentFromRow(row), nil
+}
+
+func (s *Storage) SelectTaskAttachments(taskID int64) ([]domain.Attachment, error) {
+	rows, err := s.queries.ListTaskAttachments(context.Background(), pgtype.Int8{Int64: taskID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]domain.Attachment, 0, len(rows))
+	for _, row := range rows {
+		attachments = append(attachments, attachmentFromRow(database.GetAttachmentRow(row)))
+	}
+	return attachments, nil
+}
+
+func (s *Storage) SelectAttachmentsByIDs(attachmentIDs []int64) ([]domain.Attachment, error) {
+	rows, err := s.queries.ListAttachmentsByIDs(context.Background(), attachmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]domain.Attachment, 0, len(rows))
+	for _, row := range rows {
+		attachments = append(attachments, attachmentFromRow(database.GetAttachmentRow(row)))
+	}
+	return attachments, nil
+}
+
+// LinkMessageAttachments ties the uploader's unreferenced uploads on the
+// board to a chat message and returns how many it took.
+func (s *Storage) LinkMessageAttachments(messageID string, boardID, userID int64, attachmentIDs []int64) (int64, error) {
+	return s.queries.LinkMessageAttachments(context.Background(), database.LinkMessageAttachmentsParams{
+		MessageID:  optionalText(&messageID),
+		Ids:        attachmentIDs,
+		BoardID:    boardID,
+		UploadedBy: optionalInt8(&userID),
+	})
+}
+
+// DeleteAttachment removes the attachment; its blob stays until cleanup
+// finds it unused.
+func (s *Storage) DeleteAttachment(attachmentID int64) (bool, error) {
+	affected, err := s.queries.DeleteAttachment(context.Background(), attachmentID)
+	return affected > 0, err
+}
+
+// DeleteUnreferencedAttachments removes uploads created before the cutoff
+// that no task or message refers to.
+func (s *Storage) DeleteUnreferencedAttachments(before time.Time) (int64, error) {
+	return s.queries.DeleteUnreferencedAttachments(context.Background(), pgtype.Timestamptz{Time: before, Valid: true})
+}
+
+// DeleteUnusedAttachmentBlobs forgets blobs no attachment refers to that were
+// last used before the cutoff, returning them so their content can be
+// removed.
+func (s *Storage) DeleteUnusedAttachmentBlobs(before time.Time) ([]domain.AttachmentBlob, error) {
+	rows, err := s.queries.DeleteUnusedAttachmentBlobs(context.Background(), pgtype.Timestamptz{Time: before, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := make([]domain.AttachmentBlob, 0, len(rows))
+	for _, row := range rows {
+		blobs = append(blobs, domain.AttachmentBlob{
+			SHA256:       row.Sha256,
+			HasThumbnail: row.HasThumbnail,
+		})
+	}
+	return blobs, nil
+}
+
+func attachmentFromRow(row database.GetAttachmentRow) domain.Attachment {
+	return domain.Attachment{
+		ID:           row.ID,
+		BoardID:      row.BoardID,
+		TaskID:       optionalInt64(row.TaskID),
+		MessageID:    row.MessageID.String,
+		Filename:     row.Filename,
+		ContentType:  row.ContentType,
+		Size:         row.Size,
+		SHA256:       row.Sha256,
+		UploadedBy:   optionalInt64(row.UploadedBy),
+		CreatedAt:    row.CreatedAt.Time,
+		HasThumbnail: row.HasThumbnail,
+	}
+}
@@ -0,0 +1,60 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+func (s *Storage) InsertAuditEvent(e domain.AuditEvent) error {
+	details := []byte(e.Details)
+	if len(details) == 0 {
+		details = []byte("{}")
+	}
+
+	return s.queries.CreateAuditEvent(context.Background(), database.CreateAuditEventParams{
+		EventType:    e.Type,
+		ActorID:      pgtype.Int8{Int64: e.ActorID, Valid: e.ActorID != 0},
+		TargetUserID: pgtype.Int8{Int64: e.TargetUserID, Valid: e.TargetUserID != 0},
+		IpAddress:    pgtype.Text{String: e.IP, Valid: e.IP != ""},
+		UserAgent:    pgtype.Text{String: e.UserAgent, Valid: e.UserAgent != ""},
+		Details:      details,
+	})
+}
+
+func (s *Storage) SelectAuditEvents(f domain.AuditFilter) ([]domain.AuditEvent, error) {
+	params := database.ListAuditEventsParams{
+		ActorID:      pgtype.Int8{Int64: f.ActorID, Valid: f.ActorID != 0},
+		TargetUserID: pgtype.Int8{Int64: f.TargetUserID, Valid: f.TargetUserID != 0},
+		IpAddress:    pgtype.Text{String: f.IP, Valid: f.IP != ""},
+		Since:        pgtype.Timestamptz{Time: f.Since, Valid: !f.Since.IsZero()},
+		Until:        pgtype.Timestamptz{Time: f.Until, Valid: !f.Until.IsZero()},
+		BeforeID:     pgtype.Int8{Int64: f.BeforeID, Valid: f.BeforeID != 0},
+		MaxResults:   int32(f.Limit),
+	}
+	if len(f.Types) > 0 {
+		params.EventTypes = f.Types
+	}
+
+	rows, err := s.queries.ListAuditEvents(context.Background(), params)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.AuditEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, domain.AuditEvent{
+			ID:           row.ID,
+			Type:         row.EventType,
+			ActorID:      row.ActorID.Int64,
+			TargetUserID: row.TargetUserID.Int64,
+			IP:           row.IpAddress.String,
+			UserAgent:    row.UserAgent.String,
+			Details:      row.Details,
+			CreatedAt:    row.CreatedAt.Time,
+		})
+	}
+	return events, nil
+}
@@ -0,0 +1,232 @@
+package psql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+var ErrAutomationRuleNotFound = &StorageError{"automation rule not found"}
+
+func (s *Storage) InsertAutomationRule(r domain.AutomationRule) (domain.AutomationRule, error) {
+	trigger, conditions, actions, err := marshalRule(&r.Trigger, &r.Conditions, &r.Actions)
+	if err != nil {
+		return domain.AutomationRule{}, err
+	}
+
+	row, err := s.queries.CreateAutomationRule(context.Background(), database.CreateAutomationRuleParams{
+		BoardID:     r.BoardID,
+		CreatedBy:   r.CreatedBy,
+		Name:        r.Name,
+		Enabled:     r.Enabled,
+		TriggerType: r.Trigger.Type,
+		Trigger:     trigger,
+		Conditions:  conditions,
+		Actions:     actions,
+	})
+	if err != nil {
+		return domain.AutomationRule{}, err
+	}
+	return ruleFromRow(row)
+}
+
+func (s *Storage) SelectAutomationRules(boardID int64) ([]domain.AutomationRule, error) {
+	rows, err := s.queries.ListAutomationRules(context.Background(), boardID)
+	if err != nil {
+		return nil, err
+	}
+	return rulesFromRows(rows)
+}
+
+// SelectEnabledAutomationRules returns the board's enabled rules with the
+// trigger.
+func (s *Storage) SelectEnabledAutomationRules(boardID int64, triggerType string) ([]domain.AutomationRule, error) {
+	rows, err := s.queries.ListEnabledAutomationRules(context.Background(), database.ListEnabledAutomationRulesParams{
+		BoardID:     boardID,
+		TriggerType: triggerType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rulesFromRows(rows)
+}
+
+func (s *Storage) SelectAutomationRule(ruleID int64) (domain.AutomationRule, error) {
+	row, err := s.queries.GetAutomationRule(context.Background(), ruleID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.AutomationRule{}, ErrAutomationRuleNotFound
+	}
+	if err != nil {
+		return domain.AutomationRule{}, err
+	}
+	return ruleFromRow(row)
+}
+
+func (s *Storage) UpdateAutomationRule(ruleID int64, req domain.AutomationRuleRequest) (bool, error) {
+	trigger, conditions, actions, err := marshalRule(req.Trigger, req.Conditions, req.Actions)
+	if err != nil {
+		return false, err
+	}
+
+	params := database.UpdateAutomationRuleParams{
+		Name:       optionalText(req.Name),
+		Trigger:    trigger,
+		Conditions: conditions,
+		Actions:    actions,
+		ID:         ruleID,
+	}
+	if req.Enabled != nil {
+		params.Enabled = pgtype.Bool{Bool: *req.Enabled, Valid: true}
+	}
+	if req.Trigger != nil {
+		params.TriggerType = pgtype.Text{String: req.Trigger.Type, Valid: true}
+	}
+
+	affected, err := s.queries.UpdateAutomationRule(context.Background(), params)
+	return affected > 0, err
+}
+
+func (s *Storage) DeleteAutomationRule(ruleID int64) (bool, error) {
+	affected, err := s.queries.DeleteAutomationRule(context.Background(), ruleID)
+	return affected > 0, err
+}
+
+func (s *Storage) InsertAutomationExecution(e domain.AutomationExecution) error {
+	results, err := json.Marshal(e.Results)
+	if err != nil {
+		return err
+	}
+
+	return s.queries.CreateAutomationExecution(context.Background(), database.CreateAutomationExecutionParams{
+		RuleID:      e.RuleID,
+		BoardID:     e.BoardID,
+		TaskID:      pgtype.Int8{Int64: e.TaskID, Valid: e.TaskID != 0},
+		TriggerType: e.TriggerType,
+		Status:      e.Status,
+		Depth:       int32(e.Depth),
+		Results:     results,
+		Error:       e.Error,
+	})
+}
+
+// SelectAutomationExecutions returns up to limit entries of the rule's log
+// older than beforeID, or the newest ones if it is 0.
+func (s *Storage) SelectAutomationExecutions(ruleID, beforeID int64, limit int) ([]domain.AutomationExecution, error) {
+	rows, err := s.queries.ListAutomationExecutions(context.Background(), database.ListAutomationExecutionsParams{
+		RuleID:     ruleID,
+		BeforeID:   beforeID,
+		MaxResults: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	executions := make([]domain.AutomationExecution, 0, len(rows))
+	for _, row := range rows {
+		execution := domain.AutomationExecution{
+			ID:          row.ID,
+			RuleID:      row.RuleID,
+			BoardID:     row.BoardID,
+			TaskID:      row.TaskID.Int64,
+			TriggerType: row.TriggerType,
+			Status:      row.Status,
+			Depth:       int(row.Depth),
+			Error:       row.Error,
+			CreatedAt:   row.CreatedAt.Time,
+		}
+		if err := json.Unmarshal(row.Results, &execution.Results); err != nil {
+			return nil, err
+		}
+		executions = append(executions, execution)
+	}
+	return executions, nil
+}
+
+// SelectDueDeadlines returns up to limit overdue tasks, each with a deadline
+// rule that has not handled it since its deadline.
+func (s *Storage) SelectDueDeadlines(limit int) ([]domain.AutomationDeadline, error) {
+	rows, err := s.queries.ListDueDeadlineRules(context.Background(), int32(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	deadlines := make([]domain.AutomationDeadline, 0, len(rows))
+	for _, row := range rows {
+		deadlines = append(deadlines, domain.AutomationDeadline{
+			RuleID:  row.RuleID,
+			TaskID:  row.TaskID,
+			BoardID: row.BoardID,
+		})
+	}
+	return deadlines, nil
+}
+
+// AddTaskLabel puts a label of the task's board on the task. Adding it twice
+// is not an error.
+func (s *Storage) AddTaskLabel(taskID, labelID int64) error {
+	return s.queries.AddTaskLabels(context.Background(), database.AddTaskLabelsParams{
+		TaskID:   taskID,
+		LabelIds: []int64{labelID},
+	})
+}
+
+// marshalRule encodes the parts of a rule that are given, leaving the others
+// nil.
+func marshalRule(trigger *domain.AutomationTrigger, conditions *domain.AutomationConditions, actions *[]domain.AutomationAction) (t, c, a []byte, err error) {
+	if trigger != nil {
+		if t, err = json.Marshal(trigger); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if conditions != nil {
+		if c, err = json.Marshal(conditions); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if actions != nil {
+		if a, err = json.Marshal(actions); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	return t, c, a, nil
+}
+
+func rulesFromRows(rows []database.AutomationRule) ([]domain.AutomationRule, error) {
+	rules := make([]domain.AutomationRule, 0, len(rows))
+	for _, row := range rows {
+		rule, err := ruleFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func ruleFromRow(row database.AutomationRule) (domain.AutomationRule, error) {
+	rule := domain.AutomationRule{
+		ID:        row.ID,
+		BoardID:   row.BoardID,
+		CreatedBy: row.CreatedBy,
+		Name:      row.Name,
+		Enabled:   row.Enabled,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if err := json.Unmarshal(row.Trigger, &rule.Trigger); err != nil {
+		return domain.AutomationRule{}, err
+	}
+	if err := json.Unmarshal(row.Conditions, &rule.Conditions); err != nil {
+		return domain.AutomationRule{}, err
+	}
+	if err := json.Unmarshal(row.Actions, &rule.Actions); err != nil {
+		return domain.AutomationRule{}, err
+	}
+	rule.Trigger.Type = row.TriggerType
+	return rule, nil
+}
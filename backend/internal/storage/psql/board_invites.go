@@ -0,0 +1,139 @@
+package psql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+var ErrBoardInviteNotFound = &StorageError{"board invite not found"}
+
+// errInviteUsedUp rolls back a redemption whose invite ran out of uses,
+// expired or was revoked between the read and the accept.
+var errInviteUsedUp = errors.New("board invite used up")
+
+func (s *Storage) InsertBoardInvite(inv domain.BoardInvite, tokenHash string) (domain.BoardInvite, error) {
+	row, err := s.queries.CreateBoardInvite(context.Background(), database.CreateBoardInviteParams{
+		BoardID:   inv.BoardID,
+		Email:     pgtype.Text{String: inv.Email, Valid: inv.Email != ""},
+		Role:      inv.Role,
+		TokenHash: tokenHash,
+		MaxUses:   pgtype.Int4{Int32: int32(inv.MaxUses), Valid: inv.MaxUses > 0},
+		ExpiresAt: pgtype.Timestamptz{Time: inv.ExpiresAt, Valid: true},
+		CreatedBy: pgtype.Int8{Int64: inv.CreatedBy, Valid: true},
+	})
+	if err != nil {
+		return domain.BoardInvite{}, err
+	}
+	return boardInviteFromRow(row), nil
+}
+
+// SelectBoardInvites lists the invites of a board that can still be used.
+func (s *Storage) SelectBoardInvites(boardID int64) ([]domain.BoardInvite, error) {
+	rows, err := s.queries.ListBoardInvites(context.Background(), boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	invites := make([]domain.BoardInvite, 0, len(rows))
+	for _, row := range rows {
+		invites = append(invites, boardInviteFromRow(row))
+	}
+	return invites, nil
+}
+
+func (s *Storage) RevokeBoardInvite(boardID, inviteID int64) (bool, error) {
+	affected, err := s.queries.RevokeBoardInvite(context.Background(), database.RevokeBoardInviteParams{
+		ID:      inviteID,
+		BoardID: boardID,
+	})
+	return affected > 0, err
+}
+
+func (s *Storage) SelectBoardInviteByToken(tokenHash string) (domain.BoardInvite, error) {
+	row, err := s.queries.GetBoardInviteByTokenHash(context.Background(), tokenHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.BoardInvite{}, ErrBoardInviteNotFound
+	}
+	if err != nil {
+		return domain.BoardInvite{}, err
+	}
+
+	return domain.BoardInvite{
+		ID:          row.ID,
+		BoardID:     row.BoardID,
+		BoardName:   row.BoardName,
+		WorkspaceID: row.WorkspaceID,
+		Email:       row.Email.String,
+		Role:        row.Role,
+		MaxUses:     int(row.MaxUses.Int32),
+		UseCount:    int(row.UseCount),
+		ExpiresAt:   row.ExpiresAt.Time,
+		CreatedBy:   row.CreatedBy.Int64,
+		RevokedAt:   optionalTime(row.RevokedAt),
+		CreatedAt:   row.CreatedAt.Time,
+	}, nil
+}
+
+// AcceptBoardInvite gives the user the invite's role on the board and makes
+// them a guest of its workspace unless they already belong to it. A use is
+// only counted the first time a user redeems an invite. It reports false
+// when the invite can no longer be used.
+func (s *Storage) AcceptBoardInvite(inv domain.BoardInvite, userID int64) (bool, error) {
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		redeemed, err := q.CreateBoardInviteRedemption(context.Background(), database.CreateBoardInviteRedemptionParams{
+			InviteID: inv.ID,
+			UserID:   userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if redeemed > 0 {
+			affected, err := q.RedeemBoardInvite(context.Background(), inv.ID)
+			if err != nil {
+				return err
+			}
+			if affected == 0 {
+				return errInviteUsedUp
+			}
+		}
+
+		if _, err := q.AddWorkspaceMember(context.Background(), database.AddWorkspaceMemberParams{
+			WorkspaceID: inv.WorkspaceID,
+			UserID:      userID,
+			Role:        domain.WorkspaceRoleGuest,
+		}); err != nil {
+			return err
+		}
+
+		return q.AddBoardMember(context.Background(), database.AddBoardMemberParams{
+			BoardID: inv.BoardID,
+			UserID:  userID,
+			Role:    inv.Role,
+		})
+	})
+	if errors.Is(err, errInviteUsedUp) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func boardInviteFromRow(row database.BoardInvite) domain.BoardInvite {
+	return domain.BoardInvite{
+		ID:        row.ID,
+		BoardID:   row.BoardID,
+		Email:     row.Email.String,
+		Role:      row.Role,
+		MaxUses:   int(row.MaxUses.Int32),
+		UseCount:  int(row.UseCount),
+		ExpiresAt: row.ExpiresAt.Time,
+		CreatedBy: row.CreatedBy.Int64,
+		RevokedAt: optionalTime(row.RevokedAt),
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,148 @@
+package psql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+var ErrBoardNotFound = &StorageError{"board not found"}
+
+// InsertBoard returns ErrWorkspaceNotFound unless b.CreatedBy is a non-guest
+// member of b.WorkspaceID. The creator becomes the board's first admin.
+func (s *Storage) InsertBoard(b domain.Board) (domain.Board, error) {
+	var board domain.Board
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		var err error
+		board, err = createBoard(q, b)
+		return err
+	})
+	return board, err
+}
+
+// createBoard adds the board with its creator as admin.
+func createBoard(q *database.Queries, b domain.Board) (domain.Board, error) {
+	row, err := q.CreateBoard(context.Background(), database.CreateBoardParams{
+		WorkspaceID: b.WorkspaceID,
+		Name:        b.Name,
+		Description: b.Description,
+		CreatedBy:   b.CreatedBy,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Board{}, ErrWorkspaceNotFound
+	}
+	if err != nil {
+		return domain.Board{}, err
+	}
+
+	if err := q.AddBoardMember(context.Background(), database.AddBoardMemberParams{
+		BoardID: row.ID,
+		UserID:  b.CreatedBy,
+		Role:    domain.BoardRoleAdmin,
+	}); err != nil {
+		return domain.Board{}, err
+	}
+
+	return boardFromRow(database.GetBoardForMemberRow{
+		ID:          row.ID,
+		WorkspaceID: row.WorkspaceID,
+		Name:        row.Name,
+		Description: row.Description,
+		CreatedBy:   row.CreatedBy,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+		Revision:    row.Revision,
+		Version:     row.Version,
+		BoardRole:   domain.BoardRoleAdmin,
+	}), nil
+}
+
+func (s *Storage) SelectBoards(userID, workspaceID int64) ([]domain.Board, error) {
+	rows, err := s.queries.ListBoardsForMember(context.Background(), database.ListBoardsForMemberParams{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	boards := make([]domain.Board, 0, len(rows))
+	for _, row := range rows {
+		boards = append(boards, boardFromRow(database.GetBoardForMemberRow(row)))
+	}
+	return boards, nil
+}
+
+// SelectBoard returns ErrBoardNotFound unless the user is a member of the
+// board's workspace.
+func (s *Storage) SelectBoard(userID, boardID int64) (domain.Board, error) {
+	row, err := s.queries.GetBoardForMember(context.Background(), database.GetBoardForMemberParams{
+		ID:     boardID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Board{}, ErrBoardNotFound
+	}
+	if err != nil {
+		return domain.Board{}, err
+	}
+	return boardFromRow(row), nil
+}
+
+// UpdateBoard only writes when the board is still at version; version 0
+// skips the check.
+func (s *Storage) UpdateBoard(userID, boardID, version int64, req domain.BoardRequest) (bool, error) {
+	affected, err := s.queries.UpdateBoard(context.Background(), database.UpdateBoardParams{
+		Name:        optionalText(req.Name),
+		Description: optionalText(req.Description),
+		ID:          boardID,
+		UserID:      userID,
+		Version:     expectedVersion(version),
+	})
+	return affected > 0, err
+}
+
+func (s *Storage) DeleteBoard(userID, boardID int64) (bool, error) {
+	affected, err := s.queries.DeleteBoard(context.Background(), database.DeleteBoardParams{
+		ID:     boardID,
+		UserID: userID,
+	})
+	return affected > 0, err
+}
+
+// SelectBoardAdminIDs returns the users allowed to manage the board.
+func (s *Storage) SelectBoardAdminIDs(boardID int64) ([]int64, error) {
+	return s.queries.ListBoardAdminIDs(context.Background(), boardID)
+}
+
+// NextBoardRevision bumps the board's revision and returns it together with
+// the board's workspace.
+func (s *Storage) NextBoardRevision(boardID int64) (int64, int64, error) {
+	row, err := s.queries.BumpBoardRevision(context.Background(), boardID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, 0, ErrBoardNotFound
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return row.WorkspaceID, row.Revision, nil
+}
+
+func boardFromRow(row database.GetBoardForMemberRow) domain.Board {
+	return domain.Board{
+		ID:            row.ID,
+		WorkspaceID:   row.WorkspaceID,
+		Name:          row.Name,
+		Description:   row.Description,
+		CreatedBy:     row.CreatedBy.Int64,
+		WorkspaceRole: row.Role,
+		Role:          row.BoardRole,
+		Revision:      row.Revision,
+		Version:       row.Version,
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+}
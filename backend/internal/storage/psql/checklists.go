@@ -0,0 +1,135 @@
+package psql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+	"github.com/your-team/taskmanager-chat/backend/pkg/lexorank"
+)
+
+var ErrChecklistItemNotFound = &StorageError{"checklist item not found"}
+
+// Checklist items are looked up by id alone; callers check access to their
+// task.
+
+func (s *Storage) InsertChecklistItem(item domain.ChecklistItem) (domain.ChecklistItem, error) {
+	row, err := s.queries.CreateChecklistItem(context.Background(), database.CreateChecklistItemParams{
+		TaskID:     item.TaskID,
+		Text:       item.Text,
+		AssigneeID: optionalInt8(item.AssigneeID),
+		Position:   item.Position,
+	})
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	return checklistItemFromRow(row), nil
+}
+
+func (s *Storage) SelectChecklistItems(taskID int64) ([]domain.ChecklistItem, error) {
+	rows, err := s.queries.ListChecklistItems(context.Background(), taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.ChecklistItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, checklistItemFromRow(row))
+	}
+	return items, nil
+}
+
+func (s *Storage) SelectChecklistItem(itemID int64) (domain.ChecklistItem, error) {
+	row, err := s.queries.GetChecklistItem(context.Background(), itemID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ChecklistItem{}, ErrChecklistItemNotFound
+	}
+	if err != nil {
+		return domain.ChecklistItem{}, err
+	}
+	return checklistItemFromRow(row), nil
+}
+
+func (s *Storage) UpdateChecklistItem(itemID int64, req domain.ChecklistItemRequest) (bool, error) {
+	var done pgtype.Bool
+	if req.Done != nil {
+		done = pgtype.Bool{Bool: *req.Done, Valid: true}
+	}
+
+	affected, err := s.queries.UpdateChecklistItem(context.Background(), database.UpdateChecklistItemParams{
+		Text:          optionalText(req.Text),
+		Done:          done,
+		ClearAssignee: req.ClearAssignee,
+		AssigneeID:    optionalInt8(req.AssigneeID),
+		ID:            itemID,
+	})
+	return affected > 0, err
+}
+
+func (s *Storage) DeleteChecklistItem(itemID int64) (bool, error) {
+	affected, err := s.queries.DeleteChecklistItem(context.Background(), itemID)
+	return affected > 0, err
+}
+
+// SelectLastChecklistPosition returns the largest position in the task's
+// checklist, ignoring excludeID, or "" when it is empty.
+func (s *Storage) SelectLastChecklistPosition(taskID, excludeID int64) (string, error) {
+	return s.queries.GetLastChecklistPosition(context.Background(), database.GetLastChecklistPositionParams{
+		TaskID: taskID,
+		ID:     excludeID,
+	})
+}
+
+// SelectNextChecklistPosition returns the smallest position above position,
+// ignoring excludeID, or "".
+func (s *Storage) SelectNextChecklistPosition(taskID int64, position string, excludeID int64) (string, error) {
+	return s.queries.GetNextChecklistPosition(context.Background(), database.GetNextChecklistPositionParams{
+		TaskID:    taskID,
+		Position:  position,
+		ExcludeID: excludeID,
+	})
+}
+
+func (s *Storage) MoveChecklistItem(itemID int64, position string) error {
+	return s.queries.SetChecklistItemPosition(context.Background(), database.SetChecklistItemPositionParams{
+		ID:       itemID,
+		Position: position,
+	})
+}
+
+// RebalanceChecklist spreads the positions of the task's checklist evenly,
+// keeping the order.
+func (s *Storage) RebalanceChecklist(taskID int64) error {
+	return s.inTx(context.Background(), func(q *database.Queries) error {
+		ids, err := q.ListChecklistItemIDs(context.Background(), taskID)
+		if err != nil {
+			return err
+		}
+
+		for i, position := range lexorank.Spread(len(ids)) {
+			if err := q.SetChecklistItemPosition(context.Background(), database.SetChecklistItemPositionParams{
+				ID:       ids[i],
+				Position: position,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func checklistItemFromRow(row database.TaskChecklistItem) domain.ChecklistItem {
+	return domain.ChecklistItem{
+		ID:         row.ID,
+		TaskID:     row.TaskID,
+		Text:       row.Text,
+		Done:       row.Done,
+		AssigneeID: optionalInt64(row.AssigneeID),
+		Position:   row.Position,
+		CreatedAt:  row.CreatedAt.Time,
+		UpdatedAt:  row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,191 @@
+package psql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+var ErrCommentNotFound = &StorageError{"comment not found"}
+
+// InsertComment returns ErrTaskNotFound unless c.UserID can open the task's
+// board. The author starts watching the task.
+func (s *Storage) InsertComment(c domain.Comment) (domain.Comment, error) {
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		row, err := q.CreateComment(context.Background(), database.CreateCommentParams{
+			UserID: c.UserID,
+			Body:   c.Body,
+			TaskID: c.TaskID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrTaskNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		c.ID = row.ID
+		c.CreatedAt = row.CreatedAt.Time
+		c.UpdatedAt = row.UpdatedAt.Time
+		return q.AddTaskWatcher(context.Background(), database.AddTaskWatcherParams{
+			TaskID: c.TaskID,
+			UserID: c.UserID,
+		})
+	})
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return c, nil
+}
+
+// SelectComments returns the live comments of the task, oldest first.
+func (s *Storage) SelectComments(userID, taskID int64) ([]domain.Comment, error) {
+	rows, err := s.queries.ListTaskComments(context.Background(), database.ListTaskCommentsParams{
+		TaskID: taskID,
+		UserID: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]domain.Comment, 0, len(rows))
+	for _, row := range rows {
+		comments = append(comments, commentFromListRow(row))
+	}
+	return comments, nil
+}
+
+// SelectCommentsByUserID returns the user's live comments for the account
+// export.
+func (s *Storage) SelectCommentsByUserID(userID int64) ([]domain.Comment, error) {
+	rows, err := s.queries.ListCommentsByUserID(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]domain.Comment, 0, len(rows))
+	for _, row := range rows {
+		comments = append(comments, commentFromListRow(database.ListTaskCommentsRow(row)))
+	}
+	return comments, nil
+}
+
+// SelectComment also returns deleted comments; DeletedAt tells them apart.
+func (s *Storage) SelectComment(userID, commentID int64) (domain.Comment, error) {
+	row, err := s.queries.GetCommentForMember(context.Background(), database.GetCommentForMemberParams{
+		ID:     commentID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Comment{}, ErrCommentNotFound
+	}
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return commentFromRow(row), nil
+}
+
+// UpdateComment stores the old body as a revision and replaces it. It
+// reports false when the comment is gone or deleted.
+func (s *Storage) UpdateComment(commentID, editorID int64, body string) (bool, error) {
+	updated := false
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		kept, err := q.CreateCommentRevision(context.Background(), database.CreateCommentRevisionParams{
+			EditedBy:  editorID,
+			CommentID: commentID,
+		})
+		if err != nil || kept == 0 {
+			return err
+		}
+
+		affected, err := q.UpdateCommentBody(context.Background(), database.UpdateCommentBodyParams{
+			ID:   commentID,
+			Body: body,
+		})
+		updated = affected > 0
+		return err
+	})
+	return updated, err
+}
+
+// DeleteComment stores the last body as a revision and marks the comment
+// deleted.
+func (s *Storage) DeleteComment(commentID, editorID int64) (bool, error) {
+	deleted := false
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		kept, err := q.CreateCommentRevision(context.Background(), database.CreateCommentRevisionParams{
+			EditedBy:  editorID,
+			CommentID: commentID,
+		})
+		if err != nil || kept == 0 {
+			return err
+		}
+
+		affected, err := q.MarkCommentDeleted(context.Background(), commentID)
+		deleted = affected > 0
+		return err
+	})
+	return deleted, err
+}
+
+// SelectCommentRevisions returns the earlier bodies of a comment, oldest
+// first. Access to the comment must be checked by the caller.
+func (s *Storage) SelectCommentRevisions(commentID int64) ([]domain.CommentRevision, error) {
+	rows, err := s.queries.ListCommentRevisions(context.Background(), commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]domain.CommentRevision, 0, len(rows))
+	for _, row := range rows {
+		revisions = append(revisions, domain.CommentRevision{
+			ID:               row.ID,
+			CommentID:        row.CommentID,
+			Body:             row.Body,
+			EditedBy:         row.EditedBy.Int64,
+			EditedByUsername: row.EditedByUsername.String,
+			EditedAt:         row.EditedAt.Time,
+		})
+	}
+	return revisions, nil
+}
+
+// SelectBoardMemberIDsByUsername resolves lower-cased usernames, ignoring
+// users who cannot open the board.
+func (s *Storage) SelectBoardMemberIDsByUsername(boardID int64, usernames []string) ([]int64, error) {
+	return s.queries.ListBoardMemberIDsByUsername(context.Background(), database.ListBoardMemberIDsByUsernameParams{
+		BoardID:   boardID,
+		Usernames: usernames,
+	})
+}
+
+func commentFromListRow(row database.ListTaskCommentsRow) domain.Comment {
+	return commentFromRow(database.GetCommentForMemberRow{
+		ID:        row.ID,
+		TaskID:    row.TaskID,
+		BoardID:   row.BoardID,
+		UserID:    row.UserID,
+		Username:  row.Username,
+		Body:      row.Body,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	})
+}
+
+func commentFromRow(row database.GetCommentForMemberRow) domain.Comment {
+	return domain.Comment{
+		ID:        row.ID,
+		TaskID:    row.TaskID,
+		BoardID:   row.BoardID,
+		UserID:    row.UserID,
+		Username:  row.Username,
+		Body:      row.Body,
+		Edited:    row.UpdatedAt.Time.After(row.CreatedAt.Time),
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+		DeletedAt: optionalTime(row.DeletedAt),
+	}
+}
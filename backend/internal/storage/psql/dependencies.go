@@ -0,0 +1,79 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+var ErrDependencyCycle = &StorageError{"the dependency would create a cycle"}
+
+// AddTaskDependency records that blockerID blocks blockedID, both on boardID.
+// Dependencies of a board are added one at a time, so two concurrent
+// additions cannot close a cycle together. It reports false when the
+// dependency already exists.
+func (s *Storage) AddTaskDependency(boardID, blockerID, blockedID, createdBy int64) (bool, error) {
+	added := false
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		if err := q.LockBoard(context.Background(), boardID); err != nil {
+			return err
+		}
+
+		cycle, err := q.TaskDependencyPathExists(context.Background(), database.TaskDependencyPathExistsParams{
+			FromID: blockedID,
+			ToID:   blockerID,
+		})
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return ErrDependencyCycle
+		}
+
+		affected, err := q.AddTaskDependency(context.Background(), database.AddTaskDependencyParams{
+			BlockerID: blockerID,
+			BlockedID: blockedID,
+			CreatedBy: optionalInt8(&createdBy),
+		})
+		added = affected > 0
+		return err
+	})
+	return added, err
+}
+
+func (s *Storage) RemoveTaskDependency(blockerID, blockedID int64) (bool, error) {
+	affected, err := s.queries.RemoveTaskDependency(context.Background(), database.RemoveTaskDependencyParams{
+		BlockerID: blockerID,
+		BlockedID: blockedID,
+	})
+	return affected > 0, err
+}
+
+// SelectTaskBlockers returns the tasks blocking the task.
+func (s *Storage) SelectTaskBlockers(taskID int64) ([]domain.TaskRef, error) {
+	rows, err := s.queries.ListTaskBlockers(context.Background(), taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]domain.TaskRef, 0, len(rows))
+	for _, row := range rows {
+		refs = append(refs, domain.TaskRef(row))
+	}
+	return refs, nil
+}
+
+// SelectBlockedTasks returns the tasks the task blocks.
+func (s *Storage) SelectBlockedTasks(taskID int64) ([]domain.TaskRef, error) {
+	rows, err := s.queries.ListBlockedTasks(context.Background(), taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]domain.TaskRef, 0, len(rows))
+	for _, row := range rows {
+		refs = append(refs, domain.TaskRef(row))
+	}
+	return refs, nil
+}
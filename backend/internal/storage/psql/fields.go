@@ -0,0 +1,256 @@
+package psql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+var (
+	ErrLabelNotFound = &StorageError{"label not found"}
+	ErrLabelExists   = &StorageError{"a label with this name already exists on the board"}
+	ErrFieldNotFound = &StorageError{"custom field not found"}
+	ErrFieldExists   = &StorageError{"a custom field with this name already exists on the board"}
+)
+
+// Labels and custom fields are looked up by id alone; callers check access
+// to their board.
+
+func (s *Storage) InsertLabel(l domain.Label) (domain.Label, error) {
+	row, err := s.queries.CreateLabel(context.Background(), database.CreateLabelParams{
+		BoardID: l.BoardID,
+		Name:    l.Name,
+		Color:   l.Color,
+	})
+	if isUniqueViolation(err) {
+		return domain.Label{}, ErrLabelExists
+	}
+	if err != nil {
+		return domain.Label{}, err
+	}
+	return labelFromRow(row), nil
+}
+
+func (s *Storage) SelectLabels(boardID int64) ([]domain.Label, error) {
+	rows, err := s.queries.ListBoardLabels(context.Background(), boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make([]domain.Label, 0, len(rows))
+	for _, row := range rows {
+		labels = append(labels, labelFromRow(row))
+	}
+	return labels, nil
+}
+
+func (s *Storage) SelectLabel(labelID int64) (domain.Label, error) {
+	row, err := s.queries.GetLabel(context.Background(), labelID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Label{}, ErrLabelNotFound
+	}
+	if err != nil {
+		return domain.Label{}, err
+	}
+	return labelFromRow(row), nil
+}
+
+func (s *Storage) UpdateLabel(labelID int64, req domain.LabelRequest) (bool, error) {
+	affected, err := s.queries.UpdateLabel(context.Background(), database.UpdateLabelParams{
+		Name:  optionalText(req.Name),
+		Color: optionalText(req.Color),
+		ID:    labelID,
+	})
+	if isUniqueViolation(err) {
+		return false, ErrLabelExists
+	}
+	return affected > 0, err
+}
+
+func (s *Storage) DeleteLabel(labelID int64) (bool, error) {
+	affected, err := s.queries.DeleteLabel(context.Background(), labelID)
+	return affected > 0, err
+}
+
+func (s *Storage) InsertCustomField(f domain.CustomField) (domain.CustomField, error) {
+	row, err := s.queries.CreateCustomField(context.Background(), database.CreateCustomFieldParams{
+		BoardID: f.BoardID,
+		Name:    f.Name,
+		Type:    f.Type,
+		Options: f.Options,
+	})
+	if isUniqueViolation(err) {
+		return domain.CustomField{}, ErrFieldExists
+	}
+	if err != nil {
+		return domain.CustomField{}, err
+	}
+	return customFieldFromRow(row), nil
+}
+
+func (s *Storage) SelectCustomFields(boardID int64) ([]domain.CustomField, error) {
+	rows, err := s.queries.ListBoardCustomFields(context.Background(), boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]domain.CustomField, 0, len(rows))
+	for _, row := range rows {
+		fields = append(fields, customFieldFromRow(row))
+	}
+	return fields, nil
+}
+
+func (s *Storage) SelectCustomField(fieldID int64) (domain.CustomField, error) {
+	row, err := s.queries.GetCustomField(context.Background(), fieldID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.CustomField{}, ErrFieldNotFound
+	}
+	if err != nil {
+		return domain.CustomField{}, err
+	}
+	return customFieldFromRow(row), nil
+}
+
+// UpdateCustomField renames the field and replaces its options. Values that
+// chose a removed option lose it, and are dropped when none is left.
+func (s *Storage) UpdateCustomField(fieldID int64, req domain.CustomFieldRequest) (bool, error) {
+	updated := false
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		var options []string
+		if req.Options != nil {
+			options = *req.Options
+		}
+
+		affected, err := q.UpdateCustomField(context.Background(), database.UpdateCustomFieldParams{
+			Name:    optionalText(req.Name),
+			Options: options,
+			ID:      fieldID,
+		})
+		if err != nil || affected == 0 {
+			return err
+		}
+		updated = true
+
+		if req.Options == nil {
+			return nil
+		}
+		if err := q.PruneFieldOptions(context.Background(), database.PruneFieldOptionsParams{
+			Options: options,
+			FieldID: fieldID,
+		}); err != nil {
+			return err
+		}
+		return q.DeleteEmptyFieldValues(context.Background(), fieldID)
+	})
+	if isUniqueViolation(err) {
+		return false, ErrFieldExists
+	}
+	return updated, err
+}
+
+func (s *Storage) DeleteCustomField(fieldID int64) (bool, error) {
+	affected, err := s.queries.DeleteCustomField(context.Background(), fieldID)
+	return affected > 0, err
+}
+
+// IsBoardMember reports whether the user can open the board.
+func (s *Storage) IsBoardMember(boardID, userID int64) (bool, error) {
+	return s.queries.IsBoardMember(context.Background(), database.IsBoardMemberParams{
+		BoardID: boardID,
+		UserID:  userID,
+	})
+}
+
+// setTaskFields writes validated values; a value without any member set is
+// removed.
+func setTaskFields(ctx context.Context, q *database.Queries, taskID int64, values []domain.FieldValue) error {
+	for _, v := range values {
+		if v.Text == nil && v.Number == nil && v.Date == nil && v.UserID == nil && len(v.Options) == 0 {
+			if err := q.DeleteTaskFieldValue(ctx, database.DeleteTaskFieldValueParams{
+				TaskID:  taskID,
+				FieldID: v.FieldID,
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		params := database.UpsertTaskFieldValueParams{
+			TaskID:       taskID,
+			FieldID:      v.FieldID,
+			ValueText:    optionalText(v.Text),
+			ValueOptions: v.Options,
+		}
+		if v.Number != nil {
+			params.ValueNumber = pgtype.Float8{Float64: *v.Number, Valid: true}
+		}
+		if v.Date != nil {
+			params.ValueDate = pgtype.Date{Time: *v.Date, Valid: true}
+		}
+		if v.UserID != nil {
+			params.ValueUserID = pgtype.Int8{Int64: *v.UserID, Valid: true}
+		}
+		if err := q.UpsertTaskFieldValue(ctx, params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldValue turns a stored value into its JSON form for the field's type.
+func fieldValue(row database.ListTaskFieldValuesRow) interface{} {
+	switch row.Type {
+	case domain.FieldTypeNumber:
+		return row.ValueNumber.Float64
+	case domain.FieldTypeDate:
+		return row.ValueDate.Time.Format("2006-01-02")
+	case domain.FieldTypeUser:
+		return row.ValueUserID.Int64
+	case domain.FieldTypeSelect:
+		if len(row.ValueOptions) == 0 {
+			return nil
+		}
+		return row.ValueOptions[0]
+	case domain.FieldTypeMultiSelect:
+		return row.ValueOptions
+	default:
+		return row.ValueText.String
+	}
+}
+
+func labelFromRow(row database.BoardLabel) domain.Label {
+	return domain.Label{
+		ID:        row.ID,
+		BoardID:   row.BoardID,
+		Name:      row.Name,
+		Color:     row.Color,
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
+
+func customFieldFromRow(row database.BoardCustomField) domain.CustomField {
+	options := row.Options
+	if options == nil {
+		options = []string{}
+	}
+	return domain.CustomField{
+		ID:        row.ID,
+		BoardID:   row.BoardID,
+		Name:      row.Name,
+		Type:      row.Type,
+		Options:   options,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
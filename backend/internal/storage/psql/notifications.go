@@ -2,6 +2,7 @@ package psql
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
@@ -9,6 +10,10 @@ import (
 	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
 )
 
+// ErrNotificationExists is returned for a second notification of the same
+// type about the same task to the same user.
+var ErrNotificationExists = &StorageError{"notification already sent"}
+
 func (s *Storage) CreateNotification(n domain.Notification) (domain.Notification, error) {
 	var taskID pgtype.Int8
 	if n.TaskID != 0 {
@@ -24,6 +29,14 @@ func (s *Storage) CreateNotification(n domain.Notification) (domain.Notification
 		expiresAt = pgtype.Timestamptz{Valid: false}
 	}
 
+	var activity []byte
+	if n.Activity != nil {
+		var err error
+		if activity, err = json.Marshal(n.Activity); err != nil {
+			return domain.Notification{}, err
+		}
+	}
+
 	res, err := s.queries.CreateNotification(context.Background(), database.CreateNotificationParams{
 		UserID:    n.UserID,
 		TaskID:    taskID,
@@ -31,7 +44,11 @@ func (s *Storage) CreateNotification(n domain.Notification) (domain.Notification
 		Message:   n.Message,
 		Type:      n.Type,
 		ExpiresAt: expiresAt,
+		Activity:  activity,
 	})
+	if isUniqueViolation(err) {
+		return domain.Notification{}, ErrNotificationExists
+	}
 	if err != nil {
 		return domain.Notification{}, err
 	}
@@ -46,6 +63,7 @@ func (s *Storage) CreateNotification(n domain.Notification) (domain.Notification
 		Type:      res.Type,
 		CreatedAt: res.CreatedAt.Time,
 		ExpiresAt: res.ExpiresAt.Time,
+		Activity:  n.Activity,
 	}, nil
 }
 
@@ -67,11 +85,25 @@ func (s *Storage) GetNotifications(userID int64) ([]domain.Notification, error)
 			Type:      row.Type,
 			CreatedAt: row.CreatedAt.Time,
 			ExpiresAt: row.ExpiresAt.Time,
+			Activity:  notificationActivity(row.Activity),
 		})
 	}
 	return notifications, nil
 }
 
+// notificationActivity decodes the activity copied into a notification. A
+// payload that no longer decodes is dropped rather than failing the list.
+func notificationActivity(raw []byte) *domain.TaskActivity {
+	if len(raw) == 0 {
+		return nil
+	}
+	var activity domain.TaskActivity
+	if err := json.Unmarshal(raw, &activity); err != nil {
+		return nil
+	}
+	return &activity
+}
+
 func (s *Storage) MarkAsRead(id, userID int64) error {
 	return s.queries.MarkNotificationAsRead(context.Background(), database.MarkNotificationAsReadParams{
 		ID:     id,
@@ -85,11 +117,14 @@ func (s *Storage) GetUnreadCount(userID int64) (int64, error) {
 	})
 }
 
+// GetTasksWithUpcomingDeadlines leaves out completed tasks, which need no
+// reminder.
 func (s *Storage) GetTasksWithUpcomingDeadlines(window time.Duration) ([]domain.Task, error) {
 	query := `
-		SELECT id, user_id, title, description, status, deadline, created_at, updated_at
-		FROM tasks
-		WHERE deadline BETWEEN NOW() AND NOW() + $1
+		SELECT t.id, t.board_id, t.column_id, t.user_id, t.title, t.description, c.title, t.deadline, t.position, t.created_at, t.updated_at
+		FROM tasks t
+		JOIN board_columns c ON c.id = t.column_id
+		WHERE t.deadline BETWEEN NOW() AND NOW() + $1 AND c.kind <> 'done'
 	`
 	rows, err := s.queries.GetDB().Query(context.Background(), query, window)
 	if err != nil {
@@ -102,11 +137,14 @@ func (s *Storage) GetTasksWithUpcomingDeadlines(window time.Duration) ([]domain.
 		var t domain.Task
 		if err := rows.Scan(
 			&t.ID,
+			&t.BoardID,
+			&t.ColumnID,
 			&t.UserID,
 			&t.Title,
 			&t.Description,
 			&t.Status,
 			&t.Deadline,
+			&t.Position,
 			&t.CreatedAt,
 			&t.UpdatedAt,
 		); err != nil {
@@ -0,0 +1,120 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+var (
+	ErrUserNotFound  = &StorageError{"user not found"}
+	ErrUsernameTaken = &StorageError{"username already taken"}
+)
+
+func (s *Storage) SelectProfile(userID int64) (domain.UserProfile, error) {
+	row, err := s.queries.GetUserProfile(context.Background(), userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.UserProfile{}, ErrUserNotFound
+	}
+	if err != nil {
+		return domain.UserProfile{}, err
+	}
+
+	return profileFromRow(row), nil
+}
+
+func (s *Storage) UpdateProfile(userID int64, update domain.ProfileUpdate) (domain.UserProfile, error) {
+	row, err := s.queries.UpdateUserProfile(context.Background(), database.UpdateUserProfileParams{
+		ID:        userID,
+		Username:  optionalText(update.Username),
+		Firstname: optionalText(update.Firstname),
+		Lastname:  optionalText(update.Lastname),
+		TimeZone:  optionalText(update.TimeZone),
+		Locale:    optionalText(update.Locale),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.UserProfile{}, ErrUserNotFound
+	}
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.UserProfile{}, ErrUsernameTaken
+		}
+		return domain.UserProfile{}, err
+	}
+
+	return profileFromRow(database.GetUserProfileRow(row)), nil
+}
+
+func (s *Storage) UpdateAvatar(userID int64, avatarURL string) error {
+	rows, err := s.queries.UpdateUserAvatar(context.Background(), database.UpdateUserAvatarParams{
+		ID:         userID,
+		AvatarPath: pgtype.Text{String: avatarURL, Valid: avatarURL != ""},
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// SearchUsers only matches members of the workspace and returns nothing
+// unless viewerID is a member too.
+func (s *Storage) SearchUsers(viewerID, workspaceID int64, query string, limit int) ([]domain.PublicProfile, error) {
+	rows, err := s.queries.SearchUsers(context.Background(), database.SearchUsersParams{
+		WorkspaceID: workspaceID,
+		ViewerID:    viewerID,
+		Pattern:     escapeLike(strings.ToLower(query)) + "%",
+		MaxResults:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]domain.PublicProfile, 0, len(rows))
+	for _, row := range rows {
+		profiles = append(profiles, domain.PublicProfile{
+			ID:        row.ID,
+			Username:  row.Username,
+			Firstname: row.Firstname,
+			Lastname:  row.Lastname,
+			AvatarURL: row.AvatarPath.String,
+		})
+	}
+	return profiles, nil
+}
+
+func profileFromRow(row database.GetUserProfileRow) domain.UserProfile {
+	return domain.UserProfile{
+		ID:                  row.ID,
+		Username:            row.Username,
+		Firstname:           row.Firstname,
+		Lastname:            row.Lastname,
+		Email:               row.Email,
+		TimeZone:            row.TimeZone,
+		Locale:              row.Locale,
+		AvatarURL:           row.AvatarPath.String,
+		DeletionScheduledAt: optionalTime(row.DeletionScheduledAt),
+		CreatedAt:           row.CreatedAt.Time,
+	}
+}
+
+func optionalText(v *string) pgtype.Text {
+	if v == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *v, Valid: true}
+}
+
+// escapeLike makes user input safe to use as a LIKE prefix.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,172 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+var (
+	ErrRecurrenceNotFound = &StorageError{"task recurrence not found"}
+	ErrRecurrenceAdvanced = &StorageError{"task recurrence has moved on already"}
+)
+
+// UpsertTaskRecurrence sets the recurrence of r.TaskID, replacing any it
+// had, with r.Start as the first occurrence.
+func (s *Storage) UpsertTaskRecurrence(r domain.TaskRecurrence) (domain.TaskRecurrence, error) {
+	row, err := s.queries.UpsertTaskRecurrence(context.Background(), database.UpsertTaskRecurrenceParams{
+		BoardID:       r.BoardID,
+		CreatedBy:     r.CreatedBy,
+		CurrentTaskID: r.TaskID,
+		ColumnID:      optionalInt8(r.ColumnID),
+		Rrule:         r.RRule,
+		TimeZone:      r.TimeZone,
+		Mode:          r.Mode,
+		Dtstart:       timestamptz(&r.Start),
+	})
+	if err != nil {
+		return domain.TaskRecurrence{}, err
+	}
+	return recurrenceFromRow(row), nil
+}
+
+// SelectTaskRecurrence returns the recurrence whose current instance is
+// taskID.
+func (s *Storage) SelectTaskRecurrence(taskID int64) (domain.TaskRecurrence, error) {
+	row, err := s.queries.GetTaskRecurrence(context.Background(), taskID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.TaskRecurrence{}, ErrRecurrenceNotFound
+	}
+	if err != nil {
+		return domain.TaskRecurrence{}, err
+	}
+	return recurrenceFromRow(row), nil
+}
+
+func (s *Storage) DeleteTaskRecurrence(recurrenceID int64) (bool, error) {
+	affected, err := s.queries.DeleteTaskRecurrence(context.Background(), recurrenceID)
+	return affected > 0, err
+}
+
+// SelectDueRecurrences returns up to limit recurrences that need a new
+// instance, the longest overdue first.
+func (s *Storage) SelectDueRecurrences(limit int) ([]domain.TaskRecurrence, error) {
+	rows, err := s.queries.ListDueRecurrences(context.Background(), int32(limit))
+	if err != nil {
+		return nil, err
+	}
+	recurrences := make([]domain.TaskRecurrence, len(rows))
+	for i, row := range rows {
+		recurrences[i] = recurrenceFromRow(row)
+	}
+	return recurrences, nil
+}
+
+// PostponeTaskRecurrence counts a failed attempt at the next instance of the
+// recurrence and returns when it is due to be tried again.
+func (s *Storage) PostponeTaskRecurrence(recurrenceID int64) (time.Time, error) {
+	next, err := s.queries.PostponeTaskRecurrence(context.Background(), recurrenceID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, ErrRecurrenceNotFound
+	}
+	return next.Time, err
+}
+
+// InsertRecurrenceInstance creates t as the next instance of r, copying the
+// labels, assignees, field values and checklist of the current instance, and
+// moves r on to it. It returns ErrRecurrenceAdvanced when r has moved on
+// since it was read.
+func (s *Storage) InsertRecurrenceInstance(r domain.TaskRecurrence, t domain.Task) (domain.Task, error) {
+	var task domain.Task
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		row, err := q.CreateTask(context.Background(), database.CreateTaskParams{
+			UserID:      t.UserID,
+			Title:       t.Title,
+			Description: t.Description,
+			Deadline:    timestamptz(t.Deadline),
+			Position:    t.Position,
+			Priority:    t.Priority,
+			ParentID:    optionalInt8(t.ParentID),
+			ColumnID:    t.ColumnID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrColumnNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := q.AddTaskWatcher(context.Background(), database.AddTaskWatcherParams{
+			TaskID: row.ID,
+			UserID: t.UserID,
+		}); err != nil {
+			return err
+		}
+		if len(t.LabelIDs) > 0 {
+			if err := q.AddTaskLabels(context.Background(), database.AddTaskLabelsParams{
+				TaskID:   row.ID,
+				LabelIds: t.LabelIDs,
+			}); err != nil {
+				return err
+			}
+		}
+		if err := q.CopyTaskAssignees(context.Background(), database.CopyTaskAssigneesParams{
+			TaskID:   row.ID,
+			SourceID: r.TaskID,
+		}); err != nil {
+			return err
+		}
+		if err := q.CopyTaskFieldValues(context.Background(), database.CopyTaskFieldValuesParams{
+			TaskID:   row.ID,
+			SourceID: r.TaskID,
+		}); err != nil {
+			return err
+		}
+		if err := q.CopyTaskChecklist(context.Background(), database.CopyTaskChecklistParams{
+			TaskID:   row.ID,
+			SourceID: r.TaskID,
+		}); err != nil {
+			return err
+		}
+
+		advanced, err := q.AdvanceTaskRecurrence(context.Background(), database.AdvanceTaskRecurrenceParams{
+			TaskID:         row.ID,
+			Occurrence:     timestamptz(t.Deadline),
+			ID:             r.ID,
+			PreviousTaskID: r.TaskID,
+		})
+		if err != nil {
+			return err
+		}
+		if advanced == 0 {
+			return ErrRecurrenceAdvanced
+		}
+
+		task = domain.Task{ID: row.ID, BoardID: row.BoardID}
+		return nil
+	})
+	return task, err
+}
+
+func recurrenceFromRow(row database.TaskRecurrence) domain.TaskRecurrence {
+	return domain.TaskRecurrence{
+		ID:            row.ID,
+		BoardID:       row.BoardID,
+		TaskID:        row.CurrentTaskID,
+		CreatedBy:     row.CreatedBy,
+		ColumnID:      optionalInt64(row.ColumnID),
+		RRule:         row.Rrule,
+		TimeZone:      row.TimeZone,
+		Mode:          row.Mode,
+		Start:         row.Dtstart.Time,
+		Occurrence:    row.Occurrence.Time,
+		Failures:      int(row.Failures),
+		NextAttemptAt: optionalTime(row.NextAttemptAt),
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+	}
+}
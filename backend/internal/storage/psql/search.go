@@ -0,0 +1,69 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+// Search snippets come back as the database cut them: the text is not
+// escaped and the matches are wrapped in the StartSel and StopSel of
+// headlineOptions.
+
+func (s *Storage) SelectAccessibleBoardIDs(userID int64) ([]int64, error) {
+	return s.queries.ListAccessibleBoardIDs(context.Background(), userID)
+}
+
+func (s *Storage) SearchTasks(query, headlineOptions string, boardIDs []int64, limit int) ([]domain.SearchResult, error) {
+	rows, err := s.queries.SearchTasks(context.Background(), database.SearchTasksParams{
+		Query:           query,
+		HeadlineOptions: headlineOptions,
+		BoardIds:        ids(boardIDs),
+		MaxResults:      int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.SearchResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, domain.SearchResult{
+			Type:      domain.SearchResultTask,
+			BoardID:   row.BoardID,
+			TaskID:    row.ID,
+			Title:     row.Title,
+			Snippet:   row.Snippet,
+			Score:     row.Rank,
+			CreatedAt: row.CreatedAt.Time,
+		})
+	}
+	return results, nil
+}
+
+func (s *Storage) SearchComments(query, headlineOptions string, boardIDs []int64, limit int) ([]domain.SearchResult, error) {
+	rows, err := s.queries.SearchComments(context.Background(), database.SearchCommentsParams{
+		Query:           query,
+		HeadlineOptions: headlineOptions,
+		BoardIds:        ids(boardIDs),
+		MaxResults:      int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]domain.SearchResult, 0, len(rows))
+	for _, row := range rows {
+		results = append(results, domain.SearchResult{
+			Type:      domain.SearchResultComment,
+			BoardID:   row.BoardID,
+			TaskID:    row.TaskID,
+			CommentID: row.ID,
+			Title:     row.Title,
+			Snippet:   row.Snippet,
+			Score:     row.Rank,
+			CreatedAt: row.CreatedAt.Time,
+		})
+	}
+	return results, nil
+}
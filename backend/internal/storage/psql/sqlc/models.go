@@ -8,6 +8,164 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Attachment struct {
+	ID         int64              `json:"id"`
+	BoardID    int64              `json:"board_id"`
+	TaskID     pgtype.Int8        `json:"task_id"`
+	MessageID  pgtype.Text        `json:"message_id"`
+	Sha256     string             `json:"sha256"`
+	Filename   string             `json:"filename"`
+	UploadedBy pgtype.Int8        `json:"uploaded_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type AttachmentBlob struct {
+	Sha256       string             `json:"sha256"`
+	Size         int64              `json:"size"`
+	ContentType  string             `json:"content_type"`
+	HasThumbnail bool               `json:"has_thumbnail"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	LastUsedAt   pgtype.Timestamptz `json:"last_used_at"`
+}
+
+type AuditEvent struct {
+	ID           int64              `json:"id"`
+	EventType    string             `json:"event_type"`
+	ActorID      pgtype.Int8        `json:"actor_id"`
+	TargetUserID pgtype.Int8        `json:"target_user_id"`
+	IpAddress    pgtype.Text        `json:"ip_address"`
+	UserAgent    pgtype.Text        `json:"user_agent"`
+	Details      []byte             `json:"details"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type AutomationExecution struct {
+	ID          int64              `json:"id"`
+	RuleID      int64              `json:"rule_id"`
+	BoardID     int64              `json:"board_id"`
+	TaskID      pgtype.Int8        `json:"task_id"`
+	TriggerType string             `json:"trigger_type"`
+	Status      string             `json:"status"`
+	Depth       int32              `json:"depth"`
+	Results     []byte             `json:"results"`
+	Error       string             `json:"error"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type AutomationRule struct {
+	ID          int64              `json:"id"`
+	BoardID     int64              `json:"board_id"`
+	CreatedBy   int64              `json:"created_by"`
+	Name        string             `json:"name"`
+	Enabled     bool               `json:"enabled"`
+	TriggerType string             `json:"trigger_type"`
+	Trigger     []byte             `json:"trigger"`
+	Conditions  []byte             `json:"conditions"`
+	Actions     []byte             `json:"actions"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type Board struct {
+	ID          int64              `json:"id"`
+	WorkspaceID int64              `json:"workspace_id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	CreatedBy   pgtype.Int8        `json:"created_by"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Revision    int64              `json:"revision"`
+	Version     int64              `json:"version"`
+}
+
+type BoardAccess struct {
+	BoardID       int64  `json:"board_id"`
+	WorkspaceID   int64  `json:"workspace_id"`
+	UserID        int64  `json:"user_id"`
+	WorkspaceRole string `json:"workspace_role"`
+	BoardRole     string `json:"board_role"`
+}
+
+type BoardColumn struct {
+	ID        int64              `json:"id"`
+	BoardID   int64              `json:"board_id"`
+	Title     string             `json:"title"`
+	Position  string             `json:"position"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	Kind      string             `json:"kind"`
+	WipLimit  pgtype.Int4        `json:"wip_limit"`
+	WipPolicy string             `json:"wip_policy"`
+	IsDone    bool               `json:"is_done"`
+}
+
+type BoardCustomField struct {
+	ID        int64              `json:"id"`
+	BoardID   int64              `json:"board_id"`
+	Name      string             `json:"name"`
+	Type      string             `json:"type"`
+	Options   []string           `json:"options"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type BoardInvite struct {
+	ID        int64              `json:"id"`
+	BoardID   int64              `json:"board_id"`
+	Email     pgtype.Text        `json:"email"`
+	Role      string             `json:"role"`
+	TokenHash string             `json:"token_hash"`
+	MaxUses   pgtype.Int4        `json:"max_uses"`
+	UseCount  int32              `json:"use_count"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	CreatedBy pgtype.Int8        `json:"created_by"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type BoardInviteRedemption struct {
+	InviteID   int64              `json:"invite_id"`
+	UserID     int64              `json:"user_id"`
+	RedeemedAt pgtype.Timestamptz `json:"redeemed_at"`
+}
+
+type BoardLabel struct {
+	ID        int64              `json:"id"`
+	BoardID   int64              `json:"board_id"`
+	Name      string             `json:"name"`
+	Color     string             `json:"color"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type BoardMember struct {
+	BoardID  int64              `json:"board_id"`
+	UserID   int64              `json:"user_id"`
+	Role     string             `json:"role"`
+	JoinedAt pgtype.Timestamptz `json:"joined_at"`
+}
+
+type BoardTemplate struct {
+	ID          int64              `json:"id"`
+	WorkspaceID int64              `json:"workspace_id"`
+	CreatedBy   pgtype.Int8        `json:"created_by"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Content     []byte             `json:"content"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type BoardView struct {
+	ID        int64              `json:"id"`
+	BoardID   int64              `json:"board_id"`
+	UserID    int64              `json:"user_id"`
+	Name      string             `json:"name"`
+	Shared    bool               `json:"shared"`
+	Query     []byte             `json:"query"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
 type LoginAttempt struct {
 	ID          int64              `json:"id"`
 	Email       string             `json:"email"`
@@ -15,6 +173,21 @@ type LoginAttempt struct {
 	AttemptedAt pgtype.Timestamptz `json:"attempted_at"`
 	IpAddress   pgtype.Text        `json:"ip_address"`
 	UserAgent   pgtype.Text        `json:"user_agent"`
+	Cleared     bool               `json:"cleared"`
+}
+
+type PersonalAccessToken struct {
+	ID          int64              `json:"id"`
+	UserID      int64              `json:"user_id"`
+	Name        string             `json:"name"`
+	TokenHash   string             `json:"token_hash"`
+	TokenPrefix string             `json:"token_prefix"`
+	Scopes      []string           `json:"scopes"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt  pgtype.Timestamptz `json:"last_used_at"`
+	LastUsedIp  pgtype.Text        `json:"last_used_ip"`
+	RevokedAt   pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 }
 
 type RefreshToken struct {
@@ -25,6 +198,130 @@ type RefreshToken struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+type Task struct {
+	ID          int64              `json:"id"`
+	BoardID     int64              `json:"board_id"`
+	ColumnID    int64              `json:"column_id"`
+	UserID      int64              `json:"user_id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Deadline    pgtype.Timestamptz `json:"deadline"`
+	Position    string             `json:"position"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Version     int64              `json:"version"`
+	Priority    string             `json:"priority"`
+	ParentID    pgtype.Int8        `json:"parent_id"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+}
+
+type TaskActivity struct {
+	ID           int64              `json:"id"`
+	TaskID       int64              `json:"task_id"`
+	BoardID      int64              `json:"board_id"`
+	ActorID      pgtype.Int8        `json:"actor_id"`
+	ActivityType string             `json:"activity_type"`
+	Field        pgtype.Text        `json:"field"`
+	BeforeValue  []byte             `json:"before_value"`
+	AfterValue   []byte             `json:"after_value"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type TaskAssignee struct {
+	TaskID     int64              `json:"task_id"`
+	UserID     int64              `json:"user_id"`
+	AssignedBy pgtype.Int8        `json:"assigned_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type TaskChecklistItem struct {
+	ID         int64              `json:"id"`
+	TaskID     int64              `json:"task_id"`
+	Text       string             `json:"text"`
+	Done       bool               `json:"done"`
+	AssigneeID pgtype.Int8        `json:"assignee_id"`
+	Position   string             `json:"position"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type TaskComment struct {
+	ID        int64              `json:"id"`
+	TaskID    int64              `json:"task_id"`
+	UserID    int64              `json:"user_id"`
+	Body      string             `json:"body"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type TaskCommentRevision struct {
+	ID        int64              `json:"id"`
+	CommentID int64              `json:"comment_id"`
+	Body      string             `json:"body"`
+	EditedBy  pgtype.Int8        `json:"edited_by"`
+	EditedAt  pgtype.Timestamptz `json:"edited_at"`
+}
+
+type TaskDependency struct {
+	BlockerID int64              `json:"blocker_id"`
+	BlockedID int64              `json:"blocked_id"`
+	CreatedBy pgtype.Int8        `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type TaskFieldValue struct {
+	TaskID       int64         `json:"task_id"`
+	FieldID      int64         `json:"field_id"`
+	ValueText    pgtype.Text   `json:"value_text"`
+	ValueNumber  pgtype.Float8 `json:"value_number"`
+	ValueDate    pgtype.Date   `json:"value_date"`
+	ValueUserID  pgtype.Int8   `json:"value_user_id"`
+	ValueOptions []string      `json:"value_options"`
+}
+
+type TaskLabel struct {
+	TaskID  int64 `json:"task_id"`
+	LabelID int64 `json:"label_id"`
+}
+
+type TaskRecurrence struct {
+	ID            int64              `json:"id"`
+	BoardID       int64              `json:"board_id"`
+	CreatedBy     int64              `json:"created_by"`
+	CurrentTaskID int64              `json:"current_task_id"`
+	ColumnID      pgtype.Int8        `json:"column_id"`
+	Rrule         string             `json:"rrule"`
+	TimeZone      string             `json:"time_zone"`
+	Mode          string             `json:"mode"`
+	Dtstart       pgtype.Timestamptz `json:"dtstart"`
+	Occurrence    pgtype.Timestamptz `json:"occurrence"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	Failures      int32              `json:"failures"`
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+}
+
+type TaskTemplate struct {
+	ID          int64              `json:"id"`
+	BoardID     int64              `json:"board_id"`
+	CreatedBy   pgtype.Int8        `json:"created_by"`
+	Name        string             `json:"name"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Priority    string             `json:"priority"`
+	LabelIds    []int64            `json:"label_ids"`
+	Checklist   []string           `json:"checklist"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type TaskWatcher struct {
+	TaskID    int64              `json:"task_id"`
+	UserID    int64              `json:"user_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
 type TwoFaCode struct {
 	ID        int64              `json:"id"`
 	UserID    int64              `json:"user_id"`
@@ -36,16 +333,52 @@ type TwoFaCode struct {
 }
 
 type User struct {
-	ID                int64              `json:"id"`
-	Username          string             `json:"username"`
-	Firstname         string             `json:"firstname"`
-	Lastname          string             `json:"lastname"`
-	Email             string             `json:"email"`
-	PasswordHash      string             `json:"password_hash"`
-	TwoFaEnabled      pgtype.Bool        `json:"two_fa_enabled"`
-	CreatedAt         pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
-	BlockedUntil      pgtype.Timestamptz `json:"blocked_until"`
-	FailedAttempts    pgtype.Int4        `json:"failed_attempts"`
-	LastFailedAttempt pgtype.Timestamptz `json:"last_failed_attempt"`
+	ID                    int64              `json:"id"`
+	Username              string             `json:"username"`
+	Firstname             string             `json:"firstname"`
+	Lastname              string             `json:"lastname"`
+	Email                 string             `json:"email"`
+	PasswordHash          string             `json:"password_hash"`
+	TwoFaEnabled          pgtype.Bool        `json:"two_fa_enabled"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt             pgtype.Timestamptz `json:"updated_at"`
+	BlockedUntil          pgtype.Timestamptz `json:"blocked_until"`
+	FailedAttempts        pgtype.Int4        `json:"failed_attempts"`
+	LastFailedAttempt     pgtype.Timestamptz `json:"last_failed_attempt"`
+	TimeZone              string             `json:"time_zone"`
+	Locale                string             `json:"locale"`
+	AvatarPath            pgtype.Text        `json:"avatar_path"`
+	DeletionScheduledAt   pgtype.Timestamptz `json:"deletion_scheduled_at"`
+	DeletedAt             pgtype.Timestamptz `json:"deleted_at"`
+	Role                  string             `json:"role"`
+	PasswordResetRequired bool               `json:"password_reset_required"`
+}
+
+type Workspace struct {
+	ID        int64              `json:"id"`
+	Name      string             `json:"name"`
+	CreatedBy pgtype.Int8        `json:"created_by"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type WorkspaceInvitation struct {
+	ID          int64              `json:"id"`
+	WorkspaceID int64              `json:"workspace_id"`
+	Email       string             `json:"email"`
+	Role        string             `json:"role"`
+	TokenHash   string             `json:"token_hash"`
+	InvitedBy   pgtype.Int8        `json:"invited_by"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	AcceptedBy  pgtype.Int8        `json:"accepted_by"`
+	AcceptedAt  pgtype.Timestamptz `json:"accepted_at"`
+	RevokedAt   pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type WorkspaceMember struct {
+	WorkspaceID int64              `json:"workspace_id"`
+	UserID      int64              `json:"user_id"`
+	Role        string             `json:"role"`
+	JoinedAt    pgtype.Timestamptz `json:"joined_at"`
 }
@@ -14,6 +14,7 @@ type Notification struct {
 	Type      string             `json:"type"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	Activity  []byte             `json:"activity"`
 }
 
 type CreateNotificationParams struct {
@@ -23,6 +24,7 @@ type CreateNotificationParams struct {
 	Message   string             `json:"message"`
 	Type      string             `json:"type"`
 	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	Activity  []byte             `json:"activity"`
 }
 
 type MarkNotificationAsReadParams struct {
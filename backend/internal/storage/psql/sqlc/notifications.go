@@ -15,10 +15,11 @@ INSERT INTO notifications (
     title,
     message,
     type,
-    expires_at
+    expires_at,
+    activity
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING id, user_id, task_id, title, message, is_read, type, created_at, expires_at
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, user_id, task_id, title, message, is_read, type, created_at, expires_at, activity
 `
 
 func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error) {
@@ -29,6 +30,7 @@ func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotification
 		arg.Message,
 		arg.Type,
 		arg.ExpiresAt,
+		arg.Activity,
 	)
 	var i Notification
 	err := row.Scan(
@@ -41,12 +43,13 @@ func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotification
 		&i.Type,
 		&i.CreatedAt,
 		&i.ExpiresAt,
+		&i.Activity,
 	)
 	return i, err
 }
 
 const getNotificationsByUserID = `-- name: GetNotificationsByUserID :many
-SELECT id, user_id, task_id, title, message, is_read, type, created_at, expires_at FROM notifications
+SELECT id, user_id, task_id, title, message, is_read, type, created_at, expires_at, activity FROM notifications
 WHERE user_id = $1
 ORDER BY created_at DESC
 `
@@ -70,6 +73,7 @@ func (q *Queries) GetNotificationsByUserID(ctx context.Context, userID int64) ([
 			&i.Type,
 			&i.CreatedAt,
 			&i.ExpiresAt,
+			&i.Activity,
 		); err != nil {
 			return nil, err
 		}
@@ -11,29 +11,214 @@ import (
 )
 
 type Querier interface {
+	AcceptWorkspaceInvitation(ctx context.Context, arg AcceptWorkspaceInvitationParams) (int64, error)
+	AddBoardMember(ctx context.Context, arg AddBoardMemberParams) error
+	AddTaskAssignee(ctx context.Context, arg AddTaskAssigneeParams) (int64, error)
+	AddTaskDependency(ctx context.Context, arg AddTaskDependencyParams) (int64, error)
+	AddTaskLabels(ctx context.Context, arg AddTaskLabelsParams) error
+	AddTaskWatcher(ctx context.Context, arg AddTaskWatcherParams) error
+	AddWorkspaceMember(ctx context.Context, arg AddWorkspaceMemberParams) (int64, error)
+	AdvanceTaskRecurrence(ctx context.Context, arg AdvanceTaskRecurrenceParams) (int64, error)
+	AnonymizeUser(ctx context.Context, id int64) error
 	BlockUser(ctx context.Context, arg BlockUserParams) error
+	BumpBoardRevision(ctx context.Context, id int64) (BumpBoardRevisionRow, error)
+	CancelUserDeletion(ctx context.Context, id int64) (int64, error)
+	ClearLoginAttemptsByEmail(ctx context.Context, email string) error
+	ClearLoginAttemptsByIP(ctx context.Context, ipAddress pgtype.Text) error
+	CopyTaskAssignees(ctx context.Context, arg CopyTaskAssigneesParams) error
+	CopyTaskChecklist(ctx context.Context, arg CopyTaskChecklistParams) error
+	CopyTaskFieldValues(ctx context.Context, arg CopyTaskFieldValuesParams) error
+	CountColumnTasks(ctx context.Context, arg CountColumnTasksParams) (int64, error)
+	CountWorkspaceOwners(ctx context.Context, workspaceID int64) (int64, error)
+	CreateAttachment(ctx context.Context, arg CreateAttachmentParams) (int64, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error
+	CreateAutomationExecution(ctx context.Context, arg CreateAutomationExecutionParams) error
+	CreateAutomationRule(ctx context.Context, arg CreateAutomationRuleParams) (AutomationRule, error)
+	CreateBoard(ctx context.Context, arg CreateBoardParams) (Board, error)
+	CreateBoardInvite(ctx context.Context, arg CreateBoardInviteParams) (BoardInvite, error)
+	CreateBoardInviteRedemption(ctx context.Context, arg CreateBoardInviteRedemptionParams) (int64, error)
+	CreateBoardTemplate(ctx context.Context, arg CreateBoardTemplateParams) (BoardTemplate, error)
+	CreateBoardView(ctx context.Context, arg CreateBoardViewParams) (BoardView, error)
+	CreateChecklistItem(ctx context.Context, arg CreateChecklistItemParams) (TaskChecklistItem, error)
+	CreateColumn(ctx context.Context, arg CreateColumnParams) (BoardColumn, error)
+	CreateComment(ctx context.Context, arg CreateCommentParams) (TaskComment, error)
+	CreateCommentRevision(ctx context.Context, arg CreateCommentRevisionParams) (int64, error)
+	CreateCustomField(ctx context.Context, arg CreateCustomFieldParams) (BoardCustomField, error)
+	CreateLabel(ctx context.Context, arg CreateLabelParams) (BoardLabel, error)
 	CreateLoginAttempt(ctx context.Context, arg CreateLoginAttemptParams) error
+	CreatePersonalAccessToken(ctx context.Context, arg CreatePersonalAccessTokenParams) (PersonalAccessToken, error)
 	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error
+	CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error)
+	CreateTaskActivity(ctx context.Context, arg CreateTaskActivityParams) (CreateTaskActivityRow, error)
+	CreateTaskTemplate(ctx context.Context, arg CreateTaskTemplateParams) (TaskTemplate, error)
 	CreateTwoFaCode(ctx context.Context, arg CreateTwoFaCodeParams) (int64, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateWorkspace(ctx context.Context, arg CreateWorkspaceParams) (Workspace, error)
+	CreateWorkspaceInvitation(ctx context.Context, arg CreateWorkspaceInvitationParams) (WorkspaceInvitation, error)
+	DeleteAttachment(ctx context.Context, id int64) (int64, error)
+	DeleteAutomationRule(ctx context.Context, id int64) (int64, error)
+	DeleteBoard(ctx context.Context, arg DeleteBoardParams) (int64, error)
+	DeleteBoardMembershipsByUserID(ctx context.Context, userID int64) error
+	DeleteBoardMembershipsInWorkspace(ctx context.Context, arg DeleteBoardMembershipsInWorkspaceParams) error
+	DeleteBoardTemplate(ctx context.Context, id int64) (int64, error)
+	DeleteBoardView(ctx context.Context, id int64) (int64, error)
+	DeleteChecklistItem(ctx context.Context, id int64) (int64, error)
+	DeleteCustomField(ctx context.Context, id int64) (int64, error)
+	DeleteEmptyFieldValues(ctx context.Context, fieldID int64) error
 	DeleteExpiredRefreshTokens(ctx context.Context) error
+	DeleteLabel(ctx context.Context, id int64) (int64, error)
+	DeleteLoginAttemptsByEmail(ctx context.Context, email string) error
+	DeleteNotificationsByUserID(ctx context.Context, userID int64) error
 	DeleteRefreshToken(ctx context.Context, token string) error
+	DeleteSoleMemberWorkspaces(ctx context.Context, userID int64) error
+	DeleteTask(ctx context.Context, arg DeleteTaskParams) (int64, error)
+	DeleteTaskFieldValue(ctx context.Context, arg DeleteTaskFieldValueParams) error
+	DeleteTaskLabels(ctx context.Context, taskID int64) error
+	DeleteTaskRecurrence(ctx context.Context, id int64) (int64, error)
+	DeleteTaskTemplate(ctx context.Context, id int64) (int64, error)
+	DeleteTwoFaCodesByUserID(ctx context.Context, userID int64) error
+	DeleteUnreferencedAttachments(ctx context.Context, createdAt pgtype.Timestamptz) (int64, error)
+	DeleteUnusedAttachmentBlobs(ctx context.Context, lastUsedAt pgtype.Timestamptz) ([]DeleteUnusedAttachmentBlobsRow, error)
+	DeleteWorkspace(ctx context.Context, arg DeleteWorkspaceParams) (int64, error)
+	DeleteWorkspaceMembershipsByUserID(ctx context.Context, userID int64) error
+	GetAdminUser(ctx context.Context, id int64) (GetAdminUserRow, error)
+	GetAttachment(ctx context.Context, id int64) (GetAttachmentRow, error)
+	GetAutomationRule(ctx context.Context, id int64) (AutomationRule, error)
 	GetBlockedStatus(ctx context.Context, email string) (pgtype.Timestamptz, error)
+	GetBoardForMember(ctx context.Context, arg GetBoardForMemberParams) (GetBoardForMemberRow, error)
+	GetBoardInviteByTokenHash(ctx context.Context, tokenHash string) (GetBoardInviteByTokenHashRow, error)
+	GetBoardTemplate(ctx context.Context, id int64) (BoardTemplate, error)
+	GetBoardView(ctx context.Context, id int64) (BoardView, error)
+	GetChecklistItem(ctx context.Context, id int64) (TaskChecklistItem, error)
+	GetColumnForMember(ctx context.Context, arg GetColumnForMemberParams) (BoardColumn, error)
+	GetCommentForMember(ctx context.Context, arg GetCommentForMemberParams) (GetCommentForMemberRow, error)
+	GetCustomField(ctx context.Context, id int64) (BoardCustomField, error)
+	GetFailedAttemptStatsByIP(ctx context.Context, arg GetFailedAttemptStatsByIPParams) (GetFailedAttemptStatsByIPRow, error)
 	GetFailedLogAttempts(ctx context.Context, arg GetFailedLogAttemptsParams) (int64, error)
+	GetLabel(ctx context.Context, id int64) (BoardLabel, error)
+	GetLastChecklistPosition(ctx context.Context, arg GetLastChecklistPositionParams) (string, error)
+	GetLastColumnPosition(ctx context.Context, boardID int64) (string, error)
+	GetLastTaskPosition(ctx context.Context, arg GetLastTaskPositionParams) (string, error)
+	GetLockoutCount(ctx context.Context, email string) (pgtype.Int4, error)
+	GetNextChecklistPosition(ctx context.Context, arg GetNextChecklistPositionParams) (string, error)
+	GetNextTaskPosition(ctx context.Context, arg GetNextTaskPositionParams) (string, error)
+	GetPersonalAccessTokenByHash(ctx context.Context, tokenHash string) (PersonalAccessToken, error)
+	GetPrevTaskPosition(ctx context.Context, arg GetPrevTaskPositionParams) (string, error)
 	GetRecentCodeRequests(ctx context.Context, arg GetRecentCodeRequestsParams) (int64, error)
 	GetRecentFailedAttempts(ctx context.Context, arg GetRecentFailedAttemptsParams) (int64, error)
 	GetRecentVerificationAttempts(ctx context.Context, arg GetRecentVerificationAttemptsParams) (int64, error)
 	GetRefreshToken(ctx context.Context, token string) (GetRefreshTokenRow, error)
+	GetTaskForMember(ctx context.Context, arg GetTaskForMemberParams) (GetTaskForMemberRow, error)
+	GetTaskRecurrence(ctx context.Context, currentTaskID int64) (TaskRecurrence, error)
+	GetTaskTemplate(ctx context.Context, id int64) (TaskTemplate, error)
 	GetTwoFaCodeByUserID(ctx context.Context, userID int64) (TwoFaCode, error)
 	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
 	GetUserByID(ctx context.Context, id int64) (GetUserByIDRow, error)
+	GetUserProfile(ctx context.Context, id int64) (GetUserProfileRow, error)
+	GetUserRole(ctx context.Context, id int64) (string, error)
+	GetWorkspaceForMember(ctx context.Context, arg GetWorkspaceForMemberParams) (GetWorkspaceForMemberRow, error)
+	GetWorkspaceInvitationByTokenHash(ctx context.Context, tokenHash string) (GetWorkspaceInvitationByTokenHashRow, error)
+	GetWorkspaceMemberRole(ctx context.Context, arg GetWorkspaceMemberRoleParams) (string, error)
+	IsBoardMember(ctx context.Context, arg IsBoardMemberParams) (bool, error)
+	LinkMessageAttachments(ctx context.Context, arg LinkMessageAttachmentsParams) (int64, error)
+	ListAccessibleBoardIDs(ctx context.Context, userID int64) ([]int64, error)
+	ListAttachmentsByIDs(ctx context.Context, ids []int64) ([]ListAttachmentsByIDsRow, error)
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListAutomationExecutions(ctx context.Context, arg ListAutomationExecutionsParams) ([]AutomationExecution, error)
+	ListAutomationRules(ctx context.Context, boardID int64) ([]AutomationRule, error)
+	ListBlockedTasks(ctx context.Context, blockerID int64) ([]ListBlockedTasksRow, error)
+	ListBoardAdminIDs(ctx context.Context, boardID int64) ([]int64, error)
+	ListBoardColumns(ctx context.Context, arg ListBoardColumnsParams) ([]BoardColumn, error)
+	ListBoardCustomFields(ctx context.Context, boardID int64) ([]BoardCustomField, error)
+	ListBoardInvites(ctx context.Context, boardID int64) ([]BoardInvite, error)
+	ListBoardLabels(ctx context.Context, boardID int64) ([]BoardLabel, error)
+	ListBoardMemberIDsByUsername(ctx context.Context, arg ListBoardMemberIDsByUsernameParams) ([]int64, error)
+	ListBoardTaskFieldValues(ctx context.Context, boardID int64) ([]ListBoardTaskFieldValuesRow, error)
+	ListBoardTasks(ctx context.Context, arg ListBoardTasksParams) ([]ListBoardTasksRow, error)
+	ListBoardTemplates(ctx context.Context, workspaceID int64) ([]BoardTemplate, error)
+	ListBoardViews(ctx context.Context, arg ListBoardViewsParams) ([]BoardView, error)
+	ListBoardsForMember(ctx context.Context, arg ListBoardsForMemberParams) ([]ListBoardsForMemberRow, error)
+	ListChecklistItemIDs(ctx context.Context, taskID int64) ([]int64, error)
+	ListChecklistItems(ctx context.Context, taskID int64) ([]TaskChecklistItem, error)
+	ListColumnTaskIDs(ctx context.Context, columnID int64) ([]int64, error)
+	ListColumnsNeedingRebalance(ctx context.Context, maxLength int32) ([]int64, error)
+	ListCommentRevisions(ctx context.Context, commentID int64) ([]ListCommentRevisionsRow, error)
+	ListCommentsByUserID(ctx context.Context, userID int64) ([]ListCommentsByUserIDRow, error)
+	ListDueDeadlineRules(ctx context.Context, maxResults int32) ([]ListDueDeadlineRulesRow, error)
+	ListDueRecurrences(ctx context.Context, limit int32) ([]TaskRecurrence, error)
+	ListEnabledAutomationRules(ctx context.Context, arg ListEnabledAutomationRulesParams) ([]AutomationRule, error)
+	ListLoginAttemptsByEmail(ctx context.Context, arg ListLoginAttemptsByEmailParams) ([]LoginAttempt, error)
+	ListPendingWorkspaceInvitations(ctx context.Context, workspaceID int64) ([]WorkspaceInvitation, error)
+	ListPersonalAccessTokensByUserID(ctx context.Context, userID int64) ([]PersonalAccessToken, error)
+	ListSubtasks(ctx context.Context, arg ListSubtasksParams) ([]ListSubtasksRow, error)
+	ListTaskActivity(ctx context.Context, arg ListTaskActivityParams) ([]ListTaskActivityRow, error)
+	ListTaskAssignees(ctx context.Context, taskID int64) ([]ListTaskAssigneesRow, error)
+	ListTaskAttachments(ctx context.Context, taskID pgtype.Int8) ([]ListTaskAttachmentsRow, error)
+	ListTaskBlockers(ctx context.Context, blockedID int64) ([]ListTaskBlockersRow, error)
+	ListTaskComments(ctx context.Context, arg ListTaskCommentsParams) ([]ListTaskCommentsRow, error)
+	ListTaskFieldValues(ctx context.Context, taskID int64) ([]ListTaskFieldValuesRow, error)
+	ListTaskRecipientIDs(ctx context.Context, taskID int64) ([]int64, error)
+	ListTaskTemplates(ctx context.Context, boardID int64) ([]TaskTemplate, error)
+	ListTaskWatchers(ctx context.Context, taskID int64) ([]ListTaskWatchersRow, error)
+	ListUsers(ctx context.Context, arg ListUsersParams) ([]ListUsersRow, error)
+	ListUsersDueForDeletion(ctx context.Context, deletionScheduledAt pgtype.Timestamptz) ([]ListUsersDueForDeletionRow, error)
+	ListWorkspaceMembers(ctx context.Context, arg ListWorkspaceMembersParams) ([]ListWorkspaceMembersRow, error)
+	ListWorkspacesForMember(ctx context.Context, userID int64) ([]ListWorkspacesForMemberRow, error)
+	LockBoard(ctx context.Context, id int64) error
+	LockColumn(ctx context.Context, arg LockColumnParams) (bool, error)
+	MarkCommentDeleted(ctx context.Context, id int64) (int64, error)
 	MarkTwoFaCodeAsUsed(ctx context.Context, id int64) error
+	MoveTask(ctx context.Context, arg MoveTaskParams) (int64, error)
+	PostponeTaskRecurrence(ctx context.Context, id int64) (pgtype.Timestamptz, error)
+	PromoteWorkspaceSuccessors(ctx context.Context, userID int64) error
+	PruneFieldOptions(ctx context.Context, arg PruneFieldOptionsParams) error
+	RedeemBoardInvite(ctx context.Context, id int64) (int64, error)
 	RefreshDeleteByUserI(ctx context.Context, userID int64) error
 	RefreshDeleteByUserID(ctx context.Context, userID int64) error
+	RemoveTaskAssignee(ctx context.Context, arg RemoveTaskAssigneeParams) (int64, error)
+	RemoveTaskDependency(ctx context.Context, arg RemoveTaskDependencyParams) (int64, error)
+	RemoveTaskWatcher(ctx context.Context, arg RemoveTaskWatcherParams) (int64, error)
+	RemoveWorkspaceMember(ctx context.Context, arg RemoveWorkspaceMemberParams) (int64, error)
+	RenameWorkspace(ctx context.Context, arg RenameWorkspaceParams) (int64, error)
+	RequirePasswordReset(ctx context.Context, id int64) error
 	ResetFailedAttempts(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, arg ResetPasswordParams) (int64, error)
+	RevokeAllPersonalAccessTokens(ctx context.Context, userID int64) error
+	RevokeBoardInvite(ctx context.Context, arg RevokeBoardInviteParams) (int64, error)
+	RevokePendingWorkspaceInvitations(ctx context.Context, arg RevokePendingWorkspaceInvitationsParams) error
+	RevokePersonalAccessToken(ctx context.Context, arg RevokePersonalAccessTokenParams) (int64, error)
+	RevokeWorkspaceInvitation(ctx context.Context, arg RevokeWorkspaceInvitationParams) (int64, error)
+	ScheduleUserDeletion(ctx context.Context, arg ScheduleUserDeletionParams) error
+	SearchComments(ctx context.Context, arg SearchCommentsParams) ([]SearchCommentsRow, error)
+	SearchTasks(ctx context.Context, arg SearchTasksParams) ([]SearchTasksRow, error)
+	SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error)
+	SetChecklistItemPosition(ctx context.Context, arg SetChecklistItemPositionParams) error
+	SetTaskPosition(ctx context.Context, arg SetTaskPositionParams) error
+	StampColumnTasksCompleted(ctx context.Context, columnID int64) error
+	TaskDependencyPathExists(ctx context.Context, arg TaskDependencyPathExistsParams) (bool, error)
+	TaskParentAllowed(ctx context.Context, arg TaskParentAllowedParams) (bool, error)
+	TouchAttachmentBlob(ctx context.Context, sha256 string) (int64, error)
+	TouchPersonalAccessToken(ctx context.Context, arg TouchPersonalAccessTokenParams) error
+	UpdateAutomationRule(ctx context.Context, arg UpdateAutomationRuleParams) (int64, error)
+	UpdateBoard(ctx context.Context, arg UpdateBoardParams) (int64, error)
+	UpdateBoardView(ctx context.Context, arg UpdateBoardViewParams) (int64, error)
+	UpdateChecklistItem(ctx context.Context, arg UpdateChecklistItemParams) (int64, error)
+	UpdateColumn(ctx context.Context, arg UpdateColumnParams) (int64, error)
+	UpdateCommentBody(ctx context.Context, arg UpdateCommentBodyParams) (int64, error)
+	UpdateCustomField(ctx context.Context, arg UpdateCustomFieldParams) (int64, error)
+	UpdateLabel(ctx context.Context, arg UpdateLabelParams) (int64, error)
 	UpdatePasswordHash(ctx context.Context, arg UpdatePasswordHashParams) error
+	UpdateTask(ctx context.Context, arg UpdateTaskParams) (int64, error)
+	UpdateTaskTemplate(ctx context.Context, arg UpdateTaskTemplateParams) (int64, error)
 	UpdateTwoFAStatus(ctx context.Context, arg UpdateTwoFAStatusParams) error
 	UpdateTwoFaCodeAttempts(ctx context.Context, arg UpdateTwoFaCodeAttemptsParams) error
+	UpdateUserAvatar(ctx context.Context, arg UpdateUserAvatarParams) (int64, error)
+	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (UpdateUserProfileRow, error)
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) error
+	UpdateWorkspaceMemberRole(ctx context.Context, arg UpdateWorkspaceMemberRoleParams) (int64, error)
+	UpsertAttachmentBlob(ctx context.Context, arg UpsertAttachmentBlobParams) error
+	UpsertTaskFieldValue(ctx context.Context, arg UpsertTaskFieldValueParams) error
+	UpsertTaskRecurrence(ctx context.Context, arg UpsertTaskRecurrenceParams) (TaskRecurrence, error)
 	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
 	GetNotificationsByUserID(ctx context.Context, userID int64) ([]Notification, error)
 	MarkNotificationAsRead(ctx context.Context, arg MarkNotificationAsReadParams) error
@@ -30,19 +30,51 @@ func (q *Queries) BlockUser(ctx context.Context, arg BlockUserParams) error {
 	return err
 }
 
+const clearLoginAttemptsByEmail = `-- name: ClearLoginAttemptsByEmail :exec
+UPDATE login_attempts
+SET cleared = true
+WHERE email = $1
+  AND cleared = false
+`
+
+func (q *Queries) ClearLoginAttemptsByEmail(ctx context.Context, email string) error {
+	_, err := q.db.Exec(ctx, clearLoginAttemptsByEmail, email)
+	return err
+}
+
+const clearLoginAttemptsByIP = `-- name: ClearLoginAttemptsByIP :exec
+UPDATE login_attempts
+SET cleared = true
+WHERE ip_address = $1
+  AND cleared = false
+`
+
+func (q *Queries) ClearLoginAttemptsByIP(ctx context.Context, ipAddress pgtype.Text) error {
+	_, err := q.db.Exec(ctx, clearLoginAttemptsByIP, ipAddress)
+	return err
+}
+
 const createLoginAttempt = `-- name: CreateLoginAttempt :exec
-INSERT INTO login_attempts (email, success, attempted_at)
-VALUES ($1, $2, $3)
+INSERT INTO login_attempts (email, success, attempted_at, ip_address, user_agent)
+VALUES ($1, $2, $3, $4, $5)
 `
 
 type CreateLoginAttemptParams struct {
 	Email       string             `json:"email"`
 	Success     bool               `json:"success"`
 	AttemptedAt pgtype.Timestamptz `json:"attempted_at"`
+	IpAddress   pgtype.Text        `json:"ip_address"`
+	UserAgent   pgtype.Text        `json:"user_agent"`
 }
 
 func (q *Queries) CreateLoginAttempt(ctx context.Context, arg CreateLoginAttemptParams) error {
-	_, err := q.db.Exec(ctx, createLoginAttempt, arg.Email, arg.Success, arg.AttemptedAt)
+	_, err := q.db.Exec(ctx, createLoginAttempt,
+		arg.Email,
+		arg.Success,
+		arg.AttemptedAt,
+		arg.IpAddress,
+		arg.UserAgent,
+	)
 	return err
 }
 
@@ -72,7 +104,7 @@ INSERT INTO users (
     two_fa_enabled
 ) VALUES (
     $1, $2, $3, $4, $5, $6
-) RETURNING id, username, firstname, lastname, email, password_hash, two_fa_enabled, created_at, updated_at, blocked_until, failed_attempts, last_failed_attempt
+) RETURNING id, username, firstname, lastname, email, password_hash, two_fa_enabled, created_at, updated_at, blocked_until, failed_attempts, last_failed_attempt, time_zone, locale, avatar_path, deletion_scheduled_at, deleted_at, role, password_reset_required
 `
 
 type CreateUserParams struct {
@@ -107,6 +139,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.BlockedUntil,
 		&i.FailedAttempts,
 		&i.LastFailedAttempt,
+		&i.TimeZone,
+		&i.Locale,
+		&i.AvatarPath,
+		&i.DeletionScheduledAt,
+		&i.DeletedAt,
+		&i.Role,
+		&i.PasswordResetRequired,
 	)
 	return i, err
 }
@@ -144,11 +183,40 @@ func (q *Queries) GetBlockedStatus(ctx context.Context, email string) (pgtype.Ti
 	return blocked_until, err
 }
 
+const getFailedAttemptStatsByIP = `-- name: GetFailedAttemptStatsByIP :one
+SELECT
+    COUNT(*) as failures,
+    MAX(attempted_at)::timestamptz as last_failed_at
+FROM login_attempts
+WHERE ip_address = $1
+  AND success = false
+  AND cleared = false
+  AND attempted_at >= $2
+`
+
+type GetFailedAttemptStatsByIPParams struct {
+	IpAddress   pgtype.Text        `json:"ip_address"`
+	AttemptedAt pgtype.Timestamptz `json:"attempted_at"`
+}
+
+type GetFailedAttemptStatsByIPRow struct {
+	Failures     int64              `json:"failures"`
+	LastFailedAt pgtype.Timestamptz `json:"last_failed_at"`
+}
+
+func (q *Queries) GetFailedAttemptStatsByIP(ctx context.Context, arg GetFailedAttemptStatsByIPParams) (GetFailedAttemptStatsByIPRow, error) {
+	row := q.db.QueryRow(ctx, getFailedAttemptStatsByIP, arg.IpAddress, arg.AttemptedAt)
+	var i GetFailedAttemptStatsByIPRow
+	err := row.Scan(&i.Failures, &i.LastFailedAt)
+	return i, err
+}
+
 const getFailedLogAttempts = `-- name: GetFailedLogAttempts :one
 SELECT COUNT(*) as count 
 FROM login_attempts 
 WHERE email = $1 
   AND success = false 
+  AND cleared = false
   AND attempted_at >= $2
 `
 
@@ -164,11 +232,25 @@ func (q *Queries) GetFailedLogAttempts(ctx context.Context, arg GetFailedLogAtte
 	return count, err
 }
 
+const getLockoutCount = `-- name: GetLockoutCount :one
+SELECT failed_attempts
+FROM users
+WHERE email = $1
+`
+
+func (q *Queries) GetLockoutCount(ctx context.Context, email string) (pgtype.Int4, error) {
+	row := q.db.QueryRow(ctx, getLockoutCount, email)
+	var failed_attempts pgtype.Int4
+	err := row.Scan(&failed_attempts)
+	return failed_attempts, err
+}
+
 const getRecentFailedAttempts = `-- name: GetRecentFailedAttempts :one
 SELECT COUNT(*) as count
 FROM login_attempts 
 WHERE email = $1 
   AND success = false 
+  AND cleared = false
   AND attempted_at >= $2
 `
 
@@ -213,23 +295,25 @@ SELECT
     two_fa_enabled,
     created_at,
     blocked_until,
-    failed_attempts
+    failed_attempts,
+    password_reset_required
 FROM users 
 WHERE email = $1 
 LIMIT 1
 `
 
 type GetUserByEmailRow struct {
-	ID             int64              `json:"id"`
-	Username       string             `json:"username"`
-	Firstname      string             `json:"firstname"`
-	Lastname       string             `json:"lastname"`
-	Email          string             `json:"email"`
-	PasswordHash   string             `json:"password_hash"`
-	TwoFaEnabled   pgtype.Bool        `json:"two_fa_enabled"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
-	BlockedUntil   pgtype.Timestamptz `json:"blocked_until"`
-	FailedAttempts pgtype.Int4        `json:"failed_attempts"`
+	ID                    int64              `json:"id"`
+	Username              string             `json:"username"`
+	Firstname             string             `json:"firstname"`
+	Lastname              string             `json:"lastname"`
+	Email                 string             `json:"email"`
+	PasswordHash          string             `json:"password_hash"`
+	TwoFaEnabled          pgtype.Bool        `json:"two_fa_enabled"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	BlockedUntil          pgtype.Timestamptz `json:"blocked_until"`
+	FailedAttempts        pgtype.Int4        `json:"failed_attempts"`
+	PasswordResetRequired bool               `json:"password_reset_required"`
 }
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
@@ -246,6 +330,7 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEm
 		&i.CreatedAt,
 		&i.BlockedUntil,
 		&i.FailedAttempts,
+		&i.PasswordResetRequired,
 	)
 	return i, err
 }
@@ -261,23 +346,25 @@ SELECT
     two_fa_enabled,
     created_at,
     blocked_until,
-    failed_attempts
+    failed_attempts,
+    password_reset_required
 FROM users 
 WHERE id = $1 
 LIMIT 1
 `
 
 type GetUserByIDRow struct {
-	ID             int64              `json:"id"`
-	Username       string             `json:"username"`
-	Firstname      string             `json:"firstname"`
-	Lastname       string             `json:"lastname"`
-	Email          string             `json:"email"`
-	PasswordHash   string             `json:"password_hash"`
-	TwoFaEnabled   pgtype.Bool        `json:"two_fa_enabled"`
-	CreatedAt      pgtype.Timestamptz `json:"created_at"`
-	BlockedUntil   pgtype.Timestamptz `json:"blocked_until"`
-	FailedAttempts pgtype.Int4        `json:"failed_attempts"`
+	ID                    int64              `json:"id"`
+	Username              string             `json:"username"`
+	Firstname             string             `json:"firstname"`
+	Lastname              string             `json:"lastname"`
+	Email                 string             `json:"email"`
+	PasswordHash          string             `json:"password_hash"`
+	TwoFaEnabled          pgtype.Bool        `json:"two_fa_enabled"`
+	CreatedAt             pgtype.Timestamptz `json:"created_at"`
+	BlockedUntil          pgtype.Timestamptz `json:"blocked_until"`
+	FailedAttempts        pgtype.Int4        `json:"failed_attempts"`
+	PasswordResetRequired bool               `json:"password_reset_required"`
 }
 
 func (q *Queries) GetUserByID(ctx context.Context, id int64) (GetUserByIDRow, error) {
@@ -294,6 +381,7 @@ func (q *Queries) GetUserByID(ctx context.Context, id int64) (GetUserByIDRow, er
 		&i.CreatedAt,
 		&i.BlockedUntil,
 		&i.FailedAttempts,
+		&i.PasswordResetRequired,
 	)
 	return i, err
 }
@@ -0,0 +1,550 @@
+package psql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+	"github.com/your-team/taskmanager-chat/backend/pkg/lexorank"
+)
+
+var (
+	ErrColumnNotFound = &StorageError{"column not found"}
+	ErrTaskNotFound   = &StorageError{"task not found"}
+	ErrColumnFull     = &StorageError{"column is at its work-in-progress limit"}
+	ErrInvalidParent  = &StorageError{"the task cannot be a subtask of this parent"}
+)
+
+// InsertColumn returns ErrBoardNotFound unless userID can open the board.
+func (s *Storage) InsertColumn(userID int64, c domain.Column) (domain.Column, error) {
+	row, err := s.queries.CreateColumn(context.Background(), database.CreateColumnParams{
+		BoardID:   c.BoardID,
+		Title:     c.Title,
+		Position:  c.Position,
+		Kind:      c.Kind,
+		WipLimit:  optionalInt4(c.WIPLimit),
+		WipPolicy: c.WIPPolicy,
+		UserID:    userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Column{}, ErrBoardNotFound
+	}
+	if err != nil {
+		return domain.Column{}, err
+	}
+	return columnFromRow(row), nil
+}
+
+func (s *Storage) SelectColumns(userID, boardID int64) ([]domain.Column, error) {
+	rows, err := s.queries.ListBoardColumns(context.Background(), database.ListBoardColumnsParams{
+		BoardID: boardID,
+		UserID:  userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make([]domain.Column, 0, len(rows))
+	for _, row := range rows {
+		columns = append(columns, columnFromRow(row))
+	}
+	return columns, nil
+}
+
+func (s *Storage) SelectColumn(userID, columnID int64) (domain.Column, error) {
+	row, err := s.queries.GetColumnForMember(context.Background(), database.GetColumnForMemberParams{
+		ID:     columnID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Column{}, ErrColumnNotFound
+	}
+	if err != nil {
+		return domain.Column{}, err
+	}
+	return columnFromRow(row), nil
+}
+
+// UpdateColumn changes the column's settings. When the kind changes, the
+// completion time of its tasks is set or cleared to match.
+func (s *Storage) UpdateColumn(columnID int64, req domain.ColumnUpdateRequest) (bool, error) {
+	var updated bool
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		affected, err := q.UpdateColumn(context.Background(), database.UpdateColumnParams{
+			Title:         optionalText(req.Title),
+			Kind:          optionalText(req.Kind),
+			ClearWipLimit: req.ClearWIPLimit,
+			WipLimit:      optionalInt4(req.WIPLimit),
+			WipPolicy:     optionalText(req.WIPPolicy),
+			ID:            columnID,
+		})
+		if err != nil || affected == 0 {
+			return err
+		}
+		updated = true
+
+		if req.Kind == nil {
+			return nil
+		}
+		return q.StampColumnTasksCompleted(context.Background(), columnID)
+	})
+	return updated, err
+}
+
+// CountColumnTasks counts the tasks in the column, leaving out excludeID.
+func (s *Storage) CountColumnTasks(columnID, excludeID int64) (int64, error) {
+	return s.queries.CountColumnTasks(context.Background(), database.CountColumnTasksParams{
+		ColumnID:  columnID,
+		ExcludeID: excludeID,
+	})
+}
+
+// SelectLastColumnPosition returns "" for a board without columns.
+func (s *Storage) SelectLastColumnPosition(boardID int64) (string, error) {
+	return s.queries.GetLastColumnPosition(context.Background(), boardID)
+}
+
+// InsertTask returns ErrColumnNotFound unless t.UserID can open the board of
+// t.ColumnID, and ErrColumnFull when the column enforces a work-in-progress
+// limit it has reached. BoardID is taken from the column; t.BoardID must name
+// the same board, and ErrInvalidParent is returned when t.ParentID cannot take
+// a subtask there. The creator starts watching the task. Labels and field values are written in the same
+// transaction; labels of other boards are skipped.
+func (s *Storage) InsertTask(t domain.Task, values []domain.FieldValue) (domain.Task, error) {
+	var task domain.Task
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		if err := lockColumn(q, t.ColumnID, 0); err != nil {
+			return err
+		}
+		if t.ParentID != nil {
+			if err := lockParent(q, t.BoardID, 0, *t.ParentID); err != nil {
+				return err
+			}
+		}
+
+		row, err := q.CreateTask(context.Background(), database.CreateTaskParams{
+			UserID:      t.UserID,
+			Title:       t.Title,
+			Description: t.Description,
+			Deadline:    timestamptz(t.Deadline),
+			Position:    t.Position,
+			Priority:    t.Priority,
+			ParentID:    optionalInt8(t.ParentID),
+			ColumnID:    t.ColumnID,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrColumnNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := q.AddTaskWatcher(context.Background(), database.AddTaskWatcherParams{
+			TaskID: row.ID,
+			UserID: t.UserID,
+		}); err != nil {
+			return err
+		}
+		if len(t.LabelIDs) > 0 {
+			if err := q.AddTaskLabels(context.Background(), database.AddTaskLabelsParams{
+				TaskID:   row.ID,
+				LabelIds: t.LabelIDs,
+			}); err != nil {
+				return err
+			}
+		}
+		if err := setTaskFields(context.Background(), q, row.ID, values); err != nil {
+			return err
+		}
+
+		task = taskFromRow(database.GetTaskForMemberRow{
+			ID:          row.ID,
+			BoardID:     row.BoardID,
+			ColumnID:    row.ColumnID,
+			UserID:      row.UserID,
+			Title:       row.Title,
+			Description: row.Description,
+			Deadline:    row.Deadline,
+			Position:    row.Position,
+			CreatedAt:   row.CreatedAt,
+			UpdatedAt:   row.UpdatedAt,
+			Version:     row.Version,
+			Priority:    row.Priority,
+			ParentID:    row.ParentID,
+			CompletedAt: row.CompletedAt,
+		})
+		return nil
+	})
+	return task, err
+}
+
+// SelectBoardTasks returns the tasks of the board matching filter, ordered by
+// position.
+func (s *Storage) SelectBoardTasks(userID, boardID int64, filter domain.TaskFilter) ([]domain.Task, error) {
+	params := database.ListBoardTasksParams{
+		BoardID:     boardID,
+		UserID:      userID,
+		Priorities:  filter.Priorities,
+		LabelIds:    filter.LabelIDs,
+		AssigneeIds: filter.AssigneeIDs,
+		ColumnIds:   filter.ColumnIDs,
+		DueFrom:     timestamptz(filter.DueFrom),
+		DueTo:       timestamptz(filter.DueTo),
+		Text:        pgtype.Text{String: filter.Text, Valid: filter.Text != ""},
+	}
+	if filter.Done != nil {
+		params.Done = pgtype.Bool{Bool: *filter.Done, Valid: true}
+	}
+	for _, f := range filter.Fields {
+		params.FieldIds = append(params.FieldIds, f.FieldID)
+		params.FieldOps = append(params.FieldOps, f.Op)
+		params.FieldTexts = append(params.FieldTexts, optionalText(f.Typed.Text))
+		number := pgtype.Float8{}
+		if f.Typed.Number != nil {
+			number = pgtype.Float8{Float64: *f.Typed.Number, Valid: true}
+		}
+		params.FieldNumbers = append(params.FieldNumbers, number)
+		date := pgtype.Date{}
+		if f.Typed.Date != nil {
+			date = pgtype.Date{Time: *f.Typed.Date, Valid: true}
+		}
+		params.FieldDates = append(params.FieldDates, date)
+		user := pgtype.Int8{}
+		if f.Typed.UserID != nil {
+			user = pgtype.Int8{Int64: *f.Typed.UserID, Valid: true}
+		}
+		params.FieldUsers = append(params.FieldUsers, user)
+	}
+
+	rows, err := s.queries.ListBoardTasks(context.Background(), params)
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.queries.ListBoardTaskFieldValues(context.Background(), boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[int64]map[int64]interface{})
+	for _, v := range values {
+		if fields[v.TaskID] == nil {
+			fields[v.TaskID] = make(map[int64]interface{})
+		}
+		fields[v.TaskID][v.FieldID] = fieldValue(database.ListTaskFieldValuesRow(v))
+	}
+
+	tasks := make([]domain.Task, 0, len(rows))
+	for _, row := range rows {
+		task := taskFromRow(database.GetTaskForMemberRow(row))
+		if f, ok := fields[task.ID]; ok {
+			task.Fields = f
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// SelectSubtasks returns the subtasks of the task, oldest first.
+func (s *Storage) SelectSubtasks(userID, taskID int64) ([]domain.Task, error) {
+	rows, err := s.queries.ListSubtasks(context.Background(), database.ListSubtasksParams{
+		ParentID: pgtype.Int8{Int64: taskID, Valid: true},
+		UserID:   userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]domain.Task, 0, len(rows))
+	for _, row := range rows {
+		tasks = append(tasks, taskFromRow(database.GetTaskForMemberRow(row)))
+	}
+	return tasks, nil
+}
+
+func (s *Storage) SelectTask(userID, taskID int64) (domain.Task, error) {
+	row, err := s.queries.GetTaskForMember(context.Background(), database.GetTaskForMemberParams{
+		ID:     taskID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Task{}, ErrTaskNotFound
+	}
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	values, err := s.queries.ListTaskFieldValues(context.Background(), taskID)
+	if err != nil {
+		return domain.Task{}, err
+	}
+
+	task := taskFromRow(row)
+	for _, v := range values {
+		task.Fields[v.FieldID] = fieldValue(v)
+	}
+	return task, nil
+}
+
+// UpdateTask, DeleteTask and MoveTask only write when the task is still at
+// version; version 0 skips the check. Each write bumps the version.
+// UpdateTask replaces the labels when req.LabelIDs is set and writes values
+// in the same transaction. A new parent is checked under the lock of boardID,
+// the task's board, and refused with ErrInvalidParent.
+func (s *Storage) UpdateTask(userID, boardID, taskID, version int64, req domain.TaskRequest, values []domain.FieldValue) (bool, error) {
+	updated := false
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		if req.ParentID != nil && !req.ClearParent {
+			if err := lockParent(q, boardID, taskID, *req.ParentID); err != nil {
+				return err
+			}
+		}
+
+		affected, err := q.UpdateTask(context.Background(), database.UpdateTaskParams{
+			Title:         optionalText(req.Title),
+			Description:   optionalText(req.Description),
+			ClearDeadline: req.ClearDeadline,
+			Deadline:      timestamptz(req.Deadline),
+			Priority:      optionalText(req.Priority),
+			ClearParent:   req.ClearParent,
+			ParentID:      optionalInt8(req.ParentID),
+			ID:            taskID,
+			UserID:        userID,
+			Version:       expectedVersion(version),
+		})
+		if err != nil || affected == 0 {
+			return err
+		}
+		updated = true
+
+		if req.LabelIDs != nil {
+			if err := q.DeleteTaskLabels(context.Background(), taskID); err != nil {
+				return err
+			}
+			if err := q.AddTaskLabels(context.Background(), database.AddTaskLabelsParams{
+				TaskID:   taskID,
+				LabelIds: *req.LabelIDs,
+			}); err != nil {
+				return err
+			}
+		}
+		return setTaskFields(context.Background(), q, taskID, values)
+	})
+	return updated, err
+}
+
+func (s *Storage) DeleteTask(userID, taskID, version int64) (bool, error) {
+	affected, err := s.queries.DeleteTask(context.Background(), database.DeleteTaskParams{
+		ID:      taskID,
+		UserID:  userID,
+		Version: expectedVersion(version),
+	})
+	return affected > 0, err
+}
+
+// MoveTask rewrites only the moved task's row. It reports false when the
+// task or the column is out of reach or the column is on another board, and
+// returns ErrColumnFull when the task would take the column past an enforced
+// work-in-progress limit.
+func (s *Storage) MoveTask(userID, taskID, version, columnID int64, position string) (bool, error) {
+	var moved bool
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		err := lockColumn(q, columnID, taskID)
+		if errors.Is(err, ErrColumnNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		affected, err := q.MoveTask(context.Background(), database.MoveTaskParams{
+			Position: position,
+			ID:       taskID,
+			ColumnID: columnID,
+			UserID:   userID,
+			Version:  expectedVersion(version),
+		})
+		moved = affected > 0
+		return err
+	})
+	return moved, err
+}
+
+// lockParent holds the board until the transaction ends, so subtasks are
+// attached one at a time, and returns ErrInvalidParent unless parentID can
+// take taskID as a subtask.
+func lockParent(q *database.Queries, boardID, taskID, parentID int64) error {
+	if err := q.LockBoard(context.Background(), boardID); err != nil {
+		return err
+	}
+	allowed, err := q.TaskParentAllowed(context.Background(), database.TaskParentAllowedParams{
+		ParentID: parentID,
+		TaskID:   taskID,
+		BoardID:  boardID,
+	})
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return ErrInvalidParent
+	}
+	return nil
+}
+
+// lockColumn holds the column until the transaction ends, so tasks enter it
+// one at a time, and returns ErrColumnFull when its enforced work-in-progress
+// limit leaves no room for taskID.
+func lockColumn(q *database.Queries, columnID, taskID int64) error {
+	full, err := q.LockColumn(context.Background(), database.LockColumnParams{
+		TaskID:   taskID,
+		ColumnID: columnID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrColumnNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if full {
+		return ErrColumnFull
+	}
+	return nil
+}
+
+// SelectLastTaskPosition returns the highest position in the column apart
+// from excludeID's, or "" for an empty column.
+func (s *Storage) SelectLastTaskPosition(columnID, excludeID int64) (string, error) {
+	return s.queries.GetLastTaskPosition(context.Background(), database.GetLastTaskPositionParams{
+		ColumnID: columnID,
+		ID:       excludeID,
+	})
+}
+
+func (s *Storage) SelectNextTaskPosition(columnID int64, position string, excludeID int64) (string, error) {
+	return s.queries.GetNextTaskPosition(context.Background(), database.GetNextTaskPositionParams{
+		ColumnID:  columnID,
+		Position:  position,
+		ExcludeID: excludeID,
+	})
+}
+
+func (s *Storage) SelectPrevTaskPosition(columnID int64, position string, excludeID int64) (string, error) {
+	return s.queries.GetPrevTaskPosition(context.Background(), database.GetPrevTaskPositionParams{
+		ColumnID:  columnID,
+		Position:  position,
+		ExcludeID: excludeID,
+	})
+}
+
+// SelectColumnsNeedingRebalance returns columns holding positions longer
+// than maxLength or duplicate positions.
+func (s *Storage) SelectColumnsNeedingRebalance(maxLength int) ([]int64, error) {
+	return s.queries.ListColumnsNeedingRebalance(context.Background(), int32(maxLength))
+}
+
+// RebalanceColumn gives the column's tasks evenly spaced short positions in
+// their current order. The tasks stay locked until the new keys are written.
+func (s *Storage) RebalanceColumn(columnID int64) error {
+	return s.inTx(context.Background(), func(q *database.Queries) error {
+		ids, err := q.ListColumnTaskIDs(context.Background(), columnID)
+		if err != nil {
+			return err
+		}
+
+		for i, position := range lexorank.Spread(len(ids)) {
+			if err := q.SetTaskPosition(context.Background(), database.SetTaskPositionParams{
+				ID:       ids[i],
+				Position: position,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func columnFromRow(row database.BoardColumn) domain.Column {
+	return domain.Column{
+		ID:        row.ID,
+		BoardID:   row.BoardID,
+		Title:     row.Title,
+		Position:  row.Position,
+		Kind:      row.Kind,
+		Done:      row.IsDone,
+		WIPLimit:  optionalInt(row.WipLimit),
+		WIPPolicy: row.WipPolicy,
+		Tasks:     []domain.Task{},
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+}
+
+func taskFromRow(row database.GetTaskForMemberRow) domain.Task {
+	return domain.Task{
+		ID:            row.ID,
+		BoardID:       row.BoardID,
+		ColumnID:      row.ColumnID,
+		ParentID:      optionalInt64(row.ParentID),
+		UserID:        row.UserID,
+		Title:         row.Title,
+		Description:   row.Description,
+		Status:        row.Status,
+		Done:          row.Done,
+		Deadline:      optionalTime(row.Deadline),
+		Position:      row.Position,
+		Priority:      row.Priority,
+		LabelIDs:      ids(row.LabelIds),
+		AssigneeIDs:   ids(row.AssigneeIds),
+		AttachmentIDs: ids(row.AttachmentIds),
+		BlockedBy:     ids(row.BlockedBy),
+		Fields:        map[int64]interface{}{},
+		Version:       row.Version,
+		CompletedAt:   optionalTime(row.CompletedAt),
+		CreatedAt:     row.CreatedAt.Time,
+		UpdatedAt:     row.UpdatedAt.Time,
+		Progress: domain.TaskProgress{
+			ChecklistDone:  row.ChecklistDone,
+			ChecklistTotal: row.ChecklistTotal,
+			SubtasksDone:   row.SubtasksDone,
+			SubtasksTotal:  row.SubtasksTotal,
+		},
+	}
+}
+
+// ids returns an empty list for a NULL array, so it encodes as [].
+func ids(v []int64) []int64 {
+	if v == nil {
+		return []int64{}
+	}
+	return v
+}
+
+// expectedVersion turns version 0, meaning any version, into NULL.
+func expectedVersion(version int64) pgtype.Int8 {
+	return pgtype.Int8{Int64: version, Valid: version > 0}
+}
+
+func optionalInt4(v *int) pgtype.Int4 {
+	if v == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: int32(*v), Valid: true}
+}
+
+func optionalInt(v pgtype.Int4) *int {
+	if !v.Valid {
+		return nil
+	}
+	n := int(v.Int32)
+	return &n
+}
+
+func timestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
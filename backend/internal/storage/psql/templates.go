@@ -0,0 +1,369 @@
+package psql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+	"github.com/your-team/taskmanager-chat/backend/pkg/lexorank"
+)
+
+var (
+	ErrBoardTemplateNotFound = &StorageError{"board template not found"}
+	ErrBoardTemplateExists   = &StorageError{"a board template with this name already exists in the workspace"}
+	ErrTaskTemplateNotFound  = &StorageError{"task template not found"}
+	ErrTaskTemplateExists    = &StorageError{"a task template with this name already exists on the board"}
+)
+
+// Templates are looked up by id alone; callers check access to their
+// workspace or board.
+
+func (s *Storage) InsertBoardTemplate(t domain.BoardTemplate) (domain.BoardTemplate, error) {
+	content, err := json.Marshal(t.Content)
+	if err != nil {
+		return domain.BoardTemplate{}, err
+	}
+
+	row, err := s.queries.CreateBoardTemplate(context.Background(), database.CreateBoardTemplateParams{
+		WorkspaceID: t.WorkspaceID,
+		CreatedBy:   optionalInt8(t.CreatedBy),
+		Name:        t.Name,
+		Description: t.Description,
+		Content:     content,
+	})
+	if isUniqueViolation(err) {
+		return domain.BoardTemplate{}, ErrBoardTemplateExists
+	}
+	if err != nil {
+		return domain.BoardTemplate{}, err
+	}
+	return boardTemplateFromRow(row)
+}
+
+func (s *Storage) SelectBoardTemplates(workspaceID int64) ([]domain.BoardTemplate, error) {
+	rows, err := s.queries.ListBoardTemplates(context.Background(), workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]domain.BoardTemplate, 0, len(rows))
+	for _, row := range rows {
+		template, err := boardTemplateFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+func (s *Storage) SelectBoardTemplate(templateID int64) (domain.BoardTemplate, error) {
+	row, err := s.queries.GetBoardTemplate(context.Background(), templateID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.BoardTemplate{}, ErrBoardTemplateNotFound
+	}
+	if err != nil {
+		return domain.BoardTemplate{}, err
+	}
+	return boardTemplateFromRow(row)
+}
+
+func (s *Storage) DeleteBoardTemplate(templateID int64) (bool, error) {
+	affected, err := s.queries.DeleteBoardTemplate(context.Background(), templateID)
+	return affected > 0, err
+}
+
+// InsertBoardFromTemplate creates b with the columns, labels, fields,
+// automation rules and tasks of content, giving them new ids and pointing
+// the rules and tasks at those. Rules act as b.CreatedBy. References content
+// does not define are dropped.
+func (s *Storage) InsertBoardFromTemplate(b domain.Board, content domain.BoardTemplateContent) (domain.Board, error) {
+	var board domain.Board
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		var err error
+		board, err = createBoard(q, b)
+		if err != nil {
+			return err
+		}
+
+		columnIDs := make(map[int64]int64, len(content.Columns))
+		for i, position := range lexorank.Spread(len(content.Columns)) {
+			c := content.Columns[i]
+			row, err := q.CreateColumn(context.Background(), database.CreateColumnParams{
+				BoardID:   board.ID,
+				Title:     c.Title,
+				Position:  position,
+				Kind:      c.Kind,
+				WipLimit:  optionalInt4(c.WIPLimit),
+				WipPolicy: c.WIPPolicy,
+				UserID:    b.CreatedBy,
+			})
+			if err != nil {
+				return err
+			}
+			columnIDs[c.ID] = row.ID
+		}
+
+		labelIDs := make(map[int64]int64, len(content.Labels))
+		for _, l := range content.Labels {
+			row, err := q.CreateLabel(context.Background(), database.CreateLabelParams{
+				BoardID: board.ID,
+				Name:    l.Name,
+				Color:   l.Color,
+			})
+			if err != nil {
+				return err
+			}
+			labelIDs[l.ID] = row.ID
+		}
+
+		for _, f := range content.Fields {
+			if _, err := q.CreateCustomField(context.Background(), database.CreateCustomFieldParams{
+				BoardID: board.ID,
+				Name:    f.Name,
+				Type:    f.Type,
+				Options: f.Options,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, a := range content.Automations {
+			rule := remapAutomation(a, columnIDs, labelIDs)
+			trigger, conditions, actions, err := marshalRule(&rule.Trigger, &rule.Conditions, &rule.Actions)
+			if err != nil {
+				return err
+			}
+			if _, err := q.CreateAutomationRule(context.Background(), database.CreateAutomationRuleParams{
+				BoardID:     board.ID,
+				CreatedBy:   b.CreatedBy,
+				Name:        rule.Name,
+				Enabled:     rule.Enabled,
+				TriggerType: rule.Trigger.Type,
+				Trigger:     trigger,
+				Conditions:  conditions,
+				Actions:     actions,
+			}); err != nil {
+				return err
+			}
+		}
+
+		return createTemplateTasks(q, b.CreatedBy, content.Tasks, columnIDs, labelIDs)
+	})
+	return board, err
+}
+
+// createTemplateTasks adds the seed tasks in order, each column's after one
+// another.
+func createTemplateTasks(q *database.Queries, userID int64, tasks []domain.TemplateTask, columnIDs, labelIDs map[int64]int64) error {
+	byColumn := map[int64][]domain.TemplateTask{}
+	var order []int64
+	for _, t := range tasks {
+		columnID, ok := columnIDs[t.ColumnID]
+		if !ok {
+			continue
+		}
+		if _, seen := byColumn[columnID]; !seen {
+			order = append(order, columnID)
+		}
+		byColumn[columnID] = append(byColumn[columnID], t)
+	}
+
+	for _, columnID := range order {
+		column := byColumn[columnID]
+		for i, position := range lexorank.Spread(len(column)) {
+			t := column[i]
+			row, err := q.CreateTask(context.Background(), database.CreateTaskParams{
+				UserID:      userID,
+				Title:       t.Title,
+				Description: t.Description,
+				Position:    position,
+				Priority:    t.Priority,
+				ColumnID:    columnID,
+			})
+			if err != nil {
+				return err
+			}
+			if err := q.AddTaskWatcher(context.Background(), database.AddTaskWatcherParams{
+				TaskID: row.ID,
+				UserID: userID,
+			}); err != nil {
+				return err
+			}
+			if labels := remapIDs(t.LabelIDs, labelIDs); len(labels) > 0 {
+				if err := q.AddTaskLabels(context.Background(), database.AddTaskLabelsParams{
+					TaskID:   row.ID,
+					LabelIds: labels,
+				}); err != nil {
+					return err
+				}
+			}
+			if err := createChecklist(q, row.ID, t.Checklist); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func createChecklist(q *database.Queries, taskID int64, items []string) error {
+	for i, position := range lexorank.Spread(len(items)) {
+		if _, err := q.CreateChecklistItem(context.Background(), database.CreateChecklistItemParams{
+			TaskID:   taskID,
+			Text:     items[i],
+			Position: position,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remapAutomation points a rule at the new columns and labels.
+func remapAutomation(a domain.TemplateAutomation, columnIDs, labelIDs map[int64]int64) domain.TemplateAutomation {
+	a.Trigger.ColumnID = columnIDs[a.Trigger.ColumnID]
+	a.Trigger.LabelID = labelIDs[a.Trigger.LabelID]
+	a.Conditions.ColumnIDs = remapIDs(a.Conditions.ColumnIDs, columnIDs)
+	a.Conditions.LabelIDs = remapIDs(a.Conditions.LabelIDs, labelIDs)
+
+	actions := make([]domain.AutomationAction, len(a.Actions))
+	for i, action := range a.Actions {
+		action.ColumnID = columnIDs[action.ColumnID]
+		action.LabelID = labelIDs[action.LabelID]
+		actions[i] = action
+	}
+	a.Actions = actions
+	return a
+}
+
+func remapIDs(values []int64, m map[int64]int64) []int64 {
+	var mapped []int64
+	for _, id := range values {
+		if newID, ok := m[id]; ok {
+			mapped = append(mapped, newID)
+		}
+	}
+	return mapped
+}
+
+func (s *Storage) InsertTaskTemplate(t domain.TaskTemplate) (domain.TaskTemplate, error) {
+	row, err := s.queries.CreateTaskTemplate(context.Background(), database.CreateTaskTemplateParams{
+		BoardID:     t.BoardID,
+		CreatedBy:   optionalInt8(t.CreatedBy),
+		Name:        t.Name,
+		Title:       t.Title,
+		Description: t.Description,
+		Priority:    t.Priority,
+		LabelIds:    ids(t.LabelIDs),
+		Checklist:   texts(t.Checklist),
+	})
+	if isUniqueViolation(err) {
+		return domain.TaskTemplate{}, ErrTaskTemplateExists
+	}
+	if err != nil {
+		return domain.TaskTemplate{}, err
+	}
+	return taskTemplateFromRow(row), nil
+}
+
+func (s *Storage) SelectTaskTemplates(boardID int64) ([]domain.TaskTemplate, error) {
+	rows, err := s.queries.ListTaskTemplates(context.Background(), boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]domain.TaskTemplate, 0, len(rows))
+	for _, row := range rows {
+		templates = append(templates, taskTemplateFromRow(row))
+	}
+	return templates, nil
+}
+
+func (s *Storage) SelectTaskTemplate(templateID int64) (domain.TaskTemplate, error) {
+	row, err := s.queries.GetTaskTemplate(context.Background(), templateID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.TaskTemplate{}, ErrTaskTemplateNotFound
+	}
+	if err != nil {
+		return domain.TaskTemplate{}, err
+	}
+	return taskTemplateFromRow(row), nil
+}
+
+func (s *Storage) UpdateTaskTemplate(templateID int64, req domain.TaskTemplateRequest) (bool, error) {
+	params := database.UpdateTaskTemplateParams{
+		Name:        optionalText(req.Name),
+		Title:       optionalText(req.Title),
+		Description: optionalText(req.Description),
+		Priority:    optionalText(req.Priority),
+		ID:          templateID,
+	}
+	if req.LabelIDs != nil {
+		params.LabelIds = ids(*req.LabelIDs)
+	}
+	if req.Checklist != nil {
+		params.Checklist = texts(*req.Checklist)
+	}
+
+	affected, err := s.queries.UpdateTaskTemplate(context.Background(), params)
+	if isUniqueViolation(err) {
+		return false, ErrTaskTemplateExists
+	}
+	return affected > 0, err
+}
+
+func (s *Storage) DeleteTaskTemplate(templateID int64) (bool, error) {
+	affected, err := s.queries.DeleteTaskTemplate(context.Background(), templateID)
+	return affected > 0, err
+}
+
+// InsertChecklist adds items to the end of a task's empty checklist.
+func (s *Storage) InsertChecklist(taskID int64, items []string) error {
+	return s.inTx(context.Background(), func(q *database.Queries) error {
+		return createChecklist(q, taskID, items)
+	})
+}
+
+// texts turns nil into an empty list, which the NOT NULL array columns
+// need.
+func texts(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func boardTemplateFromRow(row database.BoardTemplate) (domain.BoardTemplate, error) {
+	template := domain.BoardTemplate{
+		ID:          row.ID,
+		WorkspaceID: row.WorkspaceID,
+		CreatedBy:   optionalInt64(row.CreatedBy),
+		Name:        row.Name,
+		Description: row.Description,
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+	if err := json.Unmarshal(row.Content, &template.Content); err != nil {
+		return domain.BoardTemplate{}, err
+	}
+	return template, nil
+}
+
+func taskTemplateFromRow(row database.TaskTemplate) domain.TaskTemplate {
+	return domain.TaskTemplate{
+		ID:          row.ID,
+		BoardID:     row.BoardID,
+		CreatedBy:   optionalInt64(row.CreatedBy),
+		Name:        row.Name,
+		Title:       row.Title,
+		Description: row.Description,
+		Priority:    row.Priority,
+		LabelIDs:    ids(row.LabelIds),
+		Checklist:   texts(row.Checklist),
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,33 @@
+package psql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+type txBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// inTx runs fn with queries bound to a single transaction and commits when
+// fn succeeds. Inside a transaction Begin opens a savepoint, so calls nest.
+func (s *Storage) inTx(ctx context.Context, fn func(q *database.Queries) error) error {
+	db, ok := s.queries.GetDB().(txBeginner)
+	if !ok {
+		return errors.New("database handle does not support transactions")
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(s.queries.WithTx(tx)); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -61,6 +61,7 @@ func (s *Storage) SelectUsers(email string) (domain.User, error) {
 		PasswordHash: user.PasswordHash,
 		TwoFAEnabled: user.TwoFaEnabled.Bool,
 		CreatedAt: user.CreatedAt.Time,
+		PasswordResetRequired: user.PasswordResetRequired,
 	}, nil
 }
 
@@ -79,6 +80,7 @@ func (s *Storage) SelectUserByID(id int64) (domain.User, error) {
 		PasswordHash: user.PasswordHash,
 		TwoFAEnabled: user.TwoFaEnabled.Bool,
 		CreatedAt: user.CreatedAt.Time,
+		PasswordResetRequired: user.PasswordResetRequired,
 	}, nil
 }
 
@@ -110,11 +112,13 @@ func (s *Storage) RefreshDelete(token string) error {
 	return s.queries.DeleteRefreshToken(context.Background(), token)
 }
 
-func (s *Storage) LogAttempt(email string, result bool, attemptTime time.Time) error {
+func (s *Storage) LogAttempt(email string, result bool, attemptTime time.Time, client domain.ClientInfo) error {
 	params := database.CreateLoginAttemptParams{
 		Email: email,
 		Success: result,
 		AttemptedAt: pgtype.Timestamptz{Time: attemptTime, Valid: true},
+		IpAddress: pgtype.Text{String: client.IP, Valid: client.IP != ""},
+		UserAgent: pgtype.Text{String: client.UserAgent, Valid: client.UserAgent != ""},
 	}
 	
 	return s.queries.CreateLoginAttempt(context.Background(), params)
@@ -132,6 +136,41 @@ func (s *Storage) GetFailedLogAttempts(email string, windowStart time.Time) (int
 	return int(count), nil
 }
 
+func (s *Storage) GetFailedIPAttempts(ip string, windowStart time.Time) (int, time.Time, error) {
+	stats, err := s.queries.GetFailedAttemptStatsByIP(context.Background(), database.GetFailedAttemptStatsByIPParams{
+		IpAddress:   pgtype.Text{String: ip, Valid: true},
+		AttemptedAt: pgtype.Timestamptz{Time: windowStart, Valid: true},
+	})
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	
+	return int(stats.Failures), stats.LastFailedAt.Time, nil
+}
+
+func (s *Storage) GetLockoutCount(email string) (int, error) {
+	count, err := s.queries.GetLockoutCount(context.Background(), email)
+	if err != nil {
+		return 0, err
+	}
+	
+	return int(count.Int32), nil
+}
+
+func (s *Storage) ClearLoginAttempts(email, ip string) error {
+	if email != "" {
+		if err := s.queries.ClearLoginAttemptsByEmail(context.Background(), email); err != nil {
+			return err
+		}
+	}
+	
+	if ip != "" {
+		return s.queries.ClearLoginAttemptsByIP(context.Background(), pgtype.Text{String: ip, Valid: true})
+	}
+	
+	return nil
+}
+
 func (s *Storage) UserBlocked(email string, windowStart time.Time) ([]map[string]interface{}, error) {
 	blockedUntil, err := s.queries.GetBlockedStatus(context.Background(), email)
 	if err != nil {
@@ -0,0 +1,119 @@
+package psql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+var (
+	ErrViewNotFound = &StorageError{"view not found"}
+	ErrViewExists   = &StorageError{"you already have a view with this name on the board"}
+)
+
+// Views are looked up by id alone; callers check access to their board and
+// whether the user may see them.
+
+func (s *Storage) InsertBoardView(v domain.BoardView) (domain.BoardView, error) {
+	query, err := json.Marshal(v.Query)
+	if err != nil {
+		return domain.BoardView{}, err
+	}
+
+	row, err := s.queries.CreateBoardView(context.Background(), database.CreateBoardViewParams{
+		BoardID: v.BoardID,
+		UserID:  v.UserID,
+		Name:    v.Name,
+		Shared:  v.Shared,
+		Query:   query,
+	})
+	if isUniqueViolation(err) {
+		return domain.BoardView{}, ErrViewExists
+	}
+	if err != nil {
+		return domain.BoardView{}, err
+	}
+	return viewFromRow(row)
+}
+
+// SelectBoardViews returns the user's views of the board and the views
+// others shared there.
+func (s *Storage) SelectBoardViews(userID, boardID int64) ([]domain.BoardView, error) {
+	rows, err := s.queries.ListBoardViews(context.Background(), database.ListBoardViewsParams{
+		BoardID: boardID,
+		UserID:  userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]domain.BoardView, 0, len(rows))
+	for _, row := range rows {
+		view, err := viewFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+func (s *Storage) SelectBoardView(viewID int64) (domain.BoardView, error) {
+	row, err := s.queries.GetBoardView(context.Background(), viewID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.BoardView{}, ErrViewNotFound
+	}
+	if err != nil {
+		return domain.BoardView{}, err
+	}
+	return viewFromRow(row)
+}
+
+func (s *Storage) UpdateBoardView(viewID int64, req domain.BoardViewRequest) (bool, error) {
+	params := database.UpdateBoardViewParams{
+		Name: optionalText(req.Name),
+		ID:   viewID,
+	}
+	if req.Shared != nil {
+		params.Shared = pgtype.Bool{Bool: *req.Shared, Valid: true}
+	}
+	if req.Query != nil {
+		query, err := json.Marshal(req.Query)
+		if err != nil {
+			return false, err
+		}
+		params.Query = query
+	}
+
+	affected, err := s.queries.UpdateBoardView(context.Background(), params)
+	if isUniqueViolation(err) {
+		return false, ErrViewExists
+	}
+	return affected > 0, err
+}
+
+func (s *Storage) DeleteBoardView(viewID int64) (bool, error) {
+	affected, err := s.queries.DeleteBoardView(context.Background(), viewID)
+	return affected > 0, err
+}
+
+func viewFromRow(row database.BoardView) (domain.BoardView, error) {
+	view := domain.BoardView{
+		ID:        row.ID,
+		BoardID:   row.BoardID,
+		UserID:    row.UserID,
+		Name:      row.Name,
+		Shared:    row.Shared,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if err := json.Unmarshal(row.Query, &view.Query); err != nil {
+		return domain.BoardView{}, err
+	}
+	return view, nil
+}
@@ -0,0 +1,314 @@
+package psql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/your-team/taskmanager-chat/backend/internal/domain"
+	database "github.com/your-team/taskmanager-chat/backend/internal/storage/psql/sqlc"
+)
+
+var (
+	ErrWorkspaceNotFound  = &StorageError{"workspace not found"}
+	ErrInvitationNotFound = &StorageError{"invitation not found"}
+)
+
+// CreateWorkspace creates the workspace with userID as its first owner.
+func (s *Storage) CreateWorkspace(userID int64, name string) (domain.Workspace, error) {
+	var workspace domain.Workspace
+
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		row, err := q.CreateWorkspace(context.Background(), database.CreateWorkspaceParams{
+			Name:      name,
+			CreatedBy: pgtype.Int8{Int64: userID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := q.AddWorkspaceMember(context.Background(), database.AddWorkspaceMemberParams{
+			WorkspaceID: row.ID,
+			UserID:      userID,
+			Role:        domain.WorkspaceRoleOwner,
+		}); err != nil {
+			return err
+		}
+
+		workspace = domain.Workspace{
+			ID:          row.ID,
+			Name:        row.Name,
+			CreatedBy:   row.CreatedBy.Int64,
+			Role:        domain.WorkspaceRoleOwner,
+			MemberCount: 1,
+			CreatedAt:   row.CreatedAt.Time,
+			UpdatedAt:   row.UpdatedAt.Time,
+		}
+		return nil
+	})
+	return workspace, err
+}
+
+func (s *Storage) SelectWorkspaces(userID int64) ([]domain.Workspace, error) {
+	rows, err := s.queries.ListWorkspacesForMember(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspaces := make([]domain.Workspace, 0, len(rows))
+	for _, row := range rows {
+		workspaces = append(workspaces, workspaceFromRow(database.GetWorkspaceForMemberRow(row)))
+	}
+	return workspaces, nil
+}
+
+func (s *Storage) SelectWorkspace(userID, workspaceID int64) (domain.Workspace, error) {
+	row, err := s.queries.GetWorkspaceForMember(context.Background(), database.GetWorkspaceForMemberParams{
+		ID:     workspaceID,
+		UserID: userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.Workspace{}, ErrWorkspaceNotFound
+	}
+	if err != nil {
+		return domain.Workspace{}, err
+	}
+	return workspaceFromRow(row), nil
+}
+
+// RenameWorkspace only succeeds for owners and admins of the workspace.
+func (s *Storage) RenameWorkspace(userID, workspaceID int64, name string) (bool, error) {
+	affected, err := s.queries.RenameWorkspace(context.Background(), database.RenameWorkspaceParams{
+		ID:     workspaceID,
+		UserID: userID,
+		Name:   name,
+	})
+	return affected > 0, err
+}
+
+// DeleteWorkspace only succeeds for owners. Boards, members and
+// invitations go with the workspace.
+func (s *Storage) DeleteWorkspace(userID, workspaceID int64) (bool, error) {
+	affected, err := s.queries.DeleteWorkspace(context.Background(), database.DeleteWorkspaceParams{
+		ID:     workspaceID,
+		UserID: userID,
+	})
+	return affected > 0, err
+}
+
+// SelectWorkspaceMembers returns nothing unless viewerID is a member.
+func (s *Storage) SelectWorkspaceMembers(viewerID, workspaceID int64) ([]domain.WorkspaceMember, error) {
+	rows, err := s.queries.ListWorkspaceMembers(context.Background(), database.ListWorkspaceMembersParams{
+		WorkspaceID: workspaceID,
+		ViewerID:    viewerID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]domain.WorkspaceMember, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, domain.WorkspaceMember{
+			UserID:    row.UserID,
+			Username:  row.Username,
+			Firstname: row.Firstname,
+			Lastname:  row.Lastname,
+			AvatarURL: row.AvatarPath.String,
+			Role:      row.Role,
+			JoinedAt:  row.JoinedAt.Time,
+		})
+	}
+	return members, nil
+}
+
+// SelectWorkspaceRole returns ErrWorkspaceNotFound when the user is not a
+// member, so callers cannot tell foreign workspaces from missing ones.
+func (s *Storage) SelectWorkspaceRole(workspaceID, userID int64) (string, error) {
+	role, err := s.queries.GetWorkspaceMemberRole(context.Background(), database.GetWorkspaceMemberRoleParams{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", ErrWorkspaceNotFound
+	}
+	return role, err
+}
+
+func (s *Storage) CountWorkspaceOwners(workspaceID int64) (int64, error) {
+	return s.queries.CountWorkspaceOwners(context.Background(), workspaceID)
+}
+
+func (s *Storage) UpdateWorkspaceMemberRole(workspaceID, userID int64, role string) (bool, error) {
+	affected, err := s.queries.UpdateWorkspaceMemberRole(context.Background(), database.UpdateWorkspaceMemberRoleParams{
+		WorkspaceID: workspaceID,
+		UserID:      userID,
+		Role:        role,
+	})
+	return affected > 0, err
+}
+
+// RemoveWorkspaceMember also drops the user's roles on the workspace's
+// boards.
+func (s *Storage) RemoveWorkspaceMember(workspaceID, userID int64) (bool, error) {
+	removed := false
+
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		affected, err := q.RemoveWorkspaceMember(context.Background(), database.RemoveWorkspaceMemberParams{
+			WorkspaceID: workspaceID,
+			UserID:      userID,
+		})
+		if err != nil || affected == 0 {
+			return err
+		}
+
+		removed = true
+		return q.DeleteBoardMembershipsInWorkspace(context.Background(), database.DeleteBoardMembershipsInWorkspaceParams{
+			WorkspaceID: workspaceID,
+			UserID:      userID,
+		})
+	})
+	return removed, err
+}
+
+// InsertWorkspaceInvitation replaces any pending invitation for the same
+// email in the workspace.
+func (s *Storage) InsertWorkspaceInvitation(inv domain.WorkspaceInvitation, tokenHash string) (domain.WorkspaceInvitation, error) {
+	var created domain.WorkspaceInvitation
+
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		if err := q.RevokePendingWorkspaceInvitations(context.Background(), database.RevokePendingWorkspaceInvitationsParams{
+			WorkspaceID: inv.WorkspaceID,
+			Email:       inv.Email,
+		}); err != nil {
+			return err
+		}
+
+		row, err := q.CreateWorkspaceInvitation(context.Background(), database.CreateWorkspaceInvitationParams{
+			WorkspaceID: inv.WorkspaceID,
+			Email:       inv.Email,
+			Role:        inv.Role,
+			TokenHash:   tokenHash,
+			InvitedBy:   pgtype.Int8{Int64: inv.InvitedBy, Valid: inv.InvitedBy != 0},
+			ExpiresAt:   pgtype.Timestamptz{Time: inv.ExpiresAt, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		created = invitationFromRow(row)
+		return nil
+	})
+	return created, err
+}
+
+func (s *Storage) SelectWorkspaceInvitations(workspaceID int64) ([]domain.WorkspaceInvitation, error) {
+	rows, err := s.queries.ListPendingWorkspaceInvitations(context.Background(), workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitations := make([]domain.WorkspaceInvitation, 0, len(rows))
+	for _, row := range rows {
+		invitations = append(invitations, invitationFromRow(row))
+	}
+	return invitations, nil
+}
+
+func (s *Storage) RevokeWorkspaceInvitation(workspaceID, invitationID int64) (bool, error) {
+	affected, err := s.queries.RevokeWorkspaceInvitation(context.Background(), database.RevokeWorkspaceInvitationParams{
+		ID:          invitationID,
+		WorkspaceID: workspaceID,
+	})
+	return affected > 0, err
+}
+
+func (s *Storage) SelectWorkspaceInvitationByToken(tokenHash string) (domain.WorkspaceInvitation, error) {
+	row, err := s.queries.GetWorkspaceInvitationByTokenHash(context.Background(), tokenHash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.WorkspaceInvitation{}, ErrInvitationNotFound
+	}
+	if err != nil {
+		return domain.WorkspaceInvitation{}, err
+	}
+
+	return domain.WorkspaceInvitation{
+		ID:            row.ID,
+		WorkspaceID:   row.WorkspaceID,
+		WorkspaceName: row.WorkspaceName,
+		Email:         row.Email,
+		Role:          row.Role,
+		InvitedBy:     row.InvitedBy.Int64,
+		ExpiresAt:     row.ExpiresAt.Time,
+		AcceptedAt:    optionalTime(row.AcceptedAt),
+		RevokedAt:     optionalTime(row.RevokedAt),
+		CreatedAt:     row.CreatedAt.Time,
+	}, nil
+}
+
+// AcceptWorkspaceInvitation marks the invitation used and adds the user to
+// the workspace. It reports false when the invitation was used, revoked or
+// expired in the meantime. Existing members keep their current role unless
+// they were guests.
+func (s *Storage) AcceptWorkspaceInvitation(inv domain.WorkspaceInvitation, userID int64) (bool, error) {
+	accepted := false
+
+	err := s.inTx(context.Background(), func(q *database.Queries) error {
+		affected, err := q.AcceptWorkspaceInvitation(context.Background(), database.AcceptWorkspaceInvitationParams{
+			ID:         inv.ID,
+			AcceptedBy: pgtype.Int8{Int64: userID, Valid: true},
+		})
+		if err != nil || affected == 0 {
+			return err
+		}
+
+		if _, err := q.AddWorkspaceMember(context.Background(), database.AddWorkspaceMemberParams{
+			WorkspaceID: inv.WorkspaceID,
+			UserID:      userID,
+			Role:        inv.Role,
+		}); err != nil {
+			return err
+		}
+
+		accepted = true
+		return nil
+	})
+	return accepted, err
+}
+
+func (s *Storage) SelectUserIDByEmail(email string) (int64, error) {
+	user, err := s.queries.GetUserByEmail(context.Background(), email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrUserNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	return user.ID, nil
+}
+
+func workspaceFromRow(row database.GetWorkspaceForMemberRow) domain.Workspace {
+	return domain.Workspace{
+		ID:          row.ID,
+		Name:        row.Name,
+		CreatedBy:   row.CreatedBy.Int64,
+		Role:        row.Role,
+		MemberCount: row.MemberCount,
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+}
+
+func invitationFromRow(row database.WorkspaceInvitation) domain.WorkspaceInvitation {
+	return domain.WorkspaceInvitation{
+		ID:          row.ID,
+		WorkspaceID: row.WorkspaceID,
+		Email:       row.Email,
+		Role:        row.Role,
+		InvitedBy:   row.InvitedBy.Int64,
+		ExpiresAt:   row.ExpiresAt.Time,
+		AcceptedAt:  optionalTime(row.AcceptedAt),
+		RevokedAt:   optionalTime(row.RevokedAt),
+		CreatedAt:   row.CreatedAt.Time,
+	}
+}
@@ -19,14 +19,22 @@ var upgrader = websocket.Upgrader{
 	},
 }
 
+// BoardAccess resolves the workspace of a board the user may open. Any error,
+// including boards outside the user's workspaces, refuses the connection.
+type BoardAccess interface {
+	BoardWorkspace(userID, boardID int64) (int64, error)
+}
+
 type Handler struct {
 	hub    *Hub
+	boards BoardAccess
 	logger *logrus.Logger
 }
 
-func NewHandler(hub *Hub, logger *logrus.Logger) *Handler {
+func NewHandler(hub *Hub, boards BoardAccess, logger *logrus.Logger) *Handler {
 	return &Handler{
 		hub:    hub,
+		boards: boards,
 		logger: logger,
 	}
 }
@@ -63,6 +71,13 @@ func (h *Handler) HandleWebSocket(c *gin.Context) {
 		return
 	}
 
+	workspaceID, err := h.boards.BoardWorkspace(userIDInt64, boardID)
+	if err != nil {
+		h.logger.Warnf("Board %d chat refused for user %d: %v", boardID, userIDInt64, err)
+		c.JSON(http.StatusNotFound, gin.H{"error": "Board not found"})
+		return
+	}
+
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		h.logger.Errorf("WebSocket upgrade error: %v", err)
@@ -76,8 +91,9 @@ func (h *Handler) HandleWebSocket(c *gin.Context) {
 		conn:     conn,
 		send:     make(chan []byte, 256),
 		userID:   userIDInt64,
-		username: username,
-		boardID:  boardID,
+		username:    username,
+		workspaceID: workspaceID,
+		boardID:     boardID,
 	}
 
 	client.hub.register <- client
@@ -18,35 +18,62 @@ const (
 )
 
 type Client struct {
-	hub      *Hub
-	conn     *websocket.Conn
-	send     chan []byte
-	userID   int64
-	username string
-	boardID  int64
-	mu       sync.Mutex
+	hub         *Hub
+	conn        *websocket.Conn
+	send        chan []byte
+	userID      int64
+	username    string
+	workspaceID int64
+	boardID     int64
+	mu          sync.Mutex
+}
+
+// roomKey scopes a chat room to its workspace, so equal board ids from
+// different tenants can never share a room.
+type roomKey struct {
+	WorkspaceID int64
+	BoardID     int64
+}
+
+func (c *Client) room() roomKey {
+	return roomKey{WorkspaceID: c.workspaceID, BoardID: c.boardID}
 }
 
 type Hub struct {
-	clients    map[*Client]bool
-	rooms      map[int64]map[*Client]bool
-	broadcast  chan domain.MessageResponse
-	register   chan *Client
-	unregister chan *Client
-	storage    MessageStorage
-	logger     *logrus.Logger
-	mu         sync.RWMutex
+	clients     map[*Client]bool
+	rooms       map[roomKey]map[*Client]bool
+	broadcast   chan domain.MessageResponse
+	events      chan domain.BoardEvent
+	register    chan *Client
+	unregister  chan *Client
+	storage     MessageStorage
+	attachments MessageAttachments
+	listener    MessageListener
+	logger      *logrus.Logger
+	mu          sync.RWMutex
 }
 
 type MessageStorage interface {
-	SaveMessage(ctx context.Context, msg domain.Message) error
+	SaveMessage(ctx context.Context, msg domain.Message) (domain.Message, error)
+}
+
+// MessageAttachments checks and claims the uploads a chat message refers to.
+type MessageAttachments interface {
+	MessageAttachments(userID, boardID int64, attachmentIDs []int64) ([]domain.AttachmentRef, error)
+	LinkMessageAttachments(userID, boardID int64, messageID string, attachmentIDs []int64) error
+}
+
+// MessageListener hears about chat messages once they are saved.
+type MessageListener interface {
+	MessagePosted(message domain.Message)
 }
 
 func NewHub(storage MessageStorage, logger *logrus.Logger) *Hub {
 	return &Hub{
 		clients:    make(map[*Client]bool),
-		rooms:      make(map[int64]map[*Client]bool),
+		rooms:      make(map[roomKey]map[*Client]bool),
 		broadcast:  make(chan domain.MessageResponse, 256),
+		events:     make(chan domain.BoardEvent, 256),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
 		storage:    storage,
@@ -54,49 +81,93 @@ func NewHub(storage MessageStorage, logger *logrus.Logger) *Hub {
 	}
 }
 
+// UseAttachments lets chat messages carry attachments. It is called once
+// while wiring the application, before clients connect.
+func (h *Hub) UseAttachments(attachments MessageAttachments) {
+	h.attachments = attachments
+}
+
+// UseMessageListener sets the listener told about saved chat messages. It is
+// called once while wiring the application, before clients connect.
+func (h *Hub) UseMessageListener(listener MessageListener) {
+	h.listener = listener
+}
+
+// PostMessage saves a message sent by the server rather than a client and
+// queues it for the board's room.
+func (h *Hub) PostMessage(ctx context.Context, message domain.Message) (domain.Message, error) {
+	message, err := h.storage.SaveMessage(ctx, message)
+	if err != nil {
+		return domain.Message{}, err
+	}
+	h.broadcast <- messageResponse(message)
+	return message, nil
+}
+
 func (h *Hub) Run() {
 	for {
 		select {
 		case client := <-h.register:
 			h.mu.Lock()
-			if h.rooms[client.boardID] == nil {
-				h.rooms[client.boardID] = make(map[*Client]bool)
+			if h.rooms[client.room()] == nil {
+				h.rooms[client.room()] = make(map[*Client]bool)
 			}
-			h.rooms[client.boardID][client] = true
+			h.rooms[client.room()][client] = true
 			h.clients[client] = true
 			h.mu.Unlock()
-			h.logger.Infof("Client registered: userID=%d, boardID=%d", client.userID, client.boardID)
+			h.logger.Infof("Client registered: userID=%d, workspaceID=%d, boardID=%d", client.userID, client.workspaceID, client.boardID)
 
 		case client := <-h.unregister:
 			h.mu.Lock()
-			if room, ok := h.rooms[client.boardID]; ok {
+			if room, ok := h.rooms[client.room()]; ok {
 				if _, ok := room[client]; ok {
 					delete(room, client)
 					close(client.send)
 					if len(room) == 0 {
-						delete(h.rooms, client.boardID)
+						delete(h.rooms, client.room())
 					}
 				}
 			}
 			delete(h.clients, client)
 			h.mu.Unlock()
-			h.logger.Infof("Client unregistered: userID=%d, boardID=%d", client.userID, client.boardID)
+			h.logger.Infof("Client unregistered: userID=%d, workspaceID=%d, boardID=%d", client.userID, client.workspaceID, client.boardID)
 
 		case message := <-h.broadcast:
-			h.mu.RLock()
-			room, exists := h.rooms[message.BoardID]
-			if exists {
-				for client := range room {
-					select {
-					case client.send <- h.messageToJSON(message):
-					default:
-						close(client.send)
-						delete(room, client)
-						delete(h.clients, client)
-					}
-				}
-			}
-			h.mu.RUnlock()
+			h.sendToRoom(roomKey{WorkspaceID: message.WorkspaceID, BoardID: message.BoardID}, h.toJSON(message))
+
+		case event := <-h.events:
+			h.sendToRoom(roomKey{WorkspaceID: event.WorkspaceID, BoardID: event.BoardID}, h.toJSON(event))
+		}
+	}
+}
+
+// PublishBoardEvent queues a board event for the board's room. When the queue
+// is full the event is dropped; clients notice the gap in revisions and
+// reload the board.
+func (h *Hub) PublishBoardEvent(event domain.BoardEvent) {
+	select {
+	case h.events <- event:
+	default:
+		h.logger.Warnf("Dropped %s event %d of board %d: queue full", event.Type, event.Revision, event.BoardID)
+	}
+}
+
+// sendToRoom drops clients whose send buffer is full.
+func (h *Hub) sendToRoom(key roomKey, payload []byte) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	room, exists := h.rooms[key]
+	if !exists {
+		return
+	}
+	for client := range room {
+		select {
+		case client.send <- payload:
+		default:
+			close(client.send)
+			delete(room, client)
+			delete(h.clients, client)
 		}
 	}
 }
@@ -127,15 +198,29 @@ func (c *Client) readPump() {
 			continue
 		}
 
+		var attachments []domain.AttachmentRef
+		if len(msg.AttachmentIDs) > 0 {
+			if c.hub.attachments == nil {
+				continue
+			}
+			attachments, err = c.hub.attachments.MessageAttachments(c.userID, c.boardID, msg.AttachmentIDs)
+			if err != nil {
+				c.hub.logger.Warnf("Refused attachments of message by user %d on board %d: %v", c.userID, c.boardID, err)
+				continue
+			}
+		}
+
 		message := domain.Message{
-			BoardID:  msg.BoardID,
-			UserID:   c.userID,
-			Username: c.username,
-			Content:  msg.Content,
+			WorkspaceID: c.workspaceID,
+			BoardID:     msg.BoardID,
+			UserID:      c.userID,
+			Username:    c.username,
+			Content:     msg.Content,
+			Attachments: attachments,
 		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		err = c.hub.storage.SaveMessage(ctx, message)
+		message, err = c.hub.storage.SaveMessage(ctx, message)
 		cancel()
 
 		if err != nil {
@@ -143,16 +228,30 @@ func (c *Client) readPump() {
 			continue
 		}
 
-		response := domain.MessageResponse{
-			ID:        message.ID,
-			BoardID:   message.BoardID,
-			UserID:    message.UserID,
-			Username:  message.Username,
-			Content:   message.Content,
-			CreatedAt: message.CreatedAt,
+		if len(attachments) > 0 {
+			if err := c.hub.attachments.LinkMessageAttachments(c.userID, c.boardID, message.ID, msg.AttachmentIDs); err != nil {
+				c.hub.logger.Errorf("Failed to link attachments to message %s: %v", message.ID, err)
+			}
+		}
+
+		c.hub.broadcast <- messageResponse(message)
+		if c.hub.listener != nil {
+			c.hub.listener.MessagePosted(message)
 		}
+	}
+}
 
-		c.hub.broadcast <- response
+func messageResponse(message domain.Message) domain.MessageResponse {
+	return domain.MessageResponse{
+		Type:        domain.MessageTypeChat,
+		ID:          message.ID,
+		WorkspaceID: message.WorkspaceID,
+		BoardID:     message.BoardID,
+		UserID:      message.UserID,
+		Username:    message.Username,
+		Content:     message.Content,
+		Attachments: message.Attachments,
+		CreatedAt:   message.CreatedAt,
 	}
 }
 
@@ -197,7 +296,7 @@ func (c *Client) writePump() {
 	}
 }
 
-func (h *Hub) messageToJSON(msg domain.MessageResponse) []byte {
+func (h *Hub) toJSON(msg interface{}) []byte {
 	jsonData, err := json.Marshal(msg)
 	if err != nil {
 		h.logger.Errorf("Failed to marshal message: %v", err)
@@ -205,4 +304,3 @@ func (h *Hub) messageToJSON(msg domain.MessageResponse) []byte {
 	}
 	return jsonData
 }
-
@@ -0,0 +1,41 @@
+// Package authtest provides a JWT manager backed by a throwaway key for
+// tests that issue or verify tokens.
+package authtest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/your-team/taskmanager-chat/backend/pkg/auth"
+)
+
+// NewManager returns a manager signing with a fresh Ed25519 key kept in a
+// temporary directory. Two managers never accept each other's tokens.
+func NewManager(t testing.TB) *auth.Manager {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, "test.pem"), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := auth.NewManager(auth.Options{KeysDir: dir, Issuer: "test", Audience: "test-api"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
@@ -1,49 +1,170 @@
 package auth
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"strconv"
 	"time"
-	
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
+type TokenType string
+
+const (
+	TokenTypeAccess  TokenType = "access"
+	TokenTypeRefresh TokenType = "refresh"
+	TokenTypeTemp    TokenType = "2fa_temp"
+	TokenTypeReset   TokenType = "password_reset"
+)
+
+var ErrWrongTokenType = errors.New("token type is not accepted here")
+
 type Claims struct {
-	UserID int64 `json:"user_id"`
+	UserID int64     `json:"user_id"`
+	Type   TokenType `json:"typ"`
+	// ImpersonatorID is set on access tokens an admin issued for another
+	// user, and names that admin.
+	ImpersonatorID int64 `json:"imp,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID int64, secret string, duration time.Duration) (string, error) {
+// Options configures a Manager. KeysDir and ActiveKeyID are passed to
+// LoadKeySet; Issuer and Audience are set on issued tokens and required on
+// parsed ones.
+type Options struct {
+	KeysDir     string
+	ActiveKeyID string
+	Issuer      string
+	Audience    string
+}
+
+type Manager struct {
+	keys     *KeySet
+	issuer   string
+	audience string
+}
+
+func NewManager(opts Options) (*Manager, error) {
+	keys, err := LoadKeySet(opts.KeysDir, opts.ActiveKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Manager{
+		keys:     keys,
+		issuer:   opts.Issuer,
+		audience: opts.Audience,
+	}, nil
+}
+
+func (m *Manager) GenerateToken(userID int64, typ TokenType, duration time.Duration) (string, error) {
+	return m.generate(userID, 0, typ, duration)
+}
+
+// GenerateImpersonationToken issues an access token for userID that records
+// the admin who requested it.
+func (m *Manager) GenerateImpersonationToken(userID, adminID int64, duration time.Duration) (string, error) {
+	return m.generate(userID, adminID, TokenTypeAccess, duration)
+}
+
+func (m *Manager) generate(userID, impersonatorID int64, typ TokenType, duration time.Duration) (string, error) {
+	jti, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
 	claims := Claims{
-		UserID: userID,
+		UserID:         userID,
+		Type:           typ,
+		ImpersonatorID: impersonatorID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
+			Issuer:    m.issuer,
+			Subject:   strconv.FormatInt(userID, 10),
+			Audience:  jwt.ClaimStrings{m.audience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        jti,
 		},
 	}
-	
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+
+	key := m.keys.Active()
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
 }
 
-func ParseToken(tokenString, secret string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secret), nil
-	})
-	
+// ParseToken verifies the token and only accepts it when it was issued as
+// the expected type, so a 2FA temp token or a refresh token can never be
+// used as a bearer access token and vice versa.
+func (m *Manager) ParseToken(tokenString string, expected TokenType) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, m.keyFunc,
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(m.issuer),
+		jwt.WithAudience(m.audience),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
 	if err != nil {
 		return nil, err
 	}
-	
-	if claims, ok := token.Claims.(*Claims); ok && token.Valid {
-		return claims, nil
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	if claims.ID == "" || claims.IssuedAt == nil {
+		return nil, errors.New("token is missing jti or iat")
 	}
-	
-	return nil, errors.New("invalid token")
+
+	if claims.Subject != strconv.FormatInt(claims.UserID, 10) {
+		return nil, errors.New("token subject does not match user")
+	}
+
+	if claims.Type != expected {
+		return nil, ErrWrongTokenType
+	}
+
+	return claims, nil
 }
 
-func ExtractUserIDFromToken(tokenString, secret string) (int64, error) {
-	claims, err := ParseToken(tokenString, secret)
+func (m *Manager) ExtractUserIDFromToken(tokenString string, expected TokenType) (int64, error) {
+	claims, err := m.ParseToken(tokenString, expected)
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 	return claims.UserID, nil
-}
\ No newline at end of file
+}
+
+func (m *Manager) JWKS() JWKS {
+	return m.keys.JWKS()
+}
+
+func (m *Manager) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, ok := token.Header["kid"].(string)
+	if !ok || kid == "" {
+		return nil, errors.New("token has no kid header")
+	}
+
+	key, ok := m.keys.Lookup(kid)
+	if !ok {
+		return nil, errors.New("unknown signing key " + kid)
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, errors.New("signing method does not match key " + kid)
+	}
+
+	return key.Public, nil
+}
+
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,58 @@
+package auth_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/your-team/taskmanager-chat/backend/pkg/auth"
+	"github.com/your-team/taskmanager-chat/backend/pkg/auth/authtest"
+)
+
+func TestParseTokenRejectsOtherTypes(t *testing.T) {
+	m := authtest.NewManager(t)
+	types := []auth.TokenType{auth.TokenTypeAccess, auth.TokenTypeRefresh, auth.TokenTypeTemp, auth.TokenTypeReset}
+
+	for _, issued := range types {
+		token, err := m.GenerateToken(42, issued, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, expected := range types {
+			claims, err := m.ParseToken(token, expected)
+			if issued == expected {
+				if err != nil {
+					t.Errorf("%s token parsed as %s: %v", issued, expected, err)
+				} else if claims.UserID != 42 {
+					t.Errorf("%s token has user %d, want 42", issued, claims.UserID)
+				}
+				continue
+			}
+			if !errors.Is(err, auth.ErrWrongTokenType) {
+				t.Errorf("%s token parsed as %s: got %v, want ErrWrongTokenType", issued, expected, err)
+			}
+		}
+	}
+}
+
+func TestParseTokenRejectsOtherManagers(t *testing.T) {
+	token, err := authtest.NewManager(t).GenerateToken(42, auth.TokenTypeAccess, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := authtest.NewManager(t).ParseToken(token, auth.TokenTypeAccess); err == nil {
+		t.Error("token signed with another key was accepted")
+	}
+}
+
+func TestParseTokenRejectsExpired(t *testing.T) {
+	m := authtest.NewManager(t)
+	token, err := m.GenerateToken(42, auth.TokenTypeAccess, -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.ParseToken(token, auth.TokenTypeAccess); err == nil {
+		t.Error("expired token was accepted")
+	}
+}
@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key is a single entry of the key set. Private is nil for keys that are
+// only kept around to verify tokens issued before a rotation.
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+type KeySet struct {
+	active *Key
+	keys   map[string]*Key
+}
+
+// LoadKeySet reads every *.pem file in dir. The file name without extension
+// becomes the kid, so "2024-06.pem" and "2024-06.pub.pem" both map to
+// "2024-06". Private keys may be PKCS#8 (RSA or Ed25519) or PKCS#1 RSA,
+// public keys PKIX. activeID selects the signing key; it may be empty when
+// the directory holds exactly one private key.
+func LoadKeySet(dir, activeID string) (*KeySet, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	set := &KeySet{keys: make(map[string]*Key)}
+	var private []string
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		kid := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(file), ".pem"), ".pub")
+		key, err := parseKey(kid, data)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", file, err)
+		}
+
+		if existing, ok := set.keys[kid]; ok && existing.Private != nil {
+			continue
+		}
+		set.keys[kid] = key
+		if key.Private != nil {
+			private = append(private, kid)
+		}
+	}
+
+	if activeID == "" {
+		if len(private) != 1 {
+			return nil, fmt.Errorf("expected exactly one private key in %s when no active key id is set, found %d", dir, len(private))
+		}
+		activeID = private[0]
+	}
+
+	active, ok := set.keys[activeID]
+	if !ok || active.Private == nil {
+		return nil, fmt.Errorf("active signing key %q not found", activeID)
+	}
+	set.active = active
+
+	return set, nil
+}
+
+func (s *KeySet) Active() *Key {
+	return s.active
+}
+
+func (s *KeySet) Lookup(kid string) (*Key, bool) {
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func parseKey(kid string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var raw interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		raw, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		raw, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		raw, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := raw.(type) {
+	case *rsa.PrivateKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodRS256, Private: k, Public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodRS256, Public: k}, nil
+	case ed25519.PrivateKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodEdDSA, Private: k, Public: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodEdDSA, Public: k}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", raw)
+	}
+}
+
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public half of every key, suitable for serving from
+// /.well-known/jwks.json.
+func (s *KeySet) JWKS() JWKS {
+	ids := make([]string, 0, len(s.keys))
+	for kid := range s.keys {
+		ids = append(ids, kid)
+	}
+	sort.Strings(ids)
+
+	set := JWKS{Keys: make([]JWK, 0, len(ids))}
+	for _, kid := range ids {
+		key := s.keys[kid]
+		jwk := JWK{Kid: kid, Use: "sig", Alg: key.Method.Alg()}
+
+		switch pub := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
@@ -0,0 +1,11 @@
+package auth
+
+import "strings"
+
+// PersonalTokenPrefix marks personal access tokens so they can be told apart
+// from JWTs without trying to parse them.
+const PersonalTokenPrefix = "tmc_pat_"
+
+func IsPersonalToken(token string) bool {
+	return strings.HasPrefix(token, PersonalTokenPrefix)
+}
@@ -0,0 +1,52 @@
+package captcha
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Verifier checks CAPTCHA responses against a siteverify endpoint. hCaptcha,
+// reCAPTCHA and Turnstile all accept the same form fields.
+type Verifier struct {
+	verifyURL string
+	secret    string
+	client    *http.Client
+}
+
+func NewVerifier(verifyURL, secret string) *Verifier {
+	return &Verifier{
+		verifyURL: verifyURL,
+		secret:    secret,
+		client:    &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (v *Verifier) Verify(token, ip string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	form := url.Values{}
+	form.Set("secret", v.secret)
+	form.Set("response", token)
+	if ip != "" {
+		form.Set("remoteip", ip)
+	}
+
+	resp, err := v.client.PostForm(v.verifyURL, form)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, err
+	}
+
+	return result.Success, nil
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"sync"
+	"time"
 
 	"github.com/your-team/taskmanager-chat/backend/pkg/logging"
 
@@ -12,6 +13,13 @@ type Config struct {
 	Env string `yml:"env" env-default:"development"`
 	StorageConfig
 	MongoConfig
+	JWTConfig
+	LoginProtectionConfig
+	ProfileConfig
+	AccountConfig
+	WorkspaceConfig
+	TaskConfig
+	AttachmentConfig
 }
 
 type StorageConfig struct {
@@ -30,6 +38,73 @@ type MongoConfig struct {
 	Password string `yaml:"password" env:"MONGO_PASSWORD" env-default:"password"`
 }
 
+type JWTConfig struct {
+	JWTIssuer      string `yaml:"jwt_issuer" env:"JWT_ISSUER" env-default:"taskmanager-chat"`
+	JWTAudience    string `yaml:"jwt_audience" env:"JWT_AUDIENCE" env-default:"taskmanager-chat-api"`
+	JWTKeysDir     string `yaml:"jwt_keys_dir" env:"JWT_KEYS_DIR" env-default:"/keys"`
+	JWTActiveKeyID string `yaml:"jwt_active_key_id" env:"JWT_ACTIVE_KEY_ID"`
+}
+
+type LoginProtectionConfig struct {
+	LoginFailureWindow      time.Duration `yaml:"login_failure_window" env:"LOGIN_FAILURE_WINDOW" env-default:"15m"`
+	LoginMaxAccountFailures int           `yaml:"login_max_account_failures" env:"LOGIN_MAX_ACCOUNT_FAILURES" env-default:"5"`
+	LoginMaxIPFailures      int           `yaml:"login_max_ip_failures" env:"LOGIN_MAX_IP_FAILURES" env-default:"20"`
+	LoginCaptchaAfter       int           `yaml:"login_captcha_after" env:"LOGIN_CAPTCHA_AFTER" env-default:"3"`
+	LoginBaseLockout        time.Duration `yaml:"login_base_lockout" env:"LOGIN_BASE_LOCKOUT" env-default:"1m"`
+	LoginMaxLockout         time.Duration `yaml:"login_max_lockout" env:"LOGIN_MAX_LOCKOUT" env-default:"24h"`
+	CaptchaVerifyURL        string        `yaml:"captcha_verify_url" env:"CAPTCHA_VERIFY_URL" env-default:"https://hcaptcha.com/siteverify"`
+	CaptchaSecret           string        `yaml:"captcha_secret" env:"CAPTCHA_SECRET"`
+	AdminAPIToken           string        `yaml:"admin_api_token" env:"ADMIN_API_TOKEN"`
+}
+
+type ProfileConfig struct {
+	AvatarDir      string `yaml:"avatar_dir" env:"AVATAR_DIR" env-default:"/data/avatars"`
+	AvatarURLPath  string `yaml:"avatar_url_path" env:"AVATAR_URL_PATH" env-default:"/avatars"`
+	AvatarMaxBytes int64  `yaml:"avatar_max_bytes" env:"AVATAR_MAX_BYTES" env-default:"5242880"`
+	AvatarSize     int    `yaml:"avatar_size" env:"AVATAR_SIZE" env-default:"256"`
+}
+
+type AccountConfig struct {
+	AccountDeletionGrace time.Duration `yaml:"account_deletion_grace" env:"ACCOUNT_DELETION_GRACE" env-default:"720h"`
+}
+
+type WorkspaceConfig struct {
+	WorkspaceInvitationTTL time.Duration `yaml:"workspace_invitation_ttl" env:"WORKSPACE_INVITATION_TTL" env-default:"168h"`
+	WorkspaceInvitationURL string        `yaml:"workspace_invitation_url" env:"WORKSPACE_INVITATION_URL" env-default:"http://localhost:5173/invitations"`
+	BoardInviteTTL         time.Duration `yaml:"board_invite_ttl" env:"BOARD_INVITE_TTL" env-default:"168h"`
+	BoardInviteMaxTTL      time.Duration `yaml:"board_invite_max_ttl" env:"BOARD_INVITE_MAX_TTL" env-default:"720h"`
+	BoardInviteURL         string        `yaml:"board_invite_url" env:"BOARD_INVITE_URL" env-default:"http://localhost:5173/invites"`
+}
+
+// TaskConfig controls the background job that rewrites task positions once
+// their lexorank keys grow longer than TaskPositionMaxLength.
+type TaskConfig struct {
+	TaskRebalanceInterval time.Duration `yaml:"task_rebalance_interval" env:"TASK_REBALANCE_INTERVAL" env-default:"10m"`
+	TaskPositionMaxLength int           `yaml:"task_position_max_length" env:"TASK_POSITION_MAX_LENGTH" env-default:"24"`
+}
+
+// AttachmentConfig selects where uploaded files are kept ("local" or "s3")
+// and limits what may be uploaded. Content types are sniffed from the file,
+// not taken from the client. Download links are signed with
+// AttachmentURLSecret and expire after AttachmentURLTTL.
+type AttachmentConfig struct {
+	AttachmentStore         string        `yaml:"attachment_store" env:"ATTACHMENT_STORE" env-default:"local"`
+	AttachmentDir           string        `yaml:"attachment_dir" env:"ATTACHMENT_DIR" env-default:"/data/attachments"`
+	AttachmentMaxBytes      int64         `yaml:"attachment_max_bytes" env:"ATTACHMENT_MAX_BYTES" env-default:"26214400"`
+	AttachmentAllowedTypes  []string      `yaml:"attachment_allowed_types" env:"ATTACHMENT_ALLOWED_TYPES" env-separator:"," env-default:"image/jpeg,image/png,image/gif,image/webp,application/pdf,text/plain,application/zip"`
+	AttachmentThumbnailSize int           `yaml:"attachment_thumbnail_size" env:"ATTACHMENT_THUMBNAIL_SIZE" env-default:"320"`
+	AttachmentURLPath       string        `yaml:"attachment_url_path" env:"ATTACHMENT_URL_PATH" env-default:"/api/attachments"`
+	AttachmentURLSecret     string        `yaml:"attachment_url_secret" env:"ATTACHMENT_URL_SECRET"`
+	AttachmentURLTTL        time.Duration `yaml:"attachment_url_ttl" env:"ATTACHMENT_URL_TTL" env-default:"15m"`
+	AttachmentOrphanTTL     time.Duration `yaml:"attachment_orphan_ttl" env:"ATTACHMENT_ORPHAN_TTL" env-default:"24h"`
+	AttachmentS3Endpoint    string        `yaml:"attachment_s3_endpoint" env:"ATTACHMENT_S3_ENDPOINT"`
+	AttachmentS3Region      string        `yaml:"attachment_s3_region" env:"ATTACHMENT_S3_REGION" env-default:"us-east-1"`
+	AttachmentS3Bucket      string        `yaml:"attachment_s3_bucket" env:"ATTACHMENT_S3_BUCKET"`
+	AttachmentS3AccessKey   string        `yaml:"attachment_s3_access_key" env:"ATTACHMENT_S3_ACCESS_KEY"`
+	AttachmentS3SecretKey   string        `yaml:"attachment_s3_secret_key" env:"ATTACHMENT_S3_SECRET_KEY"`
+	AttachmentS3PathStyle   bool          `yaml:"attachment_s3_path_style" env:"ATTACHMENT_S3_PATH_STYLE" env-default:"true"`
+}
+
 var instance *Config
 var once sync.Once
 
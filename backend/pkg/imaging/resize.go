@@ -0,0 +1,87 @@
+package imaging
+
+import (
+	"image"
+	"image/color"
+)
+
+// Square center-crops src to a square and scales it to size x size.
+func Square(src image.Image, size int) *image.RGBA {
+	b := src.Bounds()
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+	return resize(src, image.Rect(x0, y0, x0+side, y0+side), size, size)
+}
+
+// Fit scales src down so that it fits into maxW x maxH while keeping the
+// aspect ratio. Images that already fit are only copied.
+func Fit(src image.Image, maxW, maxH int) *image.RGBA {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	if w > maxW {
+		h = h * maxW / w
+		w = maxW
+	}
+	if h > maxH {
+		w = w * maxH / h
+		h = maxH
+	}
+	if w < 1 {
+		w = 1
+	}
+	if h < 1 {
+		h = 1
+	}
+
+	return resize(src, b, w, h)
+}
+
+// resize maps the crop rectangle of src onto a w x h image. Every target
+// pixel is the average of the source pixels it covers, which gives a clean
+// result when shrinking; when enlarging it degrades to nearest neighbour.
+func resize(src image.Image, crop image.Rectangle, w, h int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		sy0 := crop.Min.Y + y*crop.Dy()/h
+		sy1 := crop.Min.Y + (y+1)*crop.Dy()/h
+		if sy1 <= sy0 {
+			sy1 = sy0 + 1
+		}
+
+		for x := 0; x < w; x++ {
+			sx0 := crop.Min.X + x*crop.Dx()/w
+			sx1 := crop.Min.X + (x+1)*crop.Dx()/w
+			if sx1 <= sx0 {
+				sx1 = sx0 + 1
+			}
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,118 @@
+// Package lexorank generates string keys that sort between two existing keys,
+// so an item can be moved by rewriting only its own key. Keys are base-36
+// fractions (digits 0-9a-z, compared bytewise) that never end in '0'; the
+// database column holding them must use the "C" collation.
+package lexorank
+
+import (
+	"errors"
+	"strings"
+)
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+var (
+	ErrInvalidKey   = errors.New("lexorank: invalid key")
+	ErrInvalidRange = errors.New("lexorank: lower key must sort before upper key")
+)
+
+// Between returns a key that sorts strictly after a and strictly before b.
+// An empty a means "before everything", an empty b "after everything".
+func Between(a, b string) (string, error) {
+	if !Valid(a) && a != "" || !Valid(b) && b != "" {
+		return "", ErrInvalidKey
+	}
+	if b != "" && a >= b {
+		return "", ErrInvalidRange
+	}
+	return midpoint(a, b), nil
+}
+
+// Spread returns n keys in ascending order, spaced evenly with room for
+// roughly one digit's worth of inserts between neighbours. It is used to
+// rebalance lists whose keys have grown long.
+func Spread(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	width, size := 1, base
+	for size < (n+1)*base {
+		width++
+		size *= base
+	}
+	step := size / (n + 1)
+
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = encode((i+1)*step, width)
+	}
+	return keys
+}
+
+// Valid reports whether key is a well-formed, non-empty key.
+func Valid(key string) bool {
+	if key == "" || key[len(key)-1] == digits[0] {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if strings.IndexByte(digits, key[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func midpoint(a, b string) string {
+	if b != "" {
+		n := 0
+		for n < len(b) && digitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			if n > len(a) {
+				a = ""
+			} else {
+				a = a[n:]
+			}
+			return b[:n] + midpoint(a, b[n:])
+		}
+	}
+
+	lo, hi := 0, base
+	if a != "" {
+		lo = strings.IndexByte(digits, a[0])
+	}
+	if b != "" {
+		hi = strings.IndexByte(digits, b[0])
+	}
+
+	if hi-lo > 1 {
+		return string(digits[(lo+hi)/2])
+	}
+	if len(b) > 1 {
+		return b[:1]
+	}
+	if a != "" {
+		a = a[1:]
+	}
+	return string(digits[lo]) + midpoint(a, "")
+}
+
+func digitAt(key string, i int) byte {
+	if i < len(key) {
+		return key[i]
+	}
+	return digits[0]
+}
+
+func encode(v, width int) string {
+	buf := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		buf[i] = digits[v%base]
+		v /= base
+	}
+	return strings.TrimRight(string(buf), digits[:1])
+}
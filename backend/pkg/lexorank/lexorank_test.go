@@ -0,0 +1,125 @@
+package lexorank
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func checkBetween(t *testing.T, a, b string) string {
+	t.Helper()
+
+	key, err := Between(a, b)
+	if err != nil {
+		t.Fatalf("Between(%q, %q): %v", a, b, err)
+	}
+	if !Valid(key) {
+		t.Fatalf("Between(%q, %q) = %q, not a valid key", a, b, key)
+	}
+	if a != "" && key <= a || b != "" && key >= b {
+		t.Fatalf("Between(%q, %q) = %q, not strictly between", a, b, key)
+	}
+	return key
+}
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{"", "", "i"},
+		{"", "i", "9"},
+		{"i", "", "r"},
+		{"a", "b", "ai"},
+		{"a", "a1", "a0i"},
+		{"", "01", "00i"},
+		{"y", "z", "yi"},
+		{"z", "", "zi"},
+		{"az", "b", "azi"},
+		{"a1", "a2", "a1i"},
+	}
+	for _, tt := range tests {
+		if got := checkBetween(t, tt.a, tt.b); got != tt.want {
+			t.Errorf("Between(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestBetweenErrors(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want error
+	}{
+		{"b", "a", ErrInvalidRange},
+		{"a", "a", ErrInvalidRange},
+		{"a0", "", ErrInvalidKey},
+		{"", "A", ErrInvalidKey},
+		{"a-", "b", ErrInvalidKey},
+	}
+	for _, tt := range tests {
+		if _, err := Between(tt.a, tt.b); !errors.Is(err, tt.want) {
+			t.Errorf("Between(%q, %q) error = %v, want %v", tt.a, tt.b, err, tt.want)
+		}
+	}
+}
+
+// TestBetweenRepeated inserts at random places in a growing list and checks
+// that every key lands strictly between its neighbours.
+func TestBetweenRepeated(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	keys := []string{}
+
+	for i := 0; i < 2000; i++ {
+		at := r.Intn(len(keys) + 1)
+		var a, b string
+		if at > 0 {
+			a = keys[at-1]
+		}
+		if at < len(keys) {
+			b = keys[at]
+		}
+		key := checkBetween(t, a, b)
+		keys = append(keys[:at], append([]string{key}, keys[at:]...)...)
+	}
+
+	if !sort.StringsAreSorted(keys) {
+		t.Fatal("keys are out of order")
+	}
+}
+
+// TestBetweenSameEnd keeps inserting just before the same key, the worst
+// case for key length.
+func TestBetweenSameEnd(t *testing.T) {
+	a, b := "", "i"
+	for i := 0; i < 200; i++ {
+		b = checkBetween(t, a, b)
+	}
+	a, b = "i", ""
+	for i := 0; i < 200; i++ {
+		a = checkBetween(t, a, b)
+	}
+}
+
+func TestSpread(t *testing.T) {
+	for _, n := range []int{1, 2, 35, 36, 100, 1295, 1296, 5000} {
+		keys := Spread(n)
+		if len(keys) != n {
+			t.Fatalf("Spread(%d) returned %d keys", n, len(keys))
+		}
+		for i, key := range keys {
+			if !Valid(key) {
+				t.Fatalf("Spread(%d)[%d] = %q, not a valid key", n, i, key)
+			}
+			if i > 0 && keys[i-1] >= key {
+				t.Fatalf("Spread(%d): %q does not sort before %q", n, keys[i-1], key)
+			}
+		}
+		checkBetween(t, "", keys[0])
+		checkBetween(t, keys[n-1], "")
+	}
+
+	if keys := Spread(0); keys != nil {
+		t.Errorf("Spread(0) = %v, want nil", keys)
+	}
+}
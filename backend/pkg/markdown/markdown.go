@@ -0,0 +1,234 @@
+// Package markdown cleans user-written markdown before it is stored. Clients
+// render the source themselves; Sanitize makes sure it cannot carry raw HTML
+// or script URLs into that rendering. Code spans and fenced code blocks are
+// left untouched, since renderers escape their contents anyway.
+package markdown
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// container matches the indentation, block quote markers and list
+	// markers, however deeply nested, that a line can open with.
+	container = `(?:[ \t]*(?:>[ \t]?|[-*+][ \t]+|\d{1,9}[.)][ \t]+))*[ \t]*`
+	// label matches a link label and the colon of a definition. The opening
+	// bracket may be missing when the label began on an earlier line or
+	// before a code span.
+	label = `(?:\[(?:\\.|[^\\\[\]])+|(?:\\.|[^\\\[\]])*)\]:`
+)
+
+var (
+	// inlineLink matches the destination of [text](dest) and ![alt](dest).
+	inlineLink = regexp.MustCompile(`\]\(\s*(<[^>]*>|(?:[^\s()]|\([^\s()]*\))*)`)
+	// referenceLink matches the destination of a [label]: dest definition.
+	referenceLink = regexp.MustCompile(`^(` + container + label + `\s*)(<[^>]*>|\S*)`)
+	// danglingLink matches a link whose destination would start on the next
+	// line, out of reach of the patterns above.
+	danglingLink = regexp.MustCompile(`(\]\(|^` + container + label + `)\s*$`)
+	mention      = regexp.MustCompile(`(^|[^\w@.-])@([a-zA-Z0-9_.-]{3,50})`)
+)
+
+var safeSchemes = []string{"http://", "https://", "mailto:"}
+
+// Sanitize returns src with raw HTML escaped and unsafe link destinations
+// replaced by "#". Line endings are normalised and control characters other
+// than tabs and newlines are dropped.
+func Sanitize(src string) string {
+	src = strings.ToValidUTF8(src, "")
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.Map(func(r rune) rune {
+		if r != '\n' && r != '\t' && unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, src)
+
+	return mapText(src, func(text string) string {
+		text = referenceLink.ReplaceAllStringFunc(text, func(m string) string {
+			parts := referenceLink.FindStringSubmatch(m)
+			if safeURL(parts[2]) {
+				return m
+			}
+			return parts[1] + "#"
+		})
+		text = inlineLink.ReplaceAllStringFunc(text, func(m string) string {
+			if safeURL(inlineLink.FindStringSubmatch(m)[1]) {
+				return m
+			}
+			return "](#"
+		})
+		text = danglingLink.ReplaceAllString(text, "${0}#")
+		return strings.ReplaceAll(text, "<", "&lt;")
+	})
+}
+
+// Mentions returns the distinct usernames mentioned as @username outside of
+// code, lower-cased and in order of first appearance.
+func Mentions(src string) []string {
+	var names []string
+	seen := make(map[string]bool)
+
+	mapText(src, func(text string) string {
+		for _, m := range mention.FindAllStringSubmatch(text, -1) {
+			name := strings.ToLower(strings.TrimRight(m[2], ".-"))
+			if len(name) < 3 || seen[name] {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+		return text
+	})
+	return names
+}
+
+// safeURL allows web and mail links and relative links without a scheme.
+// Anything else, including entity-encoded schemes, is refused.
+func safeURL(dest string) bool {
+	dest = strings.ToLower(strings.Trim(strings.TrimSpace(dest), "<>"))
+	for _, scheme := range safeSchemes {
+		if strings.HasPrefix(dest, scheme) {
+			return true
+		}
+	}
+	return !strings.ContainsAny(dest, ":&")
+}
+
+// mapText applies fn to every piece of src that is not code and returns the
+// result. Code is a fenced block or a code span closed on the same line.
+//
+// Only fences that start at the margin are trusted. An indented fence may
+// belong to a list item and end with it, which cannot be told without
+// parsing the lists, so from the first one on everything is treated as text.
+func mapText(src string, fn func(string) string) string {
+	lines := strings.Split(src, "\n")
+	fence := ""
+	for i, line := range lines {
+		if fence != "" {
+			if closesFence(line, fence) {
+				fence = ""
+			}
+			continue
+		}
+
+		marker, indent := fenceMarker(line)
+		switch {
+		case marker == "":
+			lines[i] = mapLine(line, fn)
+		case indent == 0:
+			fence = marker
+		default:
+			for j := i; j < len(lines); j++ {
+				lines[j] = mapLine(lines[j], fn)
+			}
+			return strings.Join(lines, "\n")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fenceMarker returns the run of backticks or tildes opening a fenced code
+// block on line and how far it is indented, or "". A backtick fence cannot
+// have a backtick in its info string.
+func fenceMarker(line string) (string, int) {
+	trimmed := strings.TrimLeft(line, " ")
+	indent := len(line) - len(trimmed)
+	if indent > 3 || len(trimmed) < 3 {
+		return "", 0
+	}
+	c := trimmed[0]
+	if c != '`' && c != '~' {
+		return "", 0
+	}
+	n := 0
+	for n < len(trimmed) && trimmed[n] == c {
+		n++
+	}
+	if n < 3 || c == '`' && strings.IndexByte(trimmed[n:], '`') >= 0 {
+		return "", 0
+	}
+	return trimmed[:n], indent
+}
+
+// closesFence reports whether line closes the block opened by fence: a run
+// of the same character at least as long, followed by nothing but spaces
+// and tabs.
+func closesFence(line, fence string) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return false
+	}
+	n := 0
+	for n < len(trimmed) && trimmed[n] == fence[0] {
+		n++
+	}
+	return n >= len(fence) && strings.Trim(trimmed[n:], " \t") == ""
+}
+
+// mapLine applies fn to the parts of line outside code spans. A code span
+// opened by n backticks ends at the next run of exactly n backticks; a
+// backslash-escaped backtick opens nothing.
+func mapLine(line string, fn func(string) string) string {
+	var b strings.Builder
+	for i := 0; i < len(line); {
+		if line[i] != '`' {
+			j := nextBacktick(line, i)
+			b.WriteString(fn(line[i:j]))
+			i = j
+			continue
+		}
+
+		n := runLength(line, i)
+		end := -1
+		for j := i + n; j < len(line); {
+			if line[j] != '`' {
+				j++
+				continue
+			}
+			m := runLength(line, j)
+			if m == n {
+				end = j + m
+				break
+			}
+			j += m
+		}
+		if end < 0 {
+			b.WriteString(line[i : i+n])
+			i += n
+			continue
+		}
+		b.WriteString(line[i:end])
+		i = end
+	}
+	return b.String()
+}
+
+// nextBacktick returns the index of the first backtick at or after i that
+// is not escaped by a backslash, or len(line). Backslashes before i belong
+// to a code span or an unmatched run and escape nothing.
+func nextBacktick(line string, i int) int {
+	for j := i; j < len(line); j++ {
+		if line[j] != '`' {
+			continue
+		}
+		k := j
+		for k > i && line[k-1] == '\\' {
+			k--
+		}
+		if (j-k)%2 == 0 {
+			return j
+		}
+	}
+	return len(line)
+}
+
+func runLength(line string, i int) int {
+	n := 0
+	for i+n < len(line) && line[i+n] == '`' {
+		n++
+	}
+	return n
+}
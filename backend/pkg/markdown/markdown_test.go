@@ -0,0 +1,86 @@
+package markdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"raw html", "<img src=x onerror=alert(1)>", "&lt;img src=x onerror=alert(1)>"},
+		{"autolink", "<javascript:alert(1)>", "&lt;javascript:alert(1)>"},
+		{"crlf and controls", "a\r\nb\x00\x07c\td", "a\nbc\td"},
+
+		{"web link", "[a](https://example.com/x)", "[a](https://example.com/x)"},
+		{"mail link", "[a](mailto:me@example.com)", "[a](mailto:me@example.com)"},
+		{"relative link", "[a](/boards/1) ![b](img.png)", "[a](/boards/1) ![b](img.png)"},
+		{"javascript link", "[a](javascript:alert(1))", "[a](#)"},
+		{"upper-case scheme", "[a](JavaScript:alert(1))", "[a](#)"},
+		{"angle destination", "[a](<javascript:alert(1)>)", "[a](#)"},
+		{"image", "![a](data:image/svg+xml;base64,PHN2Zz4=)", "![a](#)"},
+		{"entity scheme", "[a](javascript&#58;alert(1))", "[a](#)"},
+		{"entity letter", "[a](&#106;avascript:alert(1))", "[a](#)"},
+		{"reference", "[a]: javascript:alert(1)", "[a]: #"},
+		{"safe reference", "[a]: https://example.com", "[a]: https://example.com"},
+		{"destination on next line", "[a](\njavascript:alert(1))", "[a](#\njavascript:alert(1))"},
+		{"reference on next line", "[a]:\njavascript:alert(1)", "[a]:#\njavascript:alert(1)"},
+		{"reference in block quote", "> [x]: javascript:alert(1)\n\n[x]", "> [x]: #\n\n[x]"},
+		{"reference in list item", "- [x]: javascript:alert(1)\n\n[x]", "- [x]: #\n\n[x]"},
+		{"reference in ordered list", "1) [x]: javascript:alert(1)", "1) [x]: #"},
+		{"reference in nested containers", "> 1. >- * [x]: javascript:alert(1)", "> 1. >- * [x]: #"},
+		{"reference in list continuation", "- a\n  - b\n\n        [x]: javascript:alert(1)", "- a\n  - b\n\n        [x]: #"},
+		{"safe reference in block quote", "> [x]: https://example.com", "> [x]: https://example.com"},
+		{"quoted reference on next line", "> [x]:\n> javascript:alert(1)", "> [x]:#\n> javascript:alert(1)"},
+		{"escaped bracket in label", "[a\\]b]: javascript:alert(1)", "[a\\]b]: #"},
+		{"label across lines", "[a\nb]: javascript:alert(1)", "[a\nb]: #"},
+		{"code span in label", "[`a`]: javascript:alert(1)", "[`a`]: #"},
+
+		{"code span", "`<b>` and <b>", "`<b>` and &lt;b>"},
+		{"double code span", "``a`<b>`` <b>", "``a`<b>`` &lt;b>"},
+		{"unclosed code span", "`<b>", "`&lt;b>"},
+		{"escaped backtick", "\\`<img src=x onerror=alert(1)>\\`", "\\`&lt;img src=x onerror=alert(1)>\\`"},
+		{"escaped backslash", "\\\\`<b>`", "\\\\`<b>`"},
+		{"link in code span", "`[a](javascript:x)`", "`[a](javascript:x)`"},
+
+		{"fence", "```\n<b>\n```\n<b>", "```\n<b>\n```\n&lt;b>"},
+		{"tilde fence", "~~~ html\n<b>\n~~~\n<b>", "~~~ html\n<b>\n~~~\n&lt;b>"},
+		{"longer closing fence", "```\n<b>\n`````\n<b>", "```\n<b>\n`````\n&lt;b>"},
+		{"short closing fence", "````\n```\n<b>\n````", "````\n```\n<b>\n````"},
+		{"unclosed fence", "```\n<b>", "```\n<b>"},
+		{"backtick in info string", "``` a`b\n<img src=x onerror=alert(1)>", "``` a`b\n&lt;img src=x onerror=alert(1)>"},
+		{"text after closing fence", "```\n<b>\n``` x\n<b>", "```\n<b>\n``` x\n<b>"},
+		{"indented code", "    ```\n<b>", "    ```\n&lt;b>"},
+		{"fence in list item", "- a\n\n  ```\n```\n<b>\n```\n<img src=x onerror=alert(1)>",
+			"- a\n\n  ```\n```\n&lt;b>\n```\n&lt;img src=x onerror=alert(1)>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.src); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMentions(t *testing.T) {
+	tests := []struct {
+		src  string
+		want []string
+	}{
+		{"hi @Alice and @bob.", []string{"alice", "bob"}},
+		{"@alice @ALICE", []string{"alice"}},
+		{"mail me@example.com, @al", nil},
+		{"`@alice` @bob", []string{"bob"}},
+		{"```\n@alice\n```\n@bob", []string{"bob"}},
+		{"\\`@alice\\`", []string{"alice"}},
+	}
+	for _, tt := range tests {
+		if got := Mentions(tt.src); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Mentions(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/pkg/auth"
+)
+
+type AdminChecker interface {
+	IsAdmin(userID int64) (bool, error)
+}
+
+// RequireAdmin guards the admin API. Operators authenticate with the static
+// token in the X-Admin-Token header (an empty token disables that path);
+// everybody else needs an interactive session of a user with the admin
+// role. Personal access tokens and impersonated sessions are never admins.
+func RequireAdmin(operatorToken string, tokens *auth.Manager, personal PersonalTokenAuthenticator, admins AdminChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if provided := c.GetHeader("X-Admin-Token"); provided != "" {
+			if operatorToken == "" || subtle.ConstantTimeCompare([]byte(provided), []byte(operatorToken)) != 1 {
+				abortForbidden(c)
+				return
+			}
+			c.Set("adminOperator", true)
+			c.Next()
+			return
+		}
+
+		if !authenticate(c, tokens, personal) {
+			return
+		}
+
+		_, isPersonal := c.Get("scopes")
+		_, isImpersonated := c.Get("impersonatorID")
+		if isPersonal || isImpersonated {
+			abortForbidden(c)
+			return
+		}
+
+		isAdmin, err := admins.IsAdmin(c.GetInt64("userID"))
+		if err != nil || !isAdmin {
+			abortForbidden(c)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func abortForbidden(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"error": "Forbidden",
+	})
+}
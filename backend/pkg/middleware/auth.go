@@ -1,57 +1,110 @@
 package middleware
 
 import (
-	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/your-team/taskmanager-chat/backend/pkg/auth"
 )
 
-func JWTAuthMiddleware(secret string) gin.HandlerFunc {
+type PersonalTokenAuthenticator interface {
+	AuthenticatePersonalToken(token, ip string) (int64, []string, error)
+}
+
+func JWTAuthMiddleware(tokens *auth.Manager, personal PersonalTokenAuthenticator) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		if authenticate(c, tokens, personal) {
+			c.Next()
+		}
+	}
+}
+
+// authenticate resolves the bearer token into the request context. On
+// failure it aborts the request and returns false.
+func authenticate(c *gin.Context, tokens *auth.Manager, personal PersonalTokenAuthenticator) bool {
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Authorization header is required",
+		})
+		return false
+	}
+
+	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
+
+	if auth.IsPersonalToken(tokenString) {
+		userID, scopes, err := personal.AuthenticatePersonalToken(tokenString, c.ClientIP())
+		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "Authorization header is required",
+				"error": "Invalid or expired token",
 			})
+			return false
+		}
+
+		c.Set("userID", userID)
+		c.Set("scopes", scopes)
+		return true
+	}
+
+	claims, err := tokens.ParseToken(tokenString, auth.TokenTypeAccess)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid or expired token",
+		})
+		return false
+	}
+
+	c.Set("userID", claims.UserID)
+	c.Set("token", tokenString)
+	c.Set("tokenID", claims.ID)
+	if claims.ImpersonatorID != 0 {
+		c.Set("impersonatorID", claims.ImpersonatorID)
+	}
+
+	return true
+}
+
+// RequireScope only lets personal access tokens through when they carry the
+// given scope. Interactive JWT sessions are not scope-limited.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scopes, ok := c.Get("scopes")
+		if !ok {
+			c.Next()
 			return
 		}
-		
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		fmt.Printf("DEBUG JWT MIDDLEWARE: Token: %s...\n", tokenString[:min(10, len(tokenString))])
-		
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return []byte(secret), nil
+
+		for _, s := range scopes.([]string) {
+			if s == scope {
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "Token is missing required scope: " + scope,
 		})
-		
-		if err != nil || token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid or expired token",
+	}
+}
+
+// RequireSession rejects personal access tokens, e.g. for managing the
+// tokens themselves, and impersonation tokens, so an admin cannot mint
+// credentials that outlive the impersonation.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("scopes"); ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "This endpoint requires an interactive session",
 			})
 			return
 		}
-		
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error": "Invalid token claims",
+		if _, ok := c.Get("impersonatorID"); ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "This endpoint is not available while impersonating a user",
 			})
 			return
 		}
-		
-		userID := int64(claims["user_id"].(float64))
-		c.Set("userID", userID)
-		c.Set("token", tokenString)
-		
 		c.Next()
 	}
 }
-
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
\ No newline at end of file
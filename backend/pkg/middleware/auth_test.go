@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-team/taskmanager-chat/backend/pkg/auth"
+	"github.com/your-team/taskmanager-chat/backend/pkg/auth/authtest"
+)
+
+type noPersonalTokens struct{}
+
+func (noPersonalTokens) AuthenticatePersonalToken(token, ip string) (int64, []string, error) {
+	return 0, nil, errors.New("no personal tokens")
+}
+
+func serve(handlers []gin.HandlerFunc, token string) int {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", append(handlers, func(c *gin.Context) { c.Status(http.StatusOK) })...)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestJWTAuthMiddlewareTokenTypes(t *testing.T) {
+	tokens := authtest.NewManager(t)
+	handlers := []gin.HandlerFunc{JWTAuthMiddleware(tokens, noPersonalTokens{})}
+
+	tests := []struct {
+		typ  auth.TokenType
+		want int
+	}{
+		{auth.TokenTypeAccess, http.StatusOK},
+		{auth.TokenTypeRefresh, http.StatusUnauthorized},
+		{auth.TokenTypeTemp, http.StatusUnauthorized},
+		{auth.TokenTypeReset, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		token, err := tokens.GenerateToken(42, tt.typ, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := serve(handlers, token); got != tt.want {
+			t.Errorf("%s token: got %d, want %d", tt.typ, got, tt.want)
+		}
+	}
+
+	if got := serve(handlers, ""); got != http.StatusUnauthorized {
+		t.Errorf("no token: got %d, want %d", got, http.StatusUnauthorized)
+	}
+}
+
+func TestRequireSessionRejectsImpersonation(t *testing.T) {
+	tokens := authtest.NewManager(t)
+	handlers := []gin.HandlerFunc{JWTAuthMiddleware(tokens, noPersonalTokens{}), RequireSession()}
+
+	token, err := tokens.GenerateToken(42, auth.TokenTypeAccess, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := serve(handlers, token); got != http.StatusOK {
+		t.Errorf("session token: got %d, want %d", got, http.StatusOK)
+	}
+
+	token, err = tokens.GenerateImpersonationToken(42, 7, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := serve(handlers, token); got != http.StatusForbidden {
+		t.Errorf("impersonation token: got %d, want %d", got, http.StatusForbidden)
+	}
+}
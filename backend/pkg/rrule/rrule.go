@@ -0,0 +1,478 @@
+// Package rrule computes the occurrences of a subset of RFC 5545 recurrence
+// rules: FREQ (DAILY, WEEKLY, MONTHLY or YEARLY), INTERVAL, COUNT, UNTIL,
+// BYDAY, BYMONTHDAY, BYMONTH and WKST. Occurrences keep the wall-clock time
+// of DTSTART in its location, so a rule starting at 09:00 Europe/Berlin stays
+// at 09:00 on both sides of a DST change. Dates that do not exist, such as
+// February 30th, are skipped. As in RFC 5545, a time that falls into a DST
+// gap moves forward by the length of the gap, and a time that occurs twice
+// is the first of the two.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+	Yearly  = "YEARLY"
+)
+
+// maxPeriods bounds the search for the next occurrence, so a rule that
+// matches nothing, like the 31st of every February, ends instead of looping.
+const maxPeriods = 100000
+
+var (
+	ErrInvalid     = errors.New("rrule: invalid rule")
+	ErrUnsupported = errors.New("rrule: unsupported rule part")
+)
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var weekdayNames = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// WeekdayNum is an entry of BYDAY. N picks the Nth such weekday of the month,
+// counting from the end when negative; 0 means every one.
+type WeekdayNum struct {
+	N   int
+	Day time.Weekday
+}
+
+// Rule is a parsed RRULE. Until is compared in the location of DTSTART when
+// the rule gave a date or a local time, and as an instant when it was UTC.
+type Rule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	WeekStart  time.Weekday
+
+	untilLocal bool
+	untilDate  bool
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=10". A leading
+// "RRULE:" is allowed and names are case-insensitive.
+func Parse(value string) (Rule, error) {
+	value = strings.TrimSpace(value)
+	if len(value) >= 6 && strings.EqualFold(value[:6], "RRULE:") {
+		value = value[6:]
+	}
+
+	r := Rule{Interval: 1, WeekStart: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ";") {
+		name, val, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		val = strings.ToUpper(strings.TrimSpace(val))
+		if !ok || name == "" || val == "" {
+			return Rule{}, fmt.Errorf("%w: %q is not NAME=VALUE", ErrInvalid, part)
+		}
+		if seen[name] {
+			return Rule{}, fmt.Errorf("%w: %s is given twice", ErrInvalid, name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			switch val {
+			case Daily, Weekly, Monthly, Yearly:
+				r.Freq = val
+			default:
+				err = fmt.Errorf("%w: FREQ=%s", ErrUnsupported, val)
+			}
+		case "INTERVAL":
+			r.Interval, err = positive(val)
+		case "COUNT":
+			r.Count, err = positive(val)
+		case "UNTIL":
+			err = r.parseUntil(val)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseByMonthDay(val)
+		case "BYMONTH":
+			r.ByMonth, err = parseByMonth(val)
+		case "WKST":
+			day, ok := weekdays[val]
+			if !ok {
+				err = fmt.Errorf("%w: WKST=%s", ErrInvalid, val)
+			}
+			r.WeekStart = day
+		default:
+			err = fmt.Errorf("%w: %s", ErrUnsupported, name)
+		}
+		if err != nil {
+			return Rule{}, err
+		}
+	}
+
+	if r.Freq == "" {
+		return Rule{}, fmt.Errorf("%w: FREQ is required", ErrInvalid)
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return Rule{}, fmt.Errorf("%w: COUNT and UNTIL cannot be combined", ErrInvalid)
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return Rule{}, fmt.Errorf("%w: BYMONTHDAY cannot be used with FREQ=WEEKLY", ErrInvalid)
+	}
+	for _, d := range r.ByDay {
+		if d.N == 0 {
+			continue
+		}
+		if r.Freq != Monthly && (r.Freq != Yearly || len(r.ByMonth) == 0) {
+			return Rule{}, fmt.Errorf("%w: numbered BYDAY needs FREQ=MONTHLY, or FREQ=YEARLY with BYMONTH", ErrUnsupported)
+		}
+	}
+	if r.Freq == Yearly && len(r.ByDay) > 0 && len(r.ByMonth) == 0 {
+		return Rule{}, fmt.Errorf("%w: BYDAY with FREQ=YEARLY needs BYMONTH", ErrUnsupported)
+	}
+	return r, nil
+}
+
+// String formats the rule in a canonical order, without the "RRULE:" prefix.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		switch {
+		case r.untilDate:
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+		case r.untilLocal:
+			parts = append(parts, "UNTIL="+r.Until.Format("20060102T150405"))
+		default:
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+		}
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = weekdayNames[d.Day]
+			if d.N != 0 {
+				days[i] = strconv.Itoa(d.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, d := range r.ByMonthDay {
+			days[i] = strconv.Itoa(d)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]string, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = strconv.Itoa(int(m))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(months, ","))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence strictly after after, for a series
+// starting at dtstart. As in RFC 5545, dtstart itself is the first occurrence
+// and counts towards COUNT. It returns false when the series has ended.
+func (r Rule) Next(dtstart, after time.Time) (time.Time, bool) {
+	loc := dtstart.Location()
+	until := r.Until
+	if r.untilLocal && !until.IsZero() {
+		until = time.Date(until.Year(), until.Month(), until.Day(), until.Hour(), until.Minute(), until.Second(), 0, loc)
+	}
+
+	n := 1
+	if dtstart.After(after) {
+		return dtstart, r.Until.IsZero() || !dtstart.After(until)
+	}
+
+	for period := 0; period < maxPeriods; period++ {
+		days := r.expand(dtstart, period)
+		if days == nil && r.beyond(dtstart, period) {
+			return time.Time{}, false
+		}
+		for _, day := range days {
+			t := wallTime(day, dtstart, loc)
+			if !t.After(dtstart) {
+				continue
+			}
+			if !until.IsZero() && t.After(until) {
+				return time.Time{}, false
+			}
+			n++
+			if r.Count > 0 && n > r.Count {
+				return time.Time{}, false
+			}
+			if t.After(after) {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// wallTime returns the time of day of clock on day in loc. time.Date picks
+// either side of a DST change for a time in a gap or in an overlap; here
+// both read with the offset in force before the change.
+func wallTime(day, clock time.Time, loc *time.Location) time.Time {
+	y, m, d := day.Date()
+	h, min, sec := clock.Clock()
+	t := time.Date(y, m, d, h, min, sec, 0, loc)
+
+	_, before := time.Date(y, m, d, h-12, min, sec, 0, loc).Zone()
+	early := time.Date(y, m, d, h, min, sec, 0, time.UTC).Add(-time.Duration(before) * time.Second).In(loc)
+	if !sameClock(t, h, min, sec) || sameClock(early, h, min, sec) && early.Before(t) {
+		return early
+	}
+	return t
+}
+
+func sameClock(t time.Time, hour, min, sec int) bool {
+	h, m, s := t.Clock()
+	return h == hour && m == min && s == sec
+}
+
+// beyond tells whether the period lies past the dates time.Date handles
+// sensibly, which ends the search.
+func (r Rule) beyond(dtstart time.Time, period int) bool {
+	return r.periodStart(dtstart, period).Year() > 9999
+}
+
+// periodStart returns the first day of the period'th period of the series,
+// as a UTC date.
+func (r Rule) periodStart(dtstart time.Time, period int) time.Time {
+	y, m, d := dtstart.Date()
+	step := period * r.Interval
+	switch r.Freq {
+	case Daily:
+		return time.Date(y, m, d+step, 0, 0, 0, 0, time.UTC)
+	case Weekly:
+		offset := (int(dtstart.Weekday()) - int(r.WeekStart) + 7) % 7
+		return time.Date(y, m, d-offset+7*step, 0, 0, 0, 0, time.UTC)
+	case Monthly:
+		return time.Date(y, m+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(y+step, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// expand lists the days of a period that match the rule, in order, as UTC
+// dates. It returns nil for a period with no matches.
+func (r Rule) expand(dtstart time.Time, period int) []time.Time {
+	start := r.periodStart(dtstart, period)
+	if start.Year() > 9999 {
+		return nil
+	}
+
+	var days []time.Time
+	switch r.Freq {
+	case Daily:
+		if r.matchesWeekday(start) && r.matchesMonthDay(start) {
+			days = []time.Time{start}
+		}
+	case Weekly:
+		for i := 0; i < 7; i++ {
+			day := start.AddDate(0, 0, i)
+			if len(r.ByDay) > 0 && r.matchesWeekday(day) || len(r.ByDay) == 0 && day.Weekday() == dtstart.Weekday() {
+				days = append(days, day)
+			}
+		}
+	case Monthly:
+		days = r.expandMonth(dtstart, start.Year(), start.Month())
+	case Yearly:
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []time.Month{dtstart.Month()}
+			if len(r.ByMonthDay) > 0 {
+				months = []time.Month{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+			}
+		}
+		for _, month := range months {
+			days = append(days, r.expandMonth(dtstart, start.Year(), month)...)
+		}
+	}
+
+	var matched []time.Time
+	for _, day := range days {
+		if len(r.ByMonth) == 0 || containsMonth(r.ByMonth, day.Month()) {
+			matched = append(matched, day)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Before(matched[j]) })
+	return matched
+}
+
+// expandMonth lists the days of a month picked by BYMONTHDAY and BYDAY. When
+// both are given a day must match both; with neither it is DTSTART's day of
+// the month, if the month has one.
+func (r Rule) expandMonth(dtstart time.Time, year int, month time.Month) []time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	length := first.AddDate(0, 1, -1).Day()
+
+	var days []time.Time
+	for d := 1; d <= length; d++ {
+		day := first.AddDate(0, 0, d-1)
+		switch {
+		case len(r.ByMonthDay) == 0 && len(r.ByDay) == 0:
+			if d != dtstart.Day() {
+				continue
+			}
+		case !r.matchesMonthDay(day) || !r.matchesNumberedWeekday(day, length):
+			continue
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+func (r Rule) matchesWeekday(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, d := range r.ByDay {
+		if d.Day == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesNumberedWeekday matches day against BYDAY within its month, which
+// has length days.
+func (r Rule) matchesNumberedWeekday(day time.Time, length int) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	fromStart := (day.Day()-1)/7 + 1
+	fromEnd := -((length-day.Day())/7 + 1)
+	for _, d := range r.ByDay {
+		if d.Day == day.Weekday() && (d.N == 0 || d.N == fromStart || d.N == fromEnd) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchesMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	length := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, d := range r.ByMonthDay {
+		if d == day.Day() || d < 0 && length+d+1 == day.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// parseUntil accepts a UTC time (20261231T170000Z), a local time
+// (20261231T170000) or a date, which includes the whole day.
+func (r *Rule) parseUntil(val string) error {
+	var err error
+	switch {
+	case strings.HasSuffix(val, "Z"):
+		r.Until, err = time.Parse("20060102T150405Z", val)
+	case strings.Contains(val, "T"):
+		r.Until, err = time.Parse("20060102T150405", val)
+		r.untilLocal = true
+	default:
+		r.Until, err = time.Parse("20060102", val)
+		r.Until = r.Until.Add(24*time.Hour - time.Second)
+		r.untilLocal, r.untilDate = true, true
+	}
+	if err != nil {
+		return fmt.Errorf("%w: UNTIL=%s", ErrInvalid, val)
+	}
+	return nil
+}
+
+func parseByDay(val string) ([]WeekdayNum, error) {
+	var days []WeekdayNum
+	for _, item := range strings.Split(val, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("%w: BYDAY=%s", ErrInvalid, val)
+		}
+		day, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("%w: BYDAY=%s", ErrInvalid, val)
+		}
+		n := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			var err error
+			n, err = strconv.Atoi(strings.TrimPrefix(prefix, "+"))
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("%w: BYDAY=%s", ErrInvalid, val)
+			}
+		}
+		days = append(days, WeekdayNum{N: n, Day: day})
+	}
+	return days, nil
+}
+
+func parseByMonthDay(val string) ([]int, error) {
+	var days []int
+	for _, item := range strings.Split(val, ",") {
+		d, err := strconv.Atoi(item)
+		if err != nil || d == 0 || d < -31 || d > 31 {
+			return nil, fmt.Errorf("%w: BYMONTHDAY=%s", ErrInvalid, val)
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+func parseByMonth(val string) ([]time.Month, error) {
+	var months []time.Month
+	for _, item := range strings.Split(val, ",") {
+		m, err := strconv.Atoi(item)
+		if err != nil || m < 1 || m > 12 {
+			return nil, fmt.Errorf("%w: BYMONTH=%s", ErrInvalid, val)
+		}
+		months = append(months, time.Month(m))
+	}
+	return months, nil
+}
+
+func positive(val string) (int, error) {
+	n, err := strconv.Atoi(val)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%w: %s is not a positive number", ErrInvalid, val)
+	}
+	return n, nil
+}
+
+func containsMonth(months []time.Month, month time.Month) bool {
+	for _, m := range months {
+		if m == month {
+			return true
+		}
+	}
+	return false
+}
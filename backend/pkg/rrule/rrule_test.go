@@ -0,0 +1,214 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%q): %v", name, err)
+	}
+	return loc
+}
+
+// occurrences lists the first n occurrences of the rule, fewer when the
+// series ends.
+func occurrences(t *testing.T, value string, dtstart time.Time, n int) []string {
+	t.Helper()
+
+	rule, err := Parse(value)
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", value, err)
+	}
+	var got []string
+	after := dtstart.Add(-time.Second)
+	for len(got) < n {
+		next, ok := rule.Next(dtstart, after)
+		if !ok {
+			break
+		}
+		if !next.After(after) {
+			t.Fatalf("%s: Next(%s) = %s, not after it", value, after, next)
+		}
+		got = append(got, next.Format(time.RFC3339))
+		after = next
+	}
+	return got
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		value string
+		want  error
+	}{
+		{"", ErrInvalid},
+		{"FREQ=DAILY;", ErrInvalid},
+		{"INTERVAL=2", ErrInvalid},
+		{"FREQ", ErrInvalid},
+		{"FREQ=HOURLY", ErrUnsupported},
+		{"FREQ=DAILY;FREQ=WEEKLY", ErrInvalid},
+		{"FREQ=DAILY;INTERVAL=0", ErrInvalid},
+		{"FREQ=DAILY;COUNT=-1", ErrInvalid},
+		{"FREQ=DAILY;COUNT=2;UNTIL=20260101", ErrInvalid},
+		{"FREQ=DAILY;UNTIL=2026-01-01", ErrInvalid},
+		{"FREQ=DAILY;UNTIL=20261301T000000Z", ErrInvalid},
+		{"FREQ=WEEKLY;BYDAY=XX", ErrInvalid},
+		{"FREQ=MONTHLY;BYDAY=6MO", ErrInvalid},
+		{"FREQ=MONTHLY;BYDAY=0MO", ErrInvalid},
+		{"FREQ=WEEKLY;BYDAY=1MO", ErrUnsupported},
+		{"FREQ=YEARLY;BYDAY=MO", ErrUnsupported},
+		{"FREQ=WEEKLY;BYMONTHDAY=1", ErrInvalid},
+		{"FREQ=MONTHLY;BYMONTHDAY=32", ErrInvalid},
+		{"FREQ=MONTHLY;BYMONTHDAY=0", ErrInvalid},
+		{"FREQ=YEARLY;BYMONTH=13", ErrInvalid},
+		{"FREQ=DAILY;WKST=XX", ErrInvalid},
+		{"FREQ=DAILY;BYSETPOS=1", ErrUnsupported},
+	}
+	for _, tt := range tests {
+		if _, err := Parse(tt.value); !errors.Is(err, tt.want) {
+			t.Errorf("Parse(%q): err = %v, want %v", tt.value, err, tt.want)
+		}
+	}
+}
+
+func TestParseString(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"FREQ=DAILY", "FREQ=DAILY"},
+		{"rrule:freq=weekly;interval=1;byday=mo,we", "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{"FREQ=MONTHLY;BYDAY=+2TU,-1FR;INTERVAL=3", "FREQ=MONTHLY;INTERVAL=3;BYDAY=2TU,-1FR"},
+		{"FREQ=YEARLY;BYMONTH=2,8;BYMONTHDAY=-1;COUNT=4", "FREQ=YEARLY;COUNT=4;BYMONTHDAY=-1;BYMONTH=2,8"},
+		{"FREQ=DAILY;UNTIL=20261231", "FREQ=DAILY;UNTIL=20261231"},
+		{"FREQ=DAILY;UNTIL=20261231T170000", "FREQ=DAILY;UNTIL=20261231T170000"},
+		{"FREQ=DAILY;UNTIL=20261231T170000Z", "FREQ=DAILY;UNTIL=20261231T170000Z"},
+		{"FREQ=WEEKLY;WKST=SU", "FREQ=WEEKLY;WKST=SU"},
+	}
+	for _, tt := range tests {
+		rule, err := Parse(tt.value)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.value, err)
+			continue
+		}
+		if got := rule.String(); got != tt.want {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+	utc := func(y int, m time.Month, d, h int) time.Time { return time.Date(y, m, d, h, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		want    []string
+	}{
+		{"count", "FREQ=DAILY;COUNT=3", utc(2026, 1, 1, 9),
+			[]string{"2026-01-01T09:00:00Z", "2026-01-02T09:00:00Z", "2026-01-03T09:00:00Z"}},
+		{"interval", "FREQ=DAILY;INTERVAL=10;COUNT=3", utc(2026, 1, 25, 9),
+			[]string{"2026-01-25T09:00:00Z", "2026-02-04T09:00:00Z", "2026-02-14T09:00:00Z"}},
+		{"until date includes the day", "FREQ=WEEKLY;UNTIL=20260115", utc(2026, 1, 1, 23),
+			[]string{"2026-01-01T23:00:00Z", "2026-01-08T23:00:00Z", "2026-01-15T23:00:00Z"}},
+		{"until local time", "FREQ=DAILY;UNTIL=20260103T090000", time.Date(2026, 1, 1, 9, 0, 0, 0, berlin),
+			[]string{"2026-01-01T09:00:00+01:00", "2026-01-02T09:00:00+01:00", "2026-01-03T09:00:00+01:00"}},
+		{"until utc", "FREQ=DAILY;UNTIL=20260103T075959Z", time.Date(2026, 1, 1, 9, 0, 0, 0, berlin),
+			[]string{"2026-01-01T09:00:00+01:00", "2026-01-02T09:00:00+01:00"}},
+		{"weekly by day", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=5", utc(2026, 1, 5, 9),
+			[]string{"2026-01-05T09:00:00Z", "2026-01-07T09:00:00Z", "2026-01-19T09:00:00Z", "2026-01-21T09:00:00Z", "2026-02-02T09:00:00Z"}},
+		{"week start", "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU,MO;WKST=SU;COUNT=3", utc(2026, 1, 4, 9),
+			[]string{"2026-01-04T09:00:00Z", "2026-01-05T09:00:00Z", "2026-01-18T09:00:00Z"}},
+		{"last friday", "FREQ=MONTHLY;BYDAY=-1FR;COUNT=4", utc(2026, 1, 30, 9),
+			[]string{"2026-01-30T09:00:00Z", "2026-02-27T09:00:00Z", "2026-03-27T09:00:00Z", "2026-04-24T09:00:00Z"}},
+		{"second tuesday", "FREQ=MONTHLY;BYDAY=2TU;COUNT=3", utc(2026, 1, 13, 9),
+			[]string{"2026-01-13T09:00:00Z", "2026-02-10T09:00:00Z", "2026-03-10T09:00:00Z"}},
+		{"fifth monday skips months without one", "FREQ=MONTHLY;BYDAY=5MO;COUNT=3", utc(2026, 3, 30, 9),
+			[]string{"2026-03-30T09:00:00Z", "2026-06-29T09:00:00Z", "2026-08-31T09:00:00Z"}},
+		{"yearly numbered weekday", "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;COUNT=2", utc(2026, 11, 26, 18),
+			[]string{"2026-11-26T18:00:00Z", "2027-11-25T18:00:00Z"}},
+		{"month day 31", "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=4", utc(2026, 1, 31, 9),
+			[]string{"2026-01-31T09:00:00Z", "2026-03-31T09:00:00Z", "2026-05-31T09:00:00Z", "2026-07-31T09:00:00Z"}},
+		{"dtstart on the 31st", "FREQ=MONTHLY;COUNT=3", utc(2026, 1, 31, 9),
+			[]string{"2026-01-31T09:00:00Z", "2026-03-31T09:00:00Z", "2026-05-31T09:00:00Z"}},
+		{"last day of the month", "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3", utc(2026, 1, 31, 9),
+			[]string{"2026-01-31T09:00:00Z", "2026-02-28T09:00:00Z", "2026-03-31T09:00:00Z"}},
+		{"leap day", "FREQ=YEARLY;COUNT=2", utc(2024, 2, 29, 9),
+			[]string{"2024-02-29T09:00:00Z", "2028-02-29T09:00:00Z"}},
+		{"never matches", "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", utc(2026, 1, 1, 9),
+			[]string{"2026-01-01T09:00:00Z"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := occurrences(t, tt.rule, tt.dtstart, 10); !equal(got, tt.want) {
+				t.Errorf("%s from %s:\n got %q\nwant %q", tt.rule, tt.dtstart.Format(time.RFC3339), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextAfter(t *testing.T) {
+	rule, err := Parse("FREQ=DAILY;COUNT=10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dtstart := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	next, ok := rule.Next(dtstart, time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC))
+	if want := time.Date(2026, 1, 6, 9, 0, 0, 0, time.UTC); !ok || !next.Equal(want) {
+		t.Errorf("Next = %s, %v; want %s", next, ok, want)
+	}
+	if next, ok := rule.Next(dtstart, time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("Next after the tenth occurrence = %s, want the end", next)
+	}
+}
+
+func TestNextDST(t *testing.T) {
+	berlin := mustLoad(t, "Europe/Berlin")
+	newYork := mustLoad(t, "America/New_York")
+
+	tests := []struct {
+		name    string
+		dtstart time.Time
+		want    []string
+	}{
+		{"spring, same wall clock", time.Date(2026, 3, 28, 9, 0, 0, 0, berlin),
+			[]string{"2026-03-28T09:00:00+01:00", "2026-03-29T09:00:00+02:00", "2026-03-30T09:00:00+02:00"}},
+		{"autumn, same wall clock", time.Date(2026, 10, 24, 9, 0, 0, 0, berlin),
+			[]string{"2026-10-24T09:00:00+02:00", "2026-10-25T09:00:00+01:00", "2026-10-26T09:00:00+01:00"}},
+		{"spring gap moves forward", time.Date(2026, 3, 28, 2, 30, 0, 0, berlin),
+			[]string{"2026-03-28T02:30:00+01:00", "2026-03-29T03:30:00+02:00", "2026-03-30T02:30:00+02:00"}},
+		{"autumn overlap takes the first", time.Date(2026, 10, 24, 2, 30, 0, 0, berlin),
+			[]string{"2026-10-24T02:30:00+02:00", "2026-10-25T02:30:00+02:00", "2026-10-26T02:30:00+01:00"}},
+		{"gap in new york", time.Date(2026, 3, 7, 2, 30, 0, 0, newYork),
+			[]string{"2026-03-07T02:30:00-05:00", "2026-03-08T03:30:00-04:00", "2026-03-09T02:30:00-04:00"}},
+		{"overlap in new york", time.Date(2026, 10, 31, 1, 30, 0, 0, newYork),
+			[]string{"2026-10-31T01:30:00-04:00", "2026-11-01T01:30:00-04:00", "2026-11-02T01:30:00-05:00"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := occurrences(t, "FREQ=DAILY", tt.dtstart, 3); !equal(got, tt.want) {
+				t.Errorf("from %s:\n got %q\nwant %q", tt.dtstart.Format(time.RFC3339), got, tt.want)
+			}
+		})
+	}
+}
@@ -39,8 +39,8 @@ func NewServer(cfg Config, logger *logrus.Logger) *Server {
 		engine.Use(cors.New(cors.Config{
 			AllowOrigins:     cfg.CorsOrigins,
 			AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-			AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Auth orization"},
-			ExposeHeaders:    []string{"Content-Length"},
+			AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Auth orization", "If-Match"},
+			ExposeHeaders:    []string{"Content-Length", "ETag"},
 			AllowCredentials: true,
 			MaxAge:           12 * time.Hour,
 		}))
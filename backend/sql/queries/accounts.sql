@@ -0,0 +1,55 @@
+-- name: ScheduleUserDeletion :exec
+UPDATE users
+SET
+    deletion_scheduled_at = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND deleted_at IS NULL;
+
+-- name: CancelUserDeletion :execrows
+UPDATE users
+SET
+    deletion_scheduled_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND deletion_scheduled_at IS NOT NULL
+  AND deleted_at IS NULL;
+
+-- name: ListUsersDueForDeletion :many
+SELECT id, email
+FROM users
+WHERE deletion_scheduled_at <= $1
+  AND deleted_at IS NULL
+ORDER BY deletion_scheduled_at;
+
+-- name: AnonymizeUser :exec
+UPDATE users
+SET
+    username = 'deleted-' || id,
+    firstname = 'Deleted',
+    lastname = 'User',
+    email = 'deleted-' || id || '@deleted.invalid',
+    password_hash = '',
+    two_fa_enabled = false,
+    avatar_path = NULL,
+    time_zone = 'UTC',
+    locale = 'en',
+    blocked_until = NULL,
+    failed_attempts = 0,
+    last_failed_attempt = NULL,
+    deletion_scheduled_at = NULL,
+    deleted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: DeleteNotificationsByUserID :exec
+DELETE FROM notifications
+WHERE user_id = $1;
+
+-- name: DeleteTwoFaCodesByUserID :exec
+DELETE FROM two_fa_codes
+WHERE user_id = $1;
+
+-- name: DeleteLoginAttemptsByEmail :exec
+DELETE FROM login_attempts
+WHERE email = $1;
@@ -0,0 +1,22 @@
+-- name: CreateTaskActivity :one
+INSERT INTO task_activity (
+    task_id,
+    board_id,
+    actor_id,
+    activity_type,
+    field,
+    before_value,
+    after_value
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, created_at;
+
+-- name: ListTaskActivity :many
+SELECT a.id, a.task_id, a.board_id, a.actor_id, u.username AS actor_username,
+       a.activity_type, a.field, a.before_value, a.after_value, a.created_at
+FROM task_activity a
+LEFT JOIN users u ON u.id = a.actor_id
+WHERE a.task_id = sqlc.arg('task_id')
+  AND (sqlc.narg('before_id')::bigint IS NULL OR a.id < sqlc.narg('before_id'))
+ORDER BY a.id DESC
+LIMIT sqlc.arg('max_results');
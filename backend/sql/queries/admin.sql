@@ -0,0 +1,77 @@
+-- name: ListUsers :many
+SELECT
+    id,
+    username,
+    firstname,
+    lastname,
+    email,
+    role,
+    two_fa_enabled,
+    blocked_until,
+    password_reset_required,
+    deletion_scheduled_at,
+    deleted_at,
+    created_at,
+    COUNT(*) OVER() AS total
+FROM users
+WHERE sqlc.narg('pattern')::text IS NULL
+   OR LOWER(username) LIKE sqlc.narg('pattern')
+   OR LOWER(email) LIKE sqlc.narg('pattern')
+   OR LOWER(firstname || ' ' || lastname) LIKE sqlc.narg('pattern')
+ORDER BY id
+LIMIT sqlc.arg('max_results')
+OFFSET sqlc.arg('skip');
+
+-- name: GetAdminUser :one
+SELECT
+    id,
+    username,
+    firstname,
+    lastname,
+    email,
+    role,
+    two_fa_enabled,
+    blocked_until,
+    password_reset_required,
+    deletion_scheduled_at,
+    deleted_at,
+    created_at
+FROM users
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetUserRole :one
+SELECT role
+FROM users
+WHERE id = $1
+  AND deleted_at IS NULL;
+
+-- name: UpdateUserRole :exec
+UPDATE users
+SET
+    role = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: RequirePasswordReset :exec
+UPDATE users
+SET
+    password_reset_required = true,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: ResetPassword :execrows
+UPDATE users
+SET
+    password_hash = $2,
+    password_reset_required = false,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND password_reset_required = true;
+
+-- name: ListLoginAttemptsByEmail :many
+SELECT id, email, success, attempted_at, ip_address, user_agent, cleared
+FROM login_attempts
+WHERE email = $1
+ORDER BY attempted_at DESC
+LIMIT $2;
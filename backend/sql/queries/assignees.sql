@@ -0,0 +1,51 @@
+-- name: AddTaskAssignee :execrows
+-- Only users who can open the task's board are assigned.
+INSERT INTO task_assignees (task_id, user_id, assigned_by)
+SELECT t.id, a.user_id, sqlc.narg('assigned_by')::bigint
+FROM tasks t
+JOIN board_access a ON a.board_id = t.board_id
+WHERE t.id = sqlc.arg('task_id') AND a.user_id = sqlc.arg('user_id')
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveTaskAssignee :execrows
+DELETE FROM task_assignees
+WHERE task_id = $1 AND user_id = $2;
+
+-- name: ListTaskAssignees :many
+SELECT ta.user_id, u.username, ta.assigned_by, ta.created_at
+FROM task_assignees ta
+JOIN users u ON u.id = ta.user_id
+WHERE ta.task_id = $1
+ORDER BY ta.created_at, ta.user_id;
+
+-- name: AddTaskWatcher :exec
+-- Only users who can open the task's board watch it.
+INSERT INTO task_watchers (task_id, user_id)
+SELECT t.id, a.user_id
+FROM tasks t
+JOIN board_access a ON a.board_id = t.board_id
+WHERE t.id = sqlc.arg('task_id') AND a.user_id = sqlc.arg('user_id')
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveTaskWatcher :execrows
+DELETE FROM task_watchers
+WHERE task_id = $1 AND user_id = $2;
+
+-- name: ListTaskWatchers :many
+SELECT tw.user_id, u.username, tw.created_at
+FROM task_watchers tw
+JOIN users u ON u.id = tw.user_id
+WHERE tw.task_id = $1
+ORDER BY tw.created_at, tw.user_id;
+
+-- name: ListTaskRecipientIDs :many
+-- Assignees and watchers who can still open the task's board.
+SELECT r.user_id
+FROM (
+    SELECT ta.user_id FROM task_assignees ta WHERE ta.task_id = sqlc.arg('task_id')
+    UNION
+    SELECT tw.user_id FROM task_watchers tw WHERE tw.task_id = sqlc.arg('task_id')
+) r
+JOIN tasks t ON t.id = sqlc.arg('task_id')
+JOIN board_access a ON a.board_id = t.board_id AND a.user_id = r.user_id
+ORDER BY r.user_id;
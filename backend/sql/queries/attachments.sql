@@ -0,0 +1,59 @@
+-- name: TouchAttachmentBlob :execrows
+-- Marks a stored blob as used so cleanup leaves it alone; no rows means the
+-- content still has to be stored.
+UPDATE attachment_blobs SET last_used_at = CURRENT_TIMESTAMP WHERE sha256 = $1;
+
+-- name: UpsertAttachmentBlob :exec
+INSERT INTO attachment_blobs (sha256, size, content_type, has_thumbnail)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (sha256) DO UPDATE SET last_used_at = CURRENT_TIMESTAMP;
+
+-- name: CreateAttachment :one
+INSERT INTO attachments (board_id, task_id, sha256, filename, uploaded_by)
+VALUES (sqlc.arg('board_id'), sqlc.narg('task_id'), sqlc.arg('sha256'), sqlc.arg('filename'), sqlc.arg('uploaded_by'))
+RETURNING id;
+
+-- name: GetAttachment :one
+SELECT a.id, a.board_id, a.task_id, a.message_id, a.sha256, a.filename, a.uploaded_by, a.created_at,
+    b.size, b.content_type, b.has_thumbnail
+FROM attachments a
+JOIN attachment_blobs b ON b.sha256 = a.sha256
+WHERE a.id = $1;
+
+-- name: ListTaskAttachments :many
+SELECT a.id, a.board_id, a.task_id, a.message_id, a.sha256, a.filename, a.uploaded_by, a.created_at,
+    b.size, b.content_type, b.has_thumbnail
+FROM attachments a
+JOIN attachment_blobs b ON b.sha256 = a.sha256
+WHERE a.task_id = $1
+ORDER BY a.created_at, a.id;
+
+-- name: ListAttachmentsByIDs :many
+SELECT a.id, a.board_id, a.task_id, a.message_id, a.sha256, a.filename, a.uploaded_by, a.created_at,
+    b.size, b.content_type, b.has_thumbnail
+FROM attachments a
+JOIN attachment_blobs b ON b.sha256 = a.sha256
+WHERE a.id = ANY(sqlc.arg('ids')::bigint[])
+ORDER BY a.id;
+
+-- name: LinkMessageAttachments :execrows
+-- Only the uploader's unreferenced uploads on the message's board are taken.
+UPDATE attachments
+SET message_id = sqlc.arg('message_id')
+WHERE id = ANY(sqlc.arg('ids')::bigint[])
+  AND board_id = sqlc.arg('board_id')
+  AND uploaded_by = sqlc.arg('uploaded_by')
+  AND task_id IS NULL AND message_id IS NULL;
+
+-- name: DeleteAttachment :execrows
+DELETE FROM attachments WHERE id = $1;
+
+-- name: DeleteUnreferencedAttachments :execrows
+DELETE FROM attachments
+WHERE task_id IS NULL AND message_id IS NULL AND created_at < $1;
+
+-- name: DeleteUnusedAttachmentBlobs :many
+DELETE FROM attachment_blobs b
+WHERE b.last_used_at < $1
+  AND NOT EXISTS (SELECT 1 FROM attachments a WHERE a.sha256 = b.sha256)
+RETURNING b.sha256, b.has_thumbnail;
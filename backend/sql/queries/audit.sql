@@ -0,0 +1,24 @@
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (
+    event_type,
+    actor_id,
+    target_user_id,
+    ip_address,
+    user_agent,
+    details
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+);
+
+-- name: ListAuditEvents :many
+SELECT id, event_type, actor_id, target_user_id, ip_address, user_agent, details, created_at
+FROM audit_events
+WHERE (sqlc.narg('event_types')::text[] IS NULL OR event_type = ANY(sqlc.narg('event_types')::text[]))
+  AND (sqlc.narg('actor_id')::bigint IS NULL OR actor_id = sqlc.narg('actor_id'))
+  AND (sqlc.narg('target_user_id')::bigint IS NULL OR target_user_id = sqlc.narg('target_user_id'))
+  AND (sqlc.narg('ip_address')::text IS NULL OR ip_address = sqlc.narg('ip_address'))
+  AND (sqlc.narg('since')::timestamptz IS NULL OR created_at >= sqlc.narg('since'))
+  AND (sqlc.narg('until')::timestamptz IS NULL OR created_at < sqlc.narg('until'))
+  AND (sqlc.narg('before_id')::bigint IS NULL OR id < sqlc.narg('before_id'))
+ORDER BY id DESC
+LIMIT sqlc.arg('max_results');
@@ -0,0 +1,67 @@
+-- name: CreateAutomationRule :one
+INSERT INTO automation_rules (board_id, created_by, name, enabled, trigger_type, trigger, conditions, actions)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, board_id, created_by, name, enabled, trigger_type, trigger, conditions, actions, created_at, updated_at;
+
+-- name: ListAutomationRules :many
+SELECT id, board_id, created_by, name, enabled, trigger_type, trigger, conditions, actions, created_at, updated_at
+FROM automation_rules
+WHERE board_id = $1
+ORDER BY id;
+
+-- name: ListEnabledAutomationRules :many
+SELECT id, board_id, created_by, name, enabled, trigger_type, trigger, conditions, actions, created_at, updated_at
+FROM automation_rules
+WHERE board_id = $1 AND trigger_type = $2 AND enabled
+ORDER BY id;
+
+-- name: GetAutomationRule :one
+SELECT id, board_id, created_by, name, enabled, trigger_type, trigger, conditions, actions, created_at, updated_at
+FROM automation_rules
+WHERE id = $1;
+
+-- name: UpdateAutomationRule :execrows
+UPDATE automation_rules
+SET name = COALESCE(sqlc.narg('name'), name),
+    enabled = COALESCE(sqlc.narg('enabled'), enabled),
+    trigger_type = COALESCE(sqlc.narg('trigger_type'), trigger_type),
+    trigger = COALESCE(sqlc.narg('trigger')::jsonb, trigger),
+    conditions = COALESCE(sqlc.narg('conditions')::jsonb, conditions),
+    actions = COALESCE(sqlc.narg('actions')::jsonb, actions),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id');
+
+-- name: DeleteAutomationRule :execrows
+DELETE FROM automation_rules
+WHERE id = $1;
+
+-- name: CreateAutomationExecution :exec
+INSERT INTO automation_executions (rule_id, board_id, task_id, trigger_type, status, depth, results, error)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: ListAutomationExecutions :many
+-- Newest first; before_id continues a previous page.
+SELECT id, rule_id, board_id, task_id, trigger_type, status, depth, results, error, created_at
+FROM automation_executions
+WHERE rule_id = sqlc.arg('rule_id')
+  AND (sqlc.arg('before_id')::bigint = 0 OR id < sqlc.arg('before_id'))
+ORDER BY id DESC
+LIMIT sqlc.arg('max_results');
+
+-- name: ListDueDeadlineRules :many
+-- Pairs each enabled deadline rule with the open tasks of its board whose
+-- deadline has passed and which the rule has not handled since.
+SELECT r.id AS rule_id, t.id AS task_id, t.board_id
+FROM automation_rules r
+JOIN tasks t ON t.board_id = r.board_id
+JOIN board_columns c ON c.id = t.column_id
+WHERE r.enabled AND r.trigger_type = 'deadline.passed'
+  AND t.deadline < CURRENT_TIMESTAMP
+  AND c.kind <> 'done'
+  AND NOT EXISTS (
+    SELECT 1 FROM automation_executions e
+    WHERE e.rule_id = r.id AND e.task_id = t.id
+      AND e.trigger_type = 'deadline.passed' AND e.created_at >= t.deadline
+  )
+ORDER BY t.deadline, r.id
+LIMIT sqlc.arg('max_results');
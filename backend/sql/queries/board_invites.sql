@@ -0,0 +1,48 @@
+-- name: CreateBoardInvite :one
+INSERT INTO board_invites (
+    board_id,
+    email,
+    role,
+    token_hash,
+    max_uses,
+    expires_at,
+    created_by
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING id, board_id, email, role, token_hash, max_uses, use_count, expires_at, created_by, revoked_at, created_at;
+
+-- name: ListBoardInvites :many
+SELECT id, board_id, email, role, token_hash, max_uses, use_count, expires_at, created_by, revoked_at, created_at
+FROM board_invites
+WHERE board_id = $1
+  AND revoked_at IS NULL
+  AND expires_at > NOW()
+  AND (max_uses IS NULL OR use_count < max_uses)
+ORDER BY created_at DESC, id DESC;
+
+-- name: RevokeBoardInvite :execrows
+UPDATE board_invites
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND board_id = $2 AND revoked_at IS NULL;
+
+-- name: GetBoardInviteByTokenHash :one
+SELECT i.id, i.board_id, b.name AS board_name, b.workspace_id, i.email, i.role, i.max_uses, i.use_count,
+    i.expires_at, i.created_by, i.revoked_at, i.created_at
+FROM board_invites i
+JOIN boards b ON b.id = i.board_id
+WHERE i.token_hash = $1
+LIMIT 1;
+
+-- name: RedeemBoardInvite :execrows
+UPDATE board_invites
+SET use_count = use_count + 1
+WHERE id = $1
+  AND revoked_at IS NULL
+  AND expires_at > NOW()
+  AND (max_uses IS NULL OR use_count < max_uses);
+
+-- name: CreateBoardInviteRedemption :execrows
+INSERT INTO board_invite_redemptions (invite_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT (invite_id, user_id) DO NOTHING;
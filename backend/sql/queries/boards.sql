@@ -0,0 +1,114 @@
+-- name: CreateBoard :one
+INSERT INTO boards (
+    workspace_id,
+    name,
+    description,
+    created_by
+)
+SELECT sqlc.arg('workspace_id')::bigint, sqlc.arg('name')::text, sqlc.arg('description')::text, sqlc.arg('created_by')::bigint
+WHERE EXISTS (
+    SELECT 1 FROM workspace_members m
+    WHERE m.workspace_id = sqlc.arg('workspace_id')
+      AND m.user_id = sqlc.arg('created_by')
+      AND m.role <> 'guest'
+)
+RETURNING id, workspace_id, name, description, created_by, created_at, updated_at, revision, version;
+
+-- name: ListBoardsForMember :many
+-- Guests only see boards they have an explicit board role on.
+SELECT b.id, b.workspace_id, b.name, b.description, b.created_by, b.created_at, b.updated_at, b.revision, b.version, m.role,
+    (CASE WHEN m.role IN ('owner', 'admin') THEN 'admin' ELSE COALESCE(bm.role, 'editor') END)::text AS board_role
+FROM boards b
+JOIN workspace_members m ON m.workspace_id = b.workspace_id
+LEFT JOIN board_members bm ON bm.board_id = b.id AND bm.user_id = m.user_id
+WHERE b.workspace_id = $1 AND m.user_id = $2
+  AND (m.role <> 'guest' OR bm.user_id IS NOT NULL)
+ORDER BY b.name, b.id;
+
+-- name: GetBoardForMember :one
+SELECT b.id, b.workspace_id, b.name, b.description, b.created_by, b.created_at, b.updated_at, b.revision, b.version, m.role,
+    (CASE WHEN m.role IN ('owner', 'admin') THEN 'admin' ELSE COALESCE(bm.role, 'editor') END)::text AS board_role
+FROM boards b
+JOIN workspace_members m ON m.workspace_id = b.workspace_id
+LEFT JOIN board_members bm ON bm.board_id = b.id AND bm.user_id = m.user_id
+WHERE b.id = $1 AND m.user_id = $2
+  AND (m.role <> 'guest' OR bm.user_id IS NOT NULL)
+LIMIT 1;
+
+-- name: UpdateBoard :execrows
+-- A null version updates unconditionally.
+UPDATE boards b
+SET name = COALESCE(sqlc.narg('name'), b.name),
+    description = COALESCE(sqlc.narg('description'), b.description),
+    version = b.version + 1,
+    updated_at = CURRENT_TIMESTAMP
+FROM workspace_members m
+WHERE b.id = sqlc.arg('id')
+  AND m.workspace_id = b.workspace_id
+  AND m.user_id = sqlc.arg('user_id')
+  AND (m.role <> 'guest' OR EXISTS (
+    SELECT 1 FROM board_members bm
+    WHERE bm.board_id = b.id AND bm.user_id = m.user_id
+  ))
+  AND (sqlc.narg('version')::bigint IS NULL OR b.version = sqlc.narg('version'));
+
+-- name: DeleteBoard :execrows
+DELETE FROM boards b
+USING workspace_members m
+WHERE b.id = $1
+  AND m.workspace_id = b.workspace_id
+  AND m.user_id = $2
+  AND (m.role <> 'guest' OR EXISTS (
+    SELECT 1 FROM board_members bm
+    WHERE bm.board_id = b.id AND bm.user_id = m.user_id
+  ));
+
+-- name: AddBoardMember :exec
+-- Re-joining never downgrades an existing board role.
+INSERT INTO board_members (board_id, user_id, role)
+VALUES ($1, $2, $3)
+ON CONFLICT (board_id, user_id) DO UPDATE
+SET role = EXCLUDED.role
+WHERE (CASE EXCLUDED.role WHEN 'admin' THEN 3 WHEN 'editor' THEN 2 ELSE 1 END)
+    > (CASE board_members.role WHEN 'admin' THEN 3 WHEN 'editor' THEN 2 ELSE 1 END);
+
+-- name: ListBoardAdminIDs :many
+-- Board admins are explicit board admins plus the workspace owners and
+-- admins.
+SELECT bm.user_id
+FROM board_members bm
+WHERE bm.board_id = $1 AND bm.role = 'admin'
+UNION
+SELECT m.user_id
+FROM boards b
+JOIN workspace_members m ON m.workspace_id = b.workspace_id
+WHERE b.id = $1 AND m.role IN ('owner', 'admin');
+
+-- name: DeleteBoardMembershipsInWorkspace :exec
+DELETE FROM board_members bm
+USING boards b
+WHERE bm.board_id = b.id
+  AND b.workspace_id = $1
+  AND bm.user_id = $2;
+
+-- name: DeleteBoardMembershipsByUserID :exec
+DELETE FROM board_members
+WHERE user_id = $1;
+
+-- name: BumpBoardRevision :one
+-- Each event published to the board's room takes the next revision.
+UPDATE boards
+SET revision = revision + 1
+WHERE id = $1
+RETURNING workspace_id, revision;
+
+-- name: IsBoardMember :one
+SELECT EXISTS (
+    SELECT 1 FROM board_access
+    WHERE board_id = $1 AND user_id = $2
+);
+
+-- name: LockBoard :exec
+-- Serializes changes that must see the whole board, such as new task
+-- dependencies.
+SELECT id FROM boards WHERE id = $1 FOR UPDATE;
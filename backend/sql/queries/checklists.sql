@@ -0,0 +1,51 @@
+-- name: CreateChecklistItem :one
+INSERT INTO task_checklist_items (task_id, text, assignee_id, position)
+VALUES ($1, $2, $3, $4)
+RETURNING id, task_id, text, done, assignee_id, position, created_at, updated_at;
+
+-- name: ListChecklistItems :many
+SELECT id, task_id, text, done, assignee_id, position, created_at, updated_at
+FROM task_checklist_items
+WHERE task_id = $1
+ORDER BY position, id;
+
+-- name: GetChecklistItem :one
+SELECT id, task_id, text, done, assignee_id, position, created_at, updated_at
+FROM task_checklist_items
+WHERE id = $1;
+
+-- name: UpdateChecklistItem :execrows
+UPDATE task_checklist_items
+SET text = COALESCE(sqlc.narg('text'), text),
+    done = COALESCE(sqlc.narg('done'), done),
+    assignee_id = CASE WHEN sqlc.arg('clear_assignee')::bool THEN NULL ELSE COALESCE(sqlc.narg('assignee_id'), assignee_id) END,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id');
+
+-- name: DeleteChecklistItem :execrows
+DELETE FROM task_checklist_items
+WHERE id = $1;
+
+-- name: GetLastChecklistPosition :one
+SELECT COALESCE(MAX(position), '')::text AS position
+FROM task_checklist_items
+WHERE task_id = $1 AND id <> $2;
+
+-- name: GetNextChecklistPosition :one
+-- The smallest position above the given one, or an empty string.
+SELECT COALESCE(MIN(position), '')::text AS position
+FROM task_checklist_items
+WHERE task_id = sqlc.arg('task_id')
+  AND position > sqlc.arg('position')
+  AND id <> sqlc.arg('exclude_id');
+
+-- name: ListChecklistItemIDs :many
+SELECT id
+FROM task_checklist_items
+WHERE task_id = $1
+ORDER BY position, id;
+
+-- name: SetChecklistItemPosition :exec
+UPDATE task_checklist_items
+SET position = $2
+WHERE id = $1;
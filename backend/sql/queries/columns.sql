@@ -0,0 +1,70 @@
+-- name: CreateColumn :one
+INSERT INTO board_columns (
+    board_id,
+    title,
+    position,
+    kind,
+    wip_limit,
+    wip_policy
+)
+SELECT sqlc.arg('board_id')::bigint, sqlc.arg('title')::text, sqlc.arg('position')::text, sqlc.arg('kind')::text,
+    sqlc.narg('wip_limit')::integer, sqlc.arg('wip_policy')::text
+WHERE EXISTS (
+    SELECT 1 FROM board_access a
+    WHERE a.board_id = sqlc.arg('board_id') AND a.user_id = sqlc.arg('user_id')
+)
+RETURNING id, board_id, title, position, created_at, updated_at, kind, wip_limit, wip_policy, is_done;
+
+-- name: ListBoardColumns :many
+SELECT c.id, c.board_id, c.title, c.position, c.created_at, c.updated_at, c.kind, c.wip_limit, c.wip_policy, c.is_done
+FROM board_columns c
+JOIN board_access a ON a.board_id = c.board_id
+WHERE c.board_id = $1 AND a.user_id = $2
+ORDER BY c.position, c.id;
+
+-- name: GetColumnForMember :one
+SELECT c.id, c.board_id, c.title, c.position, c.created_at, c.updated_at, c.kind, c.wip_limit, c.wip_policy, c.is_done
+FROM board_columns c
+JOIN board_access a ON a.board_id = c.board_id
+WHERE c.id = $1 AND a.user_id = $2
+LIMIT 1;
+
+-- name: GetLastColumnPosition :one
+SELECT COALESCE(MAX(position), '')::text AS position
+FROM board_columns
+WHERE board_id = $1;
+
+-- name: UpdateColumn :execrows
+UPDATE board_columns
+SET title = COALESCE(sqlc.narg('title'), title),
+    kind = COALESCE(sqlc.narg('kind'), kind),
+    wip_limit = CASE WHEN sqlc.arg('clear_wip_limit')::bool THEN NULL
+        ELSE COALESCE(sqlc.narg('wip_limit'), wip_limit) END,
+    wip_policy = COALESCE(sqlc.narg('wip_policy'), wip_policy),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id');
+
+-- name: CountColumnTasks :one
+-- Counts the tasks in a column other than exclude_id, the one being moved.
+SELECT COUNT(*) AS count
+FROM tasks
+WHERE column_id = sqlc.arg('column_id') AND id <> sqlc.arg('exclude_id');
+
+-- name: LockColumn :one
+-- Serializes moves and inserts into a column, so its work-in-progress limit
+-- holds. Tells whether an enforced limit leaves no room for task_id; a task
+-- already in the column always has room.
+SELECT (c.wip_policy = 'enforce' AND c.wip_limit IS NOT NULL
+    AND NOT EXISTS (SELECT 1 FROM tasks WHERE id = sqlc.arg('task_id') AND column_id = c.id)
+    AND (SELECT COUNT(*) FROM tasks t WHERE t.column_id = c.id AND t.id <> sqlc.arg('task_id')) >= c.wip_limit)::boolean AS is_full
+FROM board_columns c
+WHERE c.id = sqlc.arg('column_id')
+FOR UPDATE;
+
+-- name: StampColumnTasksCompleted :exec
+-- Brings completed_at of the column's tasks in line with its kind after the
+-- kind changed.
+UPDATE tasks t
+SET completed_at = CASE WHEN c.is_done THEN COALESCE(t.completed_at, CURRENT_TIMESTAMP) END
+FROM board_columns c
+WHERE c.id = t.column_id AND t.column_id = sqlc.arg('column_id');
@@ -0,0 +1,75 @@
+-- name: CreateComment :one
+-- The task's board must be open to the author.
+INSERT INTO task_comments (
+    task_id,
+    user_id,
+    body
+)
+SELECT t.id, sqlc.arg('user_id')::bigint, sqlc.arg('body')::text
+FROM tasks t
+JOIN board_access a ON a.board_id = t.board_id
+WHERE t.id = sqlc.arg('task_id') AND a.user_id = sqlc.arg('user_id')
+RETURNING id, task_id, user_id, body, created_at, updated_at, deleted_at;
+
+-- name: ListTaskComments :many
+SELECT c.id, c.task_id, t.board_id, c.user_id, u.username, c.body, c.created_at, c.updated_at
+FROM task_comments c
+JOIN tasks t ON t.id = c.task_id
+JOIN users u ON u.id = c.user_id
+JOIN board_access a ON a.board_id = t.board_id
+WHERE c.task_id = $1 AND a.user_id = $2 AND c.deleted_at IS NULL
+ORDER BY c.created_at, c.id;
+
+-- name: GetCommentForMember :one
+-- Deleted comments are returned too, so their history stays reachable.
+SELECT c.id, c.task_id, t.board_id, c.user_id, u.username, c.body, c.created_at, c.updated_at, c.deleted_at
+FROM task_comments c
+JOIN tasks t ON t.id = c.task_id
+JOIN users u ON u.id = c.user_id
+JOIN board_access a ON a.board_id = t.board_id
+WHERE c.id = $1 AND a.user_id = $2
+LIMIT 1;
+
+-- name: CreateCommentRevision :execrows
+-- Keeps the current body of a live comment before it is replaced. The row
+-- stays locked until the transaction replacing the body ends.
+INSERT INTO task_comment_revisions (comment_id, body, edited_by)
+SELECT id, body, sqlc.arg('edited_by')::bigint
+FROM task_comments
+WHERE id = sqlc.arg('comment_id') AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: UpdateCommentBody :execrows
+UPDATE task_comments
+SET body = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: MarkCommentDeleted :execrows
+UPDATE task_comments
+SET body = '',
+    deleted_at = CURRENT_TIMESTAMP,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListCommentRevisions :many
+SELECT r.id, r.comment_id, r.body, r.edited_by, u.username AS edited_by_username, r.edited_at
+FROM task_comment_revisions r
+LEFT JOIN users u ON u.id = r.edited_by
+WHERE r.comment_id = $1
+ORDER BY r.edited_at, r.id;
+
+-- name: ListBoardMemberIDsByUsername :many
+-- Resolves lower-cased usernames to the users who can open the board.
+SELECT u.id
+FROM users u
+JOIN board_access a ON a.user_id = u.id
+WHERE a.board_id = sqlc.arg('board_id') AND LOWER(u.username) = ANY(sqlc.arg('usernames')::text[]);
+
+-- name: ListCommentsByUserID :many
+SELECT c.id, c.task_id, t.board_id, c.user_id, u.username, c.body, c.created_at, c.updated_at
+FROM task_comments c
+JOIN tasks t ON t.id = c.task_id
+JOIN users u ON u.id = c.user_id
+WHERE c.user_id = $1 AND c.deleted_at IS NULL
+ORDER BY c.created_at, c.id;
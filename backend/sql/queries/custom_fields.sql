@@ -0,0 +1,65 @@
+-- name: CreateCustomField :one
+INSERT INTO board_custom_fields (board_id, name, type, options)
+VALUES ($1, $2, $3, $4)
+RETURNING id, board_id, name, type, options, created_at, updated_at;
+
+-- name: ListBoardCustomFields :many
+SELECT id, board_id, name, type, options, created_at, updated_at
+FROM board_custom_fields
+WHERE board_id = $1
+ORDER BY created_at, id;
+
+-- name: GetCustomField :one
+SELECT id, board_id, name, type, options, created_at, updated_at
+FROM board_custom_fields
+WHERE id = $1;
+
+-- name: UpdateCustomField :execrows
+UPDATE board_custom_fields
+SET name = COALESCE(sqlc.narg('name'), name),
+    options = COALESCE(sqlc.narg('options'), options),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id');
+
+-- name: DeleteCustomField :execrows
+DELETE FROM board_custom_fields
+WHERE id = $1;
+
+-- name: PruneFieldOptions :exec
+-- Drops chosen options that are no longer offered by the field.
+UPDATE task_field_values
+SET value_options = ARRAY(
+    SELECT o FROM unnest(value_options) AS o
+    WHERE o = ANY(sqlc.arg('options')::text[])
+)
+WHERE field_id = sqlc.arg('field_id') AND value_options IS NOT NULL;
+
+-- name: DeleteEmptyFieldValues :exec
+DELETE FROM task_field_values
+WHERE field_id = $1 AND cardinality(value_options) = 0;
+
+-- name: UpsertTaskFieldValue :exec
+INSERT INTO task_field_values (task_id, field_id, value_text, value_number, value_date, value_user_id, value_options)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (task_id, field_id) DO UPDATE
+SET value_text = EXCLUDED.value_text,
+    value_number = EXCLUDED.value_number,
+    value_date = EXCLUDED.value_date,
+    value_user_id = EXCLUDED.value_user_id,
+    value_options = EXCLUDED.value_options;
+
+-- name: DeleteTaskFieldValue :exec
+DELETE FROM task_field_values
+WHERE task_id = $1 AND field_id = $2;
+
+-- name: ListBoardTaskFieldValues :many
+SELECT v.task_id, v.field_id, f.type, v.value_text, v.value_number, v.value_date, v.value_user_id, v.value_options
+FROM task_field_values v
+JOIN board_custom_fields f ON f.id = v.field_id
+WHERE f.board_id = $1;
+
+-- name: ListTaskFieldValues :many
+SELECT v.task_id, v.field_id, f.type, v.value_text, v.value_number, v.value_date, v.value_user_id, v.value_options
+FROM task_field_values v
+JOIN board_custom_fields f ON f.id = v.field_id
+WHERE v.task_id = $1;
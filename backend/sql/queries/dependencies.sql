@@ -0,0 +1,33 @@
+-- name: TaskDependencyPathExists :one
+-- Whether from_id blocks to_id directly or through other tasks.
+WITH RECURSIVE reachable(task_id) AS (
+    SELECT d.blocked_id FROM task_dependencies d WHERE d.blocker_id = sqlc.arg('from_id')
+    UNION
+    SELECT d.blocked_id FROM task_dependencies d JOIN reachable r ON d.blocker_id = r.task_id
+)
+SELECT EXISTS (SELECT 1 FROM reachable WHERE task_id = sqlc.arg('to_id'))::bool AS exists;
+
+-- name: AddTaskDependency :execrows
+INSERT INTO task_dependencies (blocker_id, blocked_id, created_by)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveTaskDependency :execrows
+DELETE FROM task_dependencies
+WHERE blocker_id = $1 AND blocked_id = $2;
+
+-- name: ListTaskBlockers :many
+SELECT t.id, t.title, t.column_id, c.title AS status, c.is_done AS done
+FROM task_dependencies d
+JOIN tasks t ON t.id = d.blocker_id
+JOIN board_columns c ON c.id = t.column_id
+WHERE d.blocked_id = $1
+ORDER BY t.id;
+
+-- name: ListBlockedTasks :many
+SELECT t.id, t.title, t.column_id, c.title AS status, c.is_done AS done
+FROM task_dependencies d
+JOIN tasks t ON t.id = d.blocked_id
+JOIN board_columns c ON c.id = t.column_id
+WHERE d.blocker_id = $1
+ORDER BY t.id;
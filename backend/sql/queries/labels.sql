@@ -0,0 +1,38 @@
+-- name: CreateLabel :one
+INSERT INTO board_labels (board_id, name, color)
+VALUES ($1, $2, $3)
+RETURNING id, board_id, name, color, created_at;
+
+-- name: ListBoardLabels :many
+SELECT id, board_id, name, color, created_at
+FROM board_labels
+WHERE board_id = $1
+ORDER BY LOWER(name), id;
+
+-- name: GetLabel :one
+SELECT id, board_id, name, color, created_at
+FROM board_labels
+WHERE id = $1;
+
+-- name: UpdateLabel :execrows
+UPDATE board_labels
+SET name = COALESCE(sqlc.narg('name'), name),
+    color = COALESCE(sqlc.narg('color'), color)
+WHERE id = sqlc.arg('id');
+
+-- name: DeleteLabel :execrows
+DELETE FROM board_labels
+WHERE id = $1;
+
+-- name: DeleteTaskLabels :exec
+DELETE FROM task_labels
+WHERE task_id = $1;
+
+-- name: AddTaskLabels :exec
+-- Labels of other boards are skipped.
+INSERT INTO task_labels (task_id, label_id)
+SELECT t.id, l.id
+FROM tasks t
+JOIN board_labels l ON l.board_id = t.board_id
+WHERE t.id = sqlc.arg('task_id') AND l.id = ANY(sqlc.arg('label_ids')::bigint[])
+ON CONFLICT DO NOTHING;
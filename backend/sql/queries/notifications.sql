@@ -4,9 +4,10 @@ INSERT INTO notifications (
     title,
     message,
     type,
-    expires_at
+    expires_at,
+    activity
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
 SELECT * FROM notifications
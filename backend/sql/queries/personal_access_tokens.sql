@@ -0,0 +1,41 @@
+-- name: CreatePersonalAccessToken :one
+INSERT INTO personal_access_tokens (
+    user_id,
+    name,
+    token_hash,
+    token_prefix,
+    scopes,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetPersonalAccessTokenByHash :one
+SELECT * FROM personal_access_tokens
+WHERE token_hash = $1
+LIMIT 1;
+
+-- name: ListPersonalAccessTokensByUserID :many
+SELECT * FROM personal_access_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: TouchPersonalAccessToken :exec
+UPDATE personal_access_tokens
+SET
+    last_used_at = CURRENT_TIMESTAMP,
+    last_used_ip = $2
+WHERE id = $1;
+
+-- name: RevokePersonalAccessToken :execrows
+UPDATE personal_access_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND user_id = $2
+  AND revoked_at IS NULL;
+
+-- name: RevokeAllPersonalAccessTokens :exec
+UPDATE personal_access_tokens
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE user_id = $1
+  AND revoked_at IS NULL;
@@ -0,0 +1,64 @@
+-- name: GetUserProfile :one
+SELECT
+    id,
+    username,
+    firstname,
+    lastname,
+    email,
+    time_zone,
+    locale,
+    avatar_path,
+    deletion_scheduled_at,
+    created_at
+FROM users
+WHERE id = $1
+LIMIT 1;
+
+-- name: UpdateUserProfile :one
+UPDATE users
+SET
+    username = COALESCE(sqlc.narg('username'), username),
+    firstname = COALESCE(sqlc.narg('firstname'), firstname),
+    lastname = COALESCE(sqlc.narg('lastname'), lastname),
+    time_zone = COALESCE(sqlc.narg('time_zone'), time_zone),
+    locale = COALESCE(sqlc.narg('locale'), locale),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id')
+RETURNING
+    id,
+    username,
+    firstname,
+    lastname,
+    email,
+    time_zone,
+    locale,
+    avatar_path,
+    deletion_scheduled_at,
+    created_at;
+
+-- name: UpdateUserAvatar :execrows
+UPDATE users
+SET
+    avatar_path = $2,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: SearchUsers :many
+SELECT
+    u.id,
+    u.username,
+    u.firstname,
+    u.lastname,
+    u.avatar_path
+FROM users u
+JOIN workspace_members m ON m.user_id = u.id AND m.workspace_id = sqlc.arg('workspace_id')
+WHERE u.deleted_at IS NULL
+  AND EXISTS (
+    SELECT 1 FROM workspace_members v
+    WHERE v.workspace_id = sqlc.arg('workspace_id') AND v.user_id = sqlc.arg('viewer_id')
+  )
+  AND (LOWER(u.username) LIKE sqlc.arg('pattern')
+    OR LOWER(u.firstname || ' ' || u.lastname) LIKE sqlc.arg('pattern')
+    OR LOWER(u.lastname) LIKE sqlc.arg('pattern'))
+ORDER BY u.username
+LIMIT sqlc.arg('max_results');
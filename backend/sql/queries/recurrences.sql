@@ -0,0 +1,77 @@
+-- name: UpsertTaskRecurrence :one
+-- A task has at most one recurrence; setting it again restarts the series
+-- from the task's deadline and clears its failures.
+INSERT INTO task_recurrences (board_id, created_by, current_task_id, column_id, rrule, time_zone, mode, dtstart, occurrence)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $8)
+ON CONFLICT (current_task_id) DO UPDATE
+SET created_by = EXCLUDED.created_by,
+    column_id = EXCLUDED.column_id,
+    rrule = EXCLUDED.rrule,
+    time_zone = EXCLUDED.time_zone,
+    mode = EXCLUDED.mode,
+    dtstart = EXCLUDED.dtstart,
+    occurrence = EXCLUDED.occurrence,
+    failures = 0,
+    next_attempt_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING id, board_id, created_by, current_task_id, column_id, rrule, time_zone, mode, dtstart, occurrence, created_at, updated_at, failures, next_attempt_at;
+
+-- name: GetTaskRecurrence :one
+SELECT id, board_id, created_by, current_task_id, column_id, rrule, time_zone, mode, dtstart, occurrence, created_at, updated_at, failures, next_attempt_at
+FROM task_recurrences
+WHERE current_task_id = $1;
+
+-- name: DeleteTaskRecurrence :execrows
+DELETE FROM task_recurrences
+WHERE id = $1;
+
+-- name: ListDueRecurrences :many
+-- Recurrences whose current instance was completed or, on a schedule, whose
+-- occurrence has passed, unless they are held back after failing.
+SELECT r.id, r.board_id, r.created_by, r.current_task_id, r.column_id, r.rrule, r.time_zone, r.mode, r.dtstart, r.occurrence, r.created_at, r.updated_at, r.failures, r.next_attempt_at
+FROM task_recurrences r
+JOIN tasks t ON t.id = r.current_task_id
+WHERE ((r.mode = 'on_completion' AND t.completed_at IS NOT NULL)
+    OR (r.mode = 'on_schedule' AND r.occurrence <= CURRENT_TIMESTAMP))
+  AND (r.next_attempt_at IS NULL OR r.next_attempt_at <= CURRENT_TIMESTAMP)
+ORDER BY r.occurrence, r.id
+LIMIT $1;
+
+-- name: AdvanceTaskRecurrence :execrows
+-- Moves the recurrence on to a new instance, unless another run already did.
+UPDATE task_recurrences
+SET current_task_id = sqlc.arg('task_id'),
+    occurrence = sqlc.arg('occurrence'),
+    failures = 0,
+    next_attempt_at = NULL,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id') AND current_task_id = sqlc.arg('previous_task_id');
+
+-- name: PostponeTaskRecurrence :one
+-- Counts a failed attempt at the next instance and holds the recurrence back
+-- for a minute, doubling with each consecutive failure up to a day.
+UPDATE task_recurrences
+SET failures = failures + 1,
+    next_attempt_at = CURRENT_TIMESTAMP + LEAST(interval '1 minute' * power(2, LEAST(failures, 11)), interval '1 day')
+WHERE id = $1
+RETURNING next_attempt_at;
+
+-- name: CopyTaskAssignees :exec
+INSERT INTO task_assignees (task_id, user_id, assigned_by)
+SELECT sqlc.arg('task_id')::bigint, user_id, assigned_by
+FROM task_assignees
+WHERE task_id = sqlc.arg('source_id')
+ON CONFLICT DO NOTHING;
+
+-- name: CopyTaskFieldValues :exec
+INSERT INTO task_field_values (task_id, field_id, value_text, value_number, value_date, value_user_id, value_options)
+SELECT sqlc.arg('task_id')::bigint, field_id, value_text, value_number, value_date, value_user_id, value_options
+FROM task_field_values
+WHERE task_id = sqlc.arg('source_id');
+
+-- name: CopyTaskChecklist :exec
+-- The copied items start unchecked.
+INSERT INTO task_checklist_items (task_id, text, assignee_id, position)
+SELECT sqlc.arg('task_id')::bigint, text, assignee_id, position
+FROM task_checklist_items
+WHERE task_id = sqlc.arg('source_id');
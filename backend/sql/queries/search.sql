@@ -0,0 +1,43 @@
+-- name: ListAccessibleBoardIDs :many
+SELECT board_id FROM board_access
+WHERE user_id = $1
+ORDER BY board_id;
+
+-- name: SearchTasks :many
+-- The query is parsed with both configurations and either may match. The
+-- snippet is cut with the configuration that matched, so the highlighted
+-- words are the ones that were found. Ranks are normalized to [0, 1).
+WITH q AS (
+    SELECT websearch_to_tsquery('english', sqlc.arg('query')::text) AS en,
+           websearch_to_tsquery('russian', sqlc.arg('query')::text) AS ru
+)
+SELECT t.id, t.board_id, t.title, t.created_at,
+    ts_rank_cd(t.search_vector, q.en || q.ru, 32)::float8 AS rank,
+    (CASE WHEN to_tsvector('russian', t.title || E'\n' || t.description) @@ q.ru
+        THEN ts_headline('russian', t.title || E'\n' || t.description, q.ru, sqlc.arg('headline_options')::text)
+        ELSE ts_headline('english', t.title || E'\n' || t.description, q.en, sqlc.arg('headline_options')::text)
+    END)::text AS snippet
+FROM tasks t, q
+WHERE t.board_id = ANY(sqlc.arg('board_ids')::bigint[])
+  AND t.search_vector @@ (q.en || q.ru)
+ORDER BY rank DESC, t.id DESC
+LIMIT sqlc.arg('max_results');
+
+-- name: SearchComments :many
+WITH q AS (
+    SELECT websearch_to_tsquery('english', sqlc.arg('query')::text) AS en,
+           websearch_to_tsquery('russian', sqlc.arg('query')::text) AS ru
+)
+SELECT c.id, c.task_id, t.board_id, t.title, c.created_at,
+    ts_rank_cd(c.search_vector, q.en || q.ru, 32)::float8 AS rank,
+    (CASE WHEN to_tsvector('russian', c.body) @@ q.ru
+        THEN ts_headline('russian', c.body, q.ru, sqlc.arg('headline_options')::text)
+        ELSE ts_headline('english', c.body, q.en, sqlc.arg('headline_options')::text)
+    END)::text AS snippet
+FROM task_comments c
+JOIN tasks t ON t.id = c.task_id, q
+WHERE t.board_id = ANY(sqlc.arg('board_ids')::bigint[])
+  AND c.deleted_at IS NULL
+  AND c.search_vector @@ (q.en || q.ru)
+ORDER BY rank DESC, c.id DESC
+LIMIT sqlc.arg('max_results');
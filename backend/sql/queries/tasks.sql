@@ -0,0 +1,216 @@
+-- name: CreateTask :one
+INSERT INTO tasks (
+    board_id,
+    column_id,
+    user_id,
+    title,
+    description,
+    deadline,
+    position,
+    priority,
+    parent_id,
+    completed_at
+)
+SELECT c.board_id, c.id, sqlc.arg('user_id')::bigint, sqlc.arg('title')::text, sqlc.arg('description')::text,
+    sqlc.narg('deadline')::timestamptz, sqlc.arg('position')::text, sqlc.arg('priority')::text,
+    sqlc.narg('parent_id')::bigint, CASE WHEN c.is_done THEN CURRENT_TIMESTAMP END
+FROM board_columns c
+JOIN board_access a ON a.board_id = c.board_id
+WHERE c.id = sqlc.arg('column_id') AND a.user_id = sqlc.arg('user_id')
+RETURNING id, board_id, column_id, user_id, title, description, deadline, position, created_at, updated_at, version, priority, parent_id, completed_at;
+
+-- name: ListBoardTasks :many
+-- Empty filter arrays and NULL conditions match every task. Field filters
+-- are given as parallel arrays, one entry per condition, with the value in
+-- the column matching the field's type; a task must meet all of them. The
+-- text matches the search vector or, for partly typed words, the title.
+SELECT t.id, t.board_id, t.column_id, t.user_id, t.title, t.description, t.deadline, t.position,
+    t.created_at, t.updated_at, t.version, t.priority, t.parent_id, t.completed_at, c.title AS status, c.is_done AS done,
+    ARRAY(SELECT tl.label_id FROM task_labels tl WHERE tl.task_id = t.id ORDER BY tl.label_id)::bigint[] AS label_ids,
+    ARRAY(SELECT ta.user_id FROM task_assignees ta WHERE ta.task_id = t.id ORDER BY ta.created_at, ta.user_id)::bigint[] AS assignee_ids,
+    ARRAY(SELECT at.id FROM attachments at WHERE at.task_id = t.id ORDER BY at.id)::bigint[] AS attachment_ids,
+    ARRAY(SELECT d.blocker_id FROM task_dependencies d WHERE d.blocked_id = t.id ORDER BY d.blocker_id)::bigint[] AS blocked_by,
+    (SELECT COUNT(*) FILTER (WHERE i.done) FROM task_checklist_items i WHERE i.task_id = t.id) AS checklist_done,
+    (SELECT COUNT(*) FROM task_checklist_items i WHERE i.task_id = t.id) AS checklist_total,
+    (SELECT COUNT(*) FILTER (WHERE sc.is_done) FROM tasks st JOIN board_columns sc ON sc.id = st.column_id WHERE st.parent_id = t.id) AS subtasks_done,
+    (SELECT COUNT(*) FROM tasks st WHERE st.parent_id = t.id) AS subtasks_total
+FROM tasks t
+JOIN board_columns c ON c.id = t.column_id
+JOIN board_access a ON a.board_id = t.board_id
+WHERE t.board_id = sqlc.arg('board_id') AND a.user_id = sqlc.arg('user_id')
+  AND (COALESCE(cardinality(sqlc.arg('priorities')::text[]), 0) = 0
+    OR t.priority = ANY(sqlc.arg('priorities')::text[]))
+  AND (COALESCE(cardinality(sqlc.arg('label_ids')::bigint[]), 0) = 0
+    OR EXISTS (
+      SELECT 1 FROM task_labels tl
+      WHERE tl.task_id = t.id AND tl.label_id = ANY(sqlc.arg('label_ids')::bigint[])
+    ))
+  AND (COALESCE(cardinality(sqlc.arg('assignee_ids')::bigint[]), 0) = 0
+    OR EXISTS (
+      SELECT 1 FROM task_assignees ta
+      WHERE ta.task_id = t.id AND ta.user_id = ANY(sqlc.arg('assignee_ids')::bigint[])
+    ))
+  AND (COALESCE(cardinality(sqlc.arg('column_ids')::bigint[]), 0) = 0
+    OR t.column_id = ANY(sqlc.arg('column_ids')::bigint[]))
+  AND (sqlc.narg('done')::boolean IS NULL OR c.is_done = sqlc.narg('done'))
+  AND (sqlc.narg('due_from')::timestamptz IS NULL OR t.deadline >= sqlc.narg('due_from'))
+  AND (sqlc.narg('due_to')::timestamptz IS NULL OR t.deadline < sqlc.narg('due_to'))
+  AND (sqlc.narg('text')::text IS NULL
+    OR t.search_vector @@ (websearch_to_tsquery('english', sqlc.narg('text')) || websearch_to_tsquery('russian', sqlc.narg('text')))
+    OR strpos(LOWER(t.title), LOWER(sqlc.narg('text'))) > 0)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM unnest(
+        sqlc.arg('field_ids')::bigint[],
+        sqlc.arg('field_ops')::text[],
+        sqlc.arg('field_texts')::text[],
+        sqlc.arg('field_numbers')::float8[],
+        sqlc.arg('field_dates')::date[],
+        sqlc.arg('field_users')::bigint[]
+    ) AS f(field_id, op, value_text, value_number, value_date, value_user_id)
+    WHERE NOT EXISTS (
+      SELECT 1 FROM task_field_values v
+      WHERE v.task_id = t.id AND v.field_id = f.field_id
+        AND CASE f.op
+          WHEN 'eq' THEN LOWER(v.value_text) = LOWER(f.value_text)
+            OR v.value_number = f.value_number
+            OR v.value_date = f.value_date
+            OR v.value_user_id = f.value_user_id
+            OR f.value_text = ANY(v.value_options)
+          WHEN 'gte' THEN v.value_number >= f.value_number OR v.value_date >= f.value_date
+          WHEN 'lte' THEN v.value_number <= f.value_number OR v.value_date <= f.value_date
+          ELSE FALSE
+        END
+    )
+  )
+ORDER BY t.position, t.id;
+
+-- name: GetTaskForMember :one
+SELECT t.id, t.board_id, t.column_id, t.user_id, t.title, t.description, t.deadline, t.position,
+    t.created_at, t.updated_at, t.version, t.priority, t.parent_id, t.completed_at, c.title AS status, c.is_done AS done,
+    ARRAY(SELECT tl.label_id FROM task_labels tl WHERE tl.task_id = t.id ORDER BY tl.label_id)::bigint[] AS label_ids,
+    ARRAY(SELECT ta.user_id FROM task_assignees ta WHERE ta.task_id = t.id ORDER BY ta.created_at, ta.user_id)::bigint[] AS assignee_ids,
+    ARRAY(SELECT at.id FROM attachments at WHERE at.task_id = t.id ORDER BY at.id)::bigint[] AS attachment_ids,
+    ARRAY(SELECT d.blocker_id FROM task_dependencies d WHERE d.blocked_id = t.id ORDER BY d.blocker_id)::bigint[] AS blocked_by,
+    (SELECT COUNT(*) FILTER (WHERE i.done) FROM task_checklist_items i WHERE i.task_id = t.id) AS checklist_done,
+    (SELECT COUNT(*) FROM task_checklist_items i WHERE i.task_id = t.id) AS checklist_total,
+    (SELECT COUNT(*) FILTER (WHERE sc.is_done) FROM tasks st JOIN board_columns sc ON sc.id = st.column_id WHERE st.parent_id = t.id) AS subtasks_done,
+    (SELECT COUNT(*) FROM tasks st WHERE st.parent_id = t.id) AS subtasks_total
+FROM tasks t
+JOIN board_columns c ON c.id = t.column_id
+JOIN board_access a ON a.board_id = t.board_id
+WHERE t.id = $1 AND a.user_id = $2
+LIMIT 1;
+
+-- name: ListSubtasks :many
+SELECT t.id, t.board_id, t.column_id, t.user_id, t.title, t.description, t.deadline, t.position,
+    t.created_at, t.updated_at, t.version, t.priority, t.parent_id, t.completed_at, c.title AS status, c.is_done AS done,
+    ARRAY(SELECT tl.label_id FROM task_labels tl WHERE tl.task_id = t.id ORDER BY tl.label_id)::bigint[] AS label_ids,
+    ARRAY(SELECT ta.user_id FROM task_assignees ta WHERE ta.task_id = t.id ORDER BY ta.created_at, ta.user_id)::bigint[] AS assignee_ids,
+    ARRAY(SELECT at.id FROM attachments at WHERE at.task_id = t.id ORDER BY at.id)::bigint[] AS attachment_ids,
+    ARRAY(SELECT d.blocker_id FROM task_dependencies d WHERE d.blocked_id = t.id ORDER BY d.blocker_id)::bigint[] AS blocked_by,
+    (SELECT COUNT(*) FILTER (WHERE i.done) FROM task_checklist_items i WHERE i.task_id = t.id) AS checklist_done,
+    (SELECT COUNT(*) FROM task_checklist_items i WHERE i.task_id = t.id) AS checklist_total,
+    (SELECT COUNT(*) FILTER (WHERE sc.is_done) FROM tasks st JOIN board_columns sc ON sc.id = st.column_id WHERE st.parent_id = t.id) AS subtasks_done,
+    (SELECT COUNT(*) FROM tasks st WHERE st.parent_id = t.id) AS subtasks_total
+FROM tasks t
+JOIN board_columns c ON c.id = t.column_id
+JOIN board_access a ON a.board_id = t.board_id
+WHERE t.parent_id = $1 AND a.user_id = $2
+ORDER BY t.created_at, t.id;
+
+-- name: UpdateTask :execrows
+-- A null version updates unconditionally.
+UPDATE tasks t
+SET title = COALESCE(sqlc.narg('title'), t.title),
+    description = COALESCE(sqlc.narg('description'), t.description),
+    deadline = CASE WHEN sqlc.arg('clear_deadline')::bool THEN NULL ELSE COALESCE(sqlc.narg('deadline'), t.deadline) END,
+    priority = COALESCE(sqlc.narg('priority'), t.priority),
+    parent_id = CASE WHEN sqlc.arg('clear_parent')::bool THEN NULL ELSE COALESCE(sqlc.narg('parent_id'), t.parent_id) END,
+    version = t.version + 1,
+    updated_at = CURRENT_TIMESTAMP
+FROM board_access a
+WHERE t.id = sqlc.arg('id')
+  AND a.board_id = t.board_id
+  AND a.user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('version')::bigint IS NULL OR t.version = sqlc.narg('version'));
+
+-- name: DeleteTask :execrows
+DELETE FROM tasks t
+USING board_access a
+WHERE t.id = sqlc.arg('id')
+  AND a.board_id = t.board_id
+  AND a.user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('version')::bigint IS NULL OR t.version = sqlc.narg('version'));
+
+-- name: MoveTask :execrows
+-- Moving only rewrites the task's own column and position, and stamps or
+-- clears completed_at. The target column must belong to the same board.
+UPDATE tasks t
+SET column_id = c.id,
+    position = sqlc.arg('position'),
+    completed_at = CASE WHEN c.is_done THEN COALESCE(t.completed_at, CURRENT_TIMESTAMP) END,
+    version = t.version + 1,
+    updated_at = CURRENT_TIMESTAMP
+FROM board_columns c, board_access a
+WHERE t.id = sqlc.arg('id')
+  AND c.id = sqlc.arg('column_id')
+  AND c.board_id = t.board_id
+  AND a.board_id = t.board_id
+  AND a.user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('version')::bigint IS NULL OR t.version = sqlc.narg('version'));
+
+-- name: GetLastTaskPosition :one
+SELECT COALESCE(MAX(position), '')::text AS position
+FROM tasks
+WHERE column_id = $1 AND id <> $2;
+
+-- name: GetNextTaskPosition :one
+-- The smallest position above the given one, or an empty string.
+SELECT COALESCE(MIN(position), '')::text AS position
+FROM tasks
+WHERE column_id = sqlc.arg('column_id')
+  AND position > sqlc.arg('position')
+  AND id <> sqlc.arg('exclude_id');
+
+-- name: GetPrevTaskPosition :one
+-- The largest position below the given one, or an empty string.
+SELECT COALESCE(MAX(position), '')::text AS position
+FROM tasks
+WHERE column_id = sqlc.arg('column_id')
+  AND position < sqlc.arg('position')
+  AND id <> sqlc.arg('exclude_id');
+
+-- name: ListColumnsNeedingRebalance :many
+-- Columns with overlong keys or with duplicate keys left behind by
+-- concurrent moves.
+SELECT column_id
+FROM tasks
+GROUP BY column_id
+HAVING MAX(LENGTH(position)) > sqlc.arg('max_length')::int
+    OR COUNT(DISTINCT position) < COUNT(*);
+
+-- name: ListColumnTaskIDs :many
+SELECT id
+FROM tasks
+WHERE column_id = $1
+ORDER BY position, id
+FOR UPDATE;
+
+-- name: SetTaskPosition :exec
+-- Rebalancing keeps the order, so it leaves the version alone.
+UPDATE tasks
+SET position = $2
+WHERE id = $1;
+
+-- name: TaskParentAllowed :one
+-- Tells whether parent_id can take task_id as a subtask: it is another
+-- top-level task on board_id, and task_id has no subtasks of its own. A task
+-- that is still being created has task_id 0.
+SELECT (EXISTS (
+        SELECT 1 FROM tasks p
+        WHERE p.id = sqlc.arg('parent_id') AND p.id <> sqlc.arg('task_id')
+          AND p.board_id = sqlc.arg('board_id') AND p.parent_id IS NULL
+    ) AND NOT EXISTS (
+        SELECT 1 FROM tasks s WHERE s.parent_id = sqlc.arg('task_id')
+    ))::boolean AS allowed;
@@ -0,0 +1,50 @@
+-- name: CreateBoardTemplate :one
+INSERT INTO board_templates (workspace_id, created_by, name, description, content)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, workspace_id, created_by, name, description, content, created_at, updated_at;
+
+-- name: ListBoardTemplates :many
+SELECT id, workspace_id, created_by, name, description, content, created_at, updated_at
+FROM board_templates
+WHERE workspace_id = $1
+ORDER BY LOWER(name), id;
+
+-- name: GetBoardTemplate :one
+SELECT id, workspace_id, created_by, name, description, content, created_at, updated_at
+FROM board_templates
+WHERE id = $1;
+
+-- name: DeleteBoardTemplate :execrows
+DELETE FROM board_templates
+WHERE id = $1;
+
+-- name: CreateTaskTemplate :one
+INSERT INTO task_templates (board_id, created_by, name, title, description, priority, label_ids, checklist)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, board_id, created_by, name, title, description, priority, label_ids, checklist, created_at, updated_at;
+
+-- name: ListTaskTemplates :many
+SELECT id, board_id, created_by, name, title, description, priority, label_ids, checklist, created_at, updated_at
+FROM task_templates
+WHERE board_id = $1
+ORDER BY LOWER(name), id;
+
+-- name: GetTaskTemplate :one
+SELECT id, board_id, created_by, name, title, description, priority, label_ids, checklist, created_at, updated_at
+FROM task_templates
+WHERE id = $1;
+
+-- name: UpdateTaskTemplate :execrows
+UPDATE task_templates
+SET name = COALESCE(sqlc.narg('name'), name),
+    title = COALESCE(sqlc.narg('title'), title),
+    description = COALESCE(sqlc.narg('description'), description),
+    priority = COALESCE(sqlc.narg('priority'), priority),
+    label_ids = COALESCE(sqlc.narg('label_ids')::bigint[], label_ids),
+    checklist = COALESCE(sqlc.narg('checklist')::text[], checklist),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id');
+
+-- name: DeleteTaskTemplate :execrows
+DELETE FROM task_templates
+WHERE id = $1;
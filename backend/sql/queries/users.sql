@@ -21,7 +21,8 @@ SELECT
     two_fa_enabled,
     created_at,
     blocked_until,
-    failed_attempts
+    failed_attempts,
+    password_reset_required
 FROM users 
 WHERE email = $1 
 LIMIT 1;
@@ -37,7 +38,8 @@ SELECT
     two_fa_enabled,
     created_at,
     blocked_until,
-    failed_attempts
+    failed_attempts,
+    password_reset_required
 FROM users 
 WHERE id = $1 
 LIMIT 1;
@@ -60,16 +62,44 @@ DELETE FROM refresh_tokens
 WHERE user_id = $1;
 
 -- name: CreateLoginAttempt :exec
-INSERT INTO login_attempts (email, success, attempted_at)
-VALUES ($1, $2, $3);
+INSERT INTO login_attempts (email, success, attempted_at, ip_address, user_agent)
+VALUES ($1, $2, $3, $4, $5);
 
 -- name: GetRecentFailedAttempts :one
 SELECT COUNT(*) as count
 FROM login_attempts 
 WHERE email = $1 
   AND success = false 
+  AND cleared = false
+  AND attempted_at >= $2;
+
+-- name: GetFailedAttemptStatsByIP :one
+SELECT
+    COUNT(*) as failures,
+    MAX(attempted_at)::timestamptz as last_failed_at
+FROM login_attempts
+WHERE ip_address = $1
+  AND success = false
+  AND cleared = false
   AND attempted_at >= $2;
 
+-- name: ClearLoginAttemptsByEmail :exec
+UPDATE login_attempts
+SET cleared = true
+WHERE email = $1
+  AND cleared = false;
+
+-- name: ClearLoginAttemptsByIP :exec
+UPDATE login_attempts
+SET cleared = true
+WHERE ip_address = $1
+  AND cleared = false;
+
+-- name: GetLockoutCount :one
+SELECT failed_attempts
+FROM users
+WHERE email = $1;
+
 -- name: GetBlockedStatus :one
 SELECT blocked_until
 FROM users
@@ -85,6 +115,7 @@ SELECT COUNT(*) as count
 FROM login_attempts 
 WHERE email = $1 
   AND success = false 
+  AND cleared = false
   AND attempted_at >= $2;
   
 -- name: BlockUser :exec 
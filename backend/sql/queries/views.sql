@@ -0,0 +1,28 @@
+-- name: CreateBoardView :one
+INSERT INTO board_views (board_id, user_id, name, shared, query)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, board_id, user_id, name, shared, query, created_at, updated_at;
+
+-- name: ListBoardViews :many
+-- The user's own views first, then the ones others shared.
+SELECT id, board_id, user_id, name, shared, query, created_at, updated_at
+FROM board_views
+WHERE board_id = sqlc.arg('board_id') AND (user_id = sqlc.arg('user_id') OR shared)
+ORDER BY user_id <> sqlc.arg('user_id'), LOWER(name), id;
+
+-- name: GetBoardView :one
+SELECT id, board_id, user_id, name, shared, query, created_at, updated_at
+FROM board_views
+WHERE id = $1;
+
+-- name: UpdateBoardView :execrows
+UPDATE board_views
+SET name = COALESCE(sqlc.narg('name'), name),
+    shared = COALESCE(sqlc.narg('shared'), shared),
+    query = COALESCE(sqlc.narg('query')::jsonb, query),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = sqlc.arg('id');
+
+-- name: DeleteBoardView :execrows
+DELETE FROM board_views
+WHERE id = $1;
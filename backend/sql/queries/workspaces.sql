@@ -0,0 +1,199 @@
+-- name: CreateWorkspace :one
+INSERT INTO workspaces (
+    name,
+    created_by
+) VALUES (
+    $1, $2
+) RETURNING id, name, created_by, created_at, updated_at;
+
+-- name: AddWorkspaceMember :execrows
+-- Existing members keep their role, except that guests are upgraded.
+INSERT INTO workspace_members (
+    workspace_id,
+    user_id,
+    role
+) VALUES (
+    $1, $2, $3
+) ON CONFLICT (workspace_id, user_id) DO UPDATE
+SET role = EXCLUDED.role
+WHERE workspace_members.role = 'guest' AND EXCLUDED.role <> 'guest';
+
+-- name: ListWorkspacesForMember :many
+SELECT
+    w.id,
+    w.name,
+    w.created_by,
+    w.created_at,
+    w.updated_at,
+    m.role,
+    (SELECT COUNT(*) FROM workspace_members c WHERE c.workspace_id = w.id) AS member_count
+FROM workspaces w
+JOIN workspace_members m ON m.workspace_id = w.id
+WHERE m.user_id = $1
+ORDER BY w.name, w.id;
+
+-- name: GetWorkspaceForMember :one
+SELECT
+    w.id,
+    w.name,
+    w.created_by,
+    w.created_at,
+    w.updated_at,
+    m.role,
+    (SELECT COUNT(*) FROM workspace_members c WHERE c.workspace_id = w.id) AS member_count
+FROM workspaces w
+JOIN workspace_members m ON m.workspace_id = w.id
+WHERE w.id = $1 AND m.user_id = $2
+LIMIT 1;
+
+-- name: RenameWorkspace :execrows
+UPDATE workspaces
+SET name = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND EXISTS (
+    SELECT 1 FROM workspace_members m
+    WHERE m.workspace_id = $1 AND m.user_id = $2 AND m.role IN ('owner', 'admin')
+  );
+
+-- name: DeleteWorkspace :execrows
+DELETE FROM workspaces
+WHERE id = $1
+  AND EXISTS (
+    SELECT 1 FROM workspace_members m
+    WHERE m.workspace_id = $1 AND m.user_id = $2 AND m.role = 'owner'
+  );
+
+-- name: ListWorkspaceMembers :many
+SELECT
+    m.user_id,
+    u.username,
+    u.firstname,
+    u.lastname,
+    u.avatar_path,
+    m.role,
+    m.joined_at
+FROM workspace_members m
+JOIN users u ON u.id = m.user_id
+WHERE m.workspace_id = sqlc.arg('workspace_id')
+  AND EXISTS (
+    SELECT 1 FROM workspace_members v
+    WHERE v.workspace_id = sqlc.arg('workspace_id') AND v.user_id = sqlc.arg('viewer_id')
+  )
+ORDER BY m.joined_at, m.user_id;
+
+-- name: GetWorkspaceMemberRole :one
+SELECT role FROM workspace_members
+WHERE workspace_id = $1 AND user_id = $2
+LIMIT 1;
+
+-- name: CountWorkspaceOwners :one
+SELECT COUNT(*) FROM workspace_members
+WHERE workspace_id = $1 AND role = 'owner';
+
+-- name: UpdateWorkspaceMemberRole :execrows
+UPDATE workspace_members
+SET role = $3
+WHERE workspace_id = $1 AND user_id = $2;
+
+-- name: RemoveWorkspaceMember :execrows
+DELETE FROM workspace_members
+WHERE workspace_id = $1 AND user_id = $2;
+
+-- name: RevokePendingWorkspaceInvitations :exec
+UPDATE workspace_invitations
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE workspace_id = sqlc.arg('workspace_id')
+  AND LOWER(email) = LOWER(sqlc.arg('email'))
+  AND accepted_at IS NULL
+  AND revoked_at IS NULL;
+
+-- name: CreateWorkspaceInvitation :one
+INSERT INTO workspace_invitations (
+    workspace_id,
+    email,
+    role,
+    token_hash,
+    invited_by,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, workspace_id, email, role, token_hash, invited_by, expires_at, accepted_by, accepted_at, revoked_at, created_at;
+
+-- name: ListPendingWorkspaceInvitations :many
+SELECT id, workspace_id, email, role, token_hash, invited_by, expires_at, accepted_by, accepted_at, revoked_at, created_at
+FROM workspace_invitations
+WHERE workspace_id = $1
+  AND accepted_at IS NULL
+  AND revoked_at IS NULL
+  AND expires_at > CURRENT_TIMESTAMP
+ORDER BY created_at DESC;
+
+-- name: RevokeWorkspaceInvitation :execrows
+UPDATE workspace_invitations
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND workspace_id = $2
+  AND accepted_at IS NULL
+  AND revoked_at IS NULL;
+
+-- name: GetWorkspaceInvitationByTokenHash :one
+SELECT
+    i.id,
+    i.workspace_id,
+    w.name AS workspace_name,
+    i.email,
+    i.role,
+    i.invited_by,
+    i.expires_at,
+    i.accepted_at,
+    i.revoked_at,
+    i.created_at
+FROM workspace_invitations i
+JOIN workspaces w ON w.id = i.workspace_id
+WHERE i.token_hash = $1
+LIMIT 1;
+
+-- name: AcceptWorkspaceInvitation :execrows
+UPDATE workspace_invitations
+SET accepted_at = CURRENT_TIMESTAMP,
+    accepted_by = $2
+WHERE id = $1
+  AND accepted_at IS NULL
+  AND revoked_at IS NULL
+  AND expires_at > CURRENT_TIMESTAMP;
+
+-- name: PromoteWorkspaceSuccessors :exec
+-- For every workspace the user is the only owner of, the longest-standing
+-- admin (or, failing that, member) becomes owner. Guests are never promoted.
+UPDATE workspace_members m
+SET role = 'owner'
+FROM (
+    SELECT DISTINCT ON (o.workspace_id) o.workspace_id, c.user_id
+    FROM workspace_members o
+    JOIN workspace_members c ON c.workspace_id = o.workspace_id AND c.user_id <> o.user_id AND c.role <> 'guest'
+    WHERE o.user_id = $1
+      AND o.role = 'owner'
+      AND NOT EXISTS (
+        SELECT 1 FROM workspace_members x
+        WHERE x.workspace_id = o.workspace_id AND x.role = 'owner' AND x.user_id <> o.user_id
+      )
+    ORDER BY o.workspace_id, (c.role = 'admin') DESC, c.joined_at
+) s
+WHERE m.workspace_id = s.workspace_id AND m.user_id = s.user_id;
+
+-- name: DeleteSoleMemberWorkspaces :exec
+-- Deletes the workspaces where nobody but the user and guests is left.
+DELETE FROM workspaces w
+WHERE EXISTS (
+    SELECT 1 FROM workspace_members m
+    WHERE m.workspace_id = w.id AND m.user_id = $1
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM workspace_members o
+    WHERE o.workspace_id = w.id AND o.user_id <> $1 AND o.role <> 'guest'
+  );
+
+-- name: DeleteWorkspaceMembershipsByUserID :exec
+DELETE FROM workspace_members
+WHERE user_id = $1;
@@ -0,0 +1,2 @@
+-- RS256 refresh tokens do not fit into 512 characters.
+ALTER TABLE refresh_tokens ALTER COLUMN token TYPE TEXT;
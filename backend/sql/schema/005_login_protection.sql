@@ -0,0 +1,3 @@
+ALTER TABLE login_attempts ADD COLUMN cleared BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_login_attempts_ip_time ON login_attempts(ip_address, attempted_at);
@@ -0,0 +1,6 @@
+ALTER TABLE users ADD COLUMN time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+ALTER TABLE users ADD COLUMN locale VARCHAR(16) NOT NULL DEFAULT 'en';
+ALTER TABLE users ADD COLUMN avatar_path TEXT;
+
+CREATE INDEX idx_users_username_lower ON users(LOWER(username) varchar_pattern_ops);
+CREATE INDEX idx_users_name_lower ON users(LOWER(firstname || ' ' || lastname) varchar_pattern_ops);
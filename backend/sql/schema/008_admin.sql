@@ -0,0 +1,16 @@
+ALTER TABLE users ADD COLUMN role VARCHAR(20) NOT NULL DEFAULT 'user';
+ALTER TABLE users ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE admin_actions (
+    id BIGSERIAL PRIMARY KEY,
+    admin_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(50) NOT NULL,
+    target_user_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_admin_actions_target_user_id ON admin_actions(target_user_id, created_at);
+CREATE INDEX idx_admin_actions_admin_id ON admin_actions(admin_id, created_at);
@@ -0,0 +1,49 @@
+-- audit_events supersedes admin_actions. Rows are never updated or deleted,
+-- so user ids are kept as plain values instead of foreign keys: anonymizing
+-- or removing a user must not rewrite the trail.
+CREATE TABLE audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_type VARCHAR(64) NOT NULL,
+    actor_id BIGINT,
+    target_user_id BIGINT,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_events_created_at ON audit_events(created_at);
+CREATE INDEX idx_audit_events_event_type ON audit_events(event_type, id);
+CREATE INDEX idx_audit_events_actor_id ON audit_events(actor_id, id);
+CREATE INDEX idx_audit_events_target_user_id ON audit_events(target_user_id, id);
+
+CREATE OR REPLACE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_no_update_delete
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
+
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only();
+
+INSERT INTO audit_events (event_type, actor_id, target_user_id, ip_address, user_agent, details, created_at)
+SELECT
+    CASE action
+        WHEN 'change_role' THEN 'permission.role_changed'
+        ELSE 'admin.' || action
+    END,
+    admin_id,
+    target_user_id,
+    ip_address,
+    user_agent,
+    details,
+    COALESCE(created_at, CURRENT_TIMESTAMP)
+FROM admin_actions
+ORDER BY id;
+
+DROP TABLE admin_actions;
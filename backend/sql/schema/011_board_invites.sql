@@ -0,0 +1,47 @@
+-- Guests are workspace members that only see the boards they were invited
+-- to.
+ALTER TABLE workspace_members DROP CONSTRAINT workspace_members_role_check;
+ALTER TABLE workspace_members ADD CONSTRAINT workspace_members_role_check
+    CHECK (role IN ('owner', 'admin', 'member', 'guest'));
+
+-- Explicit per-board roles. Workspace owners and admins are board admins
+-- without a row here; other members without a row are editors.
+CREATE TABLE board_members (
+    board_id BIGINT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'editor', 'viewer')),
+    joined_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (board_id, user_id)
+);
+
+CREATE INDEX idx_board_members_user_id ON board_members(user_id);
+
+INSERT INTO board_members (board_id, user_id, role)
+SELECT id, created_by, 'admin'
+FROM boards
+WHERE created_by IS NOT NULL;
+
+-- An invite without email is an open link anyone signed in can use, up to
+-- max_uses times (unlimited when NULL). Only the token hash is stored.
+CREATE TABLE board_invites (
+    id BIGSERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    email VARCHAR(255),
+    role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'editor', 'viewer')),
+    token_hash VARCHAR(64) UNIQUE NOT NULL,
+    max_uses INTEGER CHECK (max_uses > 0),
+    use_count INTEGER NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_board_invites_board_id ON board_invites(board_id);
+
+CREATE TABLE board_invite_redemptions (
+    invite_id BIGINT NOT NULL REFERENCES board_invites(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    redeemed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (invite_id, user_id)
+);
@@ -0,0 +1,42 @@
+-- board_access lists who can open which board and with what role. Task and
+-- column queries join it so they stay scoped to the caller's workspaces.
+CREATE VIEW board_access AS
+SELECT b.id AS board_id,
+    b.workspace_id,
+    m.user_id,
+    m.role AS workspace_role,
+    (CASE WHEN m.role IN ('owner', 'admin') THEN 'admin' ELSE COALESCE(bm.role, 'editor') END)::text AS board_role
+FROM boards b
+JOIN workspace_members m ON m.workspace_id = b.workspace_id
+LEFT JOIN board_members bm ON bm.board_id = b.id AND bm.user_id = m.user_id
+WHERE m.role <> 'guest' OR bm.user_id IS NOT NULL;
+
+-- position columns hold lexorank keys and must compare bytewise.
+CREATE TABLE board_columns (
+    id BIGSERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL,
+    position VARCHAR(255) COLLATE "C" NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_board_columns_board_position ON board_columns(board_id, position);
+
+CREATE TABLE tasks (
+    id BIGSERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    column_id BIGINT NOT NULL REFERENCES board_columns(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    deadline TIMESTAMP WITH TIME ZONE,
+    position VARCHAR(255) COLLATE "C" NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_tasks_column_position ON tasks(column_id, position);
+CREATE INDEX idx_tasks_board_id ON tasks(board_id);
+CREATE INDEX idx_tasks_user_id ON tasks(user_id);
+CREATE INDEX idx_tasks_deadline ON tasks(deadline) WHERE deadline IS NOT NULL;
@@ -0,0 +1,3 @@
+-- revision counts the changes published to a board's room. Clients compare
+-- it with the revision of each event to notice events they missed.
+ALTER TABLE boards ADD COLUMN revision BIGINT NOT NULL DEFAULT 0;
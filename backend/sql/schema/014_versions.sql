@@ -0,0 +1,4 @@
+-- version counts the writes to a row. Clients send it back in If-Match so a
+-- write based on a stale copy is refused instead of overwriting newer data.
+ALTER TABLE boards ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE tasks ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,24 @@
+-- Comment bodies are markdown, sanitized before they are stored. Deleting a
+-- comment only marks it; its text survives in task_comment_revisions.
+CREATE TABLE task_comments (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_task_comments_task ON task_comments(task_id, created_at);
+
+-- One row per replaced body: written before every edit and before deletion.
+CREATE TABLE task_comment_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    comment_id BIGINT NOT NULL REFERENCES task_comments(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    edited_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    edited_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_task_comment_revisions_comment ON task_comment_revisions(comment_id, edited_at);
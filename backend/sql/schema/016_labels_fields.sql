@@ -0,0 +1,53 @@
+ALTER TABLE tasks ADD COLUMN priority VARCHAR(20) NOT NULL DEFAULT 'none'
+    CHECK (priority IN ('none', 'low', 'medium', 'high', 'urgent'));
+
+CREATE INDEX idx_tasks_board_priority ON tasks(board_id, priority);
+
+CREATE TABLE board_labels (
+    id BIGSERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    color CHAR(7) NOT NULL CHECK (color ~ '^#[0-9a-f]{6}$'),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_board_labels_board_name ON board_labels(board_id, LOWER(name));
+
+CREATE TABLE task_labels (
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    label_id BIGINT NOT NULL REFERENCES board_labels(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, label_id)
+);
+
+CREATE INDEX idx_task_labels_label ON task_labels(label_id);
+
+-- options lists the choices of select and multi_select fields.
+CREATE TABLE board_custom_fields (
+    id BIGSERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('text', 'number', 'date', 'select', 'multi_select', 'user')),
+    options TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_board_custom_fields_board_name ON board_custom_fields(board_id, LOWER(name));
+
+-- One typed column per field type, so values stay comparable and indexable.
+-- Select fields keep their chosen options in value_options.
+CREATE TABLE task_field_values (
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    field_id BIGINT NOT NULL REFERENCES board_custom_fields(id) ON DELETE CASCADE,
+    value_text TEXT,
+    value_number DOUBLE PRECISION,
+    value_date DATE,
+    value_user_id BIGINT REFERENCES users(id) ON DELETE CASCADE,
+    value_options TEXT[],
+    PRIMARY KEY (task_id, field_id)
+);
+
+CREATE INDEX idx_task_field_values_number ON task_field_values(field_id, value_number) WHERE value_number IS NOT NULL;
+CREATE INDEX idx_task_field_values_date ON task_field_values(field_id, value_date) WHERE value_date IS NOT NULL;
+CREATE INDEX idx_task_field_values_user ON task_field_values(field_id, value_user_id) WHERE value_user_id IS NOT NULL;
+CREATE INDEX idx_task_field_values_options ON task_field_values USING GIN (value_options);
@@ -0,0 +1,27 @@
+-- tasks.user_id stays the creator. Assignees do the work; watchers, together
+-- with the assignees, receive the task's notifications.
+CREATE TABLE task_assignees (
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE INDEX idx_task_assignees_user ON task_assignees(user_id);
+
+CREATE TABLE task_watchers (
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE INDEX idx_task_watchers_user ON task_watchers(user_id);
+
+-- Creators and commenters watch the tasks they already have.
+INSERT INTO task_watchers (task_id, user_id)
+SELECT id, user_id FROM tasks
+UNION
+SELECT task_id, user_id FROM task_comments WHERE deleted_at IS NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,37 @@
+-- Tasks in a done column count as completed for progress and dependencies.
+ALTER TABLE board_columns ADD COLUMN is_done BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- A subtask is a task with a parent on the same board. Subtasks are one level
+-- deep; deleting the parent turns them into plain tasks.
+ALTER TABLE tasks ADD COLUMN parent_id BIGINT REFERENCES tasks(id) ON DELETE SET NULL
+    CHECK (parent_id <> id);
+
+CREATE INDEX idx_tasks_parent ON tasks(parent_id) WHERE parent_id IS NOT NULL;
+
+-- position holds lexorank keys, like the positions of tasks.
+CREATE TABLE task_checklist_items (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    text VARCHAR(500) NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    assignee_id BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    position VARCHAR(255) COLLATE "C" NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_task_checklist_items_task_position ON task_checklist_items(task_id, position);
+
+-- blocker_id blocks blocked_id: the blocked task should not be completed
+-- before the blocker. Both tasks are on the same board and the graph is kept
+-- acyclic.
+CREATE TABLE task_dependencies (
+    blocker_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    blocked_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX idx_task_dependencies_blocked ON task_dependencies(blocked_id);
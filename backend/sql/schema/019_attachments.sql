@@ -0,0 +1,32 @@
+-- File contents live in the blob store under their SHA-256, once per
+-- content. A blob no attachment refers to is removed once it has not been
+-- used for a while.
+CREATE TABLE attachment_blobs (
+    sha256 CHAR(64) PRIMARY KEY,
+    size BIGINT NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    has_thumbnail BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_used_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_attachment_blobs_last_used ON attachment_blobs(last_used_at);
+
+-- An attachment is uploaded to a board and referenced by at most one task or
+-- chat message. Uploads nothing refers to are removed after a grace period.
+CREATE TABLE attachments (
+    id BIGSERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    task_id BIGINT REFERENCES tasks(id) ON DELETE CASCADE,
+    message_id VARCHAR(24),
+    sha256 CHAR(64) NOT NULL REFERENCES attachment_blobs(sha256),
+    filename VARCHAR(255) NOT NULL,
+    uploaded_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (task_id IS NULL OR message_id IS NULL)
+);
+
+CREATE INDEX idx_attachments_task ON attachments(task_id);
+CREATE INDEX idx_attachments_sha256 ON attachments(sha256);
+CREATE INDEX idx_attachments_unreferenced ON attachments(created_at)
+    WHERE task_id IS NULL AND message_id IS NULL;
@@ -0,0 +1,19 @@
+-- task_activity is the timeline of a task: one row per changed field,
+-- comment or attachment. Like audit_events, actors are plain ids so removing
+-- a user keeps the history; the rows go away with their task.
+CREATE TABLE task_activity (
+    id BIGSERIAL PRIMARY KEY,
+    task_id BIGINT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    board_id BIGINT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    actor_id BIGINT,
+    activity_type VARCHAR(64) NOT NULL,
+    field VARCHAR(64),
+    before_value JSONB,
+    after_value JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_task_activity_task_id ON task_activity(task_id, id);
+
+-- The change a task notification is about, copied so it outlives the task.
+ALTER TABLE notifications ADD COLUMN activity JSONB;
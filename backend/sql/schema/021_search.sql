@@ -0,0 +1,18 @@
+-- Tasks and comments are indexed with both the English and the Russian
+-- configuration, since the team writes in both: a word matches when either
+-- stemmer reduces it to the same lexeme as the query. Titles weigh more than
+-- descriptions.
+ALTER TABLE tasks ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', title), 'A') ||
+    setweight(to_tsvector('russian', title), 'A') ||
+    setweight(to_tsvector('english', description), 'B') ||
+    setweight(to_tsvector('russian', description), 'B')
+) STORED;
+
+CREATE INDEX idx_tasks_search ON tasks USING GIN (search_vector);
+
+ALTER TABLE task_comments ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    to_tsvector('english', body) || to_tsvector('russian', body)
+) STORED;
+
+CREATE INDEX idx_task_comments_search ON task_comments USING GIN (search_vector);
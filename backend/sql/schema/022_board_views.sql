@@ -0,0 +1,14 @@
+-- A view is a saved board listing: its filter, sort and grouping as JSON.
+-- Names are unique per owner and board.
+CREATE TABLE board_views (
+    id BIGSERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    shared BOOLEAN NOT NULL DEFAULT FALSE,
+    query JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_board_views_name ON board_views(board_id, user_id, LOWER(name));
@@ -0,0 +1,23 @@
+-- A column's kind says where its tasks are in their life: not started, being
+-- worked on or completed. is_done stays as a generated column, so everything
+-- reading it keeps working.
+ALTER TABLE board_columns ADD COLUMN kind VARCHAR(20) NOT NULL DEFAULT 'in_progress'
+    CHECK (kind IN ('backlog', 'in_progress', 'done'));
+UPDATE board_columns SET kind = 'done' WHERE is_done;
+ALTER TABLE board_columns DROP COLUMN is_done;
+ALTER TABLE board_columns ADD COLUMN is_done BOOLEAN GENERATED ALWAYS AS (kind = 'done') STORED;
+
+-- A work-in-progress limit caps the tasks in a column. With the "warn" policy
+-- moves past the limit go through with a warning; "enforce" refuses them.
+ALTER TABLE board_columns ADD COLUMN wip_limit INTEGER CHECK (wip_limit > 0);
+ALTER TABLE board_columns ADD COLUMN wip_policy VARCHAR(20) NOT NULL DEFAULT 'warn'
+    CHECK (wip_policy IN ('warn', 'enforce'));
+
+-- completed_at is set when a task enters a done column and cleared when it
+-- leaves one. Tasks already done get their last update as a best guess.
+ALTER TABLE tasks ADD COLUMN completed_at TIMESTAMP WITH TIME ZONE;
+UPDATE tasks t SET completed_at = t.updated_at
+FROM board_columns c
+WHERE c.id = t.column_id AND c.is_done;
+
+CREATE INDEX idx_tasks_board_completed ON tasks(board_id, completed_at) WHERE completed_at IS NOT NULL;
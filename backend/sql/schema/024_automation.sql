@@ -0,0 +1,39 @@
+-- Automation rules run on a board when their trigger fires and their
+-- conditions hold. trigger, conditions and actions are JSON; trigger_type is
+-- kept apart so the engine can pick the rules for an event by index. Rules act
+-- with the access of the member who created them.
+CREATE TABLE automation_rules (
+    id BIGSERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    created_by BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    trigger_type VARCHAR(50) NOT NULL,
+    trigger JSONB NOT NULL DEFAULT '{}',
+    conditions JSONB NOT NULL DEFAULT '{}',
+    actions JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_automation_rules_board_trigger ON automation_rules(board_id, trigger_type) WHERE enabled;
+
+-- One row per time a rule's trigger fired: whether it ran, was skipped by its
+-- conditions or loop protection, or failed, with the outcome of each action.
+-- task_id has no foreign key so the log outlives deleted tasks.
+CREATE TABLE automation_executions (
+    id BIGSERIAL PRIMARY KEY,
+    rule_id BIGINT NOT NULL REFERENCES automation_rules(id) ON DELETE CASCADE,
+    board_id BIGINT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    task_id BIGINT,
+    trigger_type VARCHAR(50) NOT NULL,
+    status VARCHAR(20) NOT NULL CHECK (status IN ('succeeded', 'skipped', 'failed')),
+    depth INTEGER NOT NULL DEFAULT 0,
+    results JSONB NOT NULL DEFAULT '[]',
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_automation_executions_rule ON automation_executions(rule_id, id);
+CREATE INDEX idx_automation_executions_task ON automation_executions(rule_id, task_id, created_at)
+    WHERE trigger_type = 'deadline.passed';
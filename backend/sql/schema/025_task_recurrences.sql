@@ -0,0 +1,25 @@
+-- A recurrence keeps a task coming back. current_task_id is the latest
+-- instance and occurrence its deadline according to the rule; a new instance,
+-- a copy of the current one with the next deadline, is created when the
+-- current one is completed (on_completion) or its deadline passes
+-- (on_schedule). rrule is an RFC 5545 RRULE, expanded from dtstart keeping
+-- its wall-clock time in time_zone. New instances go to column_id, or the
+-- board's first open column once that is gone or done. Deleting the current
+-- instance ends the recurrence.
+CREATE TABLE task_recurrences (
+    id BIGSERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    created_by BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    current_task_id BIGINT NOT NULL UNIQUE REFERENCES tasks(id) ON DELETE CASCADE,
+    column_id BIGINT REFERENCES board_columns(id) ON DELETE SET NULL,
+    rrule VARCHAR(500) NOT NULL,
+    time_zone VARCHAR(64) NOT NULL,
+    mode VARCHAR(20) NOT NULL CHECK (mode IN ('on_completion', 'on_schedule')),
+    dtstart TIMESTAMP WITH TIME ZONE NOT NULL,
+    occurrence TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_task_recurrences_board ON task_recurrences(board_id);
+CREATE INDEX idx_task_recurrences_schedule ON task_recurrences(occurrence) WHERE mode = 'on_schedule';
@@ -0,0 +1,33 @@
+-- A board template keeps a board's setup in its workspace as JSON: columns,
+-- labels, custom fields, automation rules and optionally seed tasks. The
+-- content refers to columns, labels and fields by their ids on the board it
+-- was saved from; boards made from the template get new ones.
+CREATE TABLE board_templates (
+    id BIGSERIAL PRIMARY KEY,
+    workspace_id BIGINT NOT NULL REFERENCES workspaces(id) ON DELETE CASCADE,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    content JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_board_templates_workspace_name ON board_templates(workspace_id, LOWER(name));
+
+-- A task template prefills the tasks created from it on its board.
+CREATE TABLE task_templates (
+    id BIGSERIAL PRIMARY KEY,
+    board_id BIGINT NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    created_by BIGINT REFERENCES users(id) ON DELETE SET NULL,
+    name VARCHAR(100) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    priority VARCHAR(20) NOT NULL DEFAULT 'none',
+    label_ids BIGINT[] NOT NULL DEFAULT '{}',
+    checklist TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_task_templates_board_name ON task_templates(board_id, LOWER(name));
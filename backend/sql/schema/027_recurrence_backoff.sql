@@ -0,0 +1,5 @@
+-- A recurrence whose next instance cannot be created, for example because
+-- its board has no open column, is held back until next_attempt_at, waiting
+-- twice as long after each of its consecutive failures.
+ALTER TABLE task_recurrences ADD COLUMN failures INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE task_recurrences ADD COLUMN next_attempt_at TIMESTAMP WITH TIME ZONE;
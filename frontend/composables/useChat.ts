@@ -24,7 +24,7 @@ export const useChat = (boardId: number) => {
     wsService.connect(boardId, token)
     
     wsService.onMessage((data) => {
-      if (data.board_id === boardId) {
+      if (data.type === 'chat.message' && data.board_id === boardId) {
         messages.value.push(data)
         
         if (data.user_id !== parseInt(localStorage.getItem('userId') || '0')) {
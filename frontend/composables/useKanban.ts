@@ -1,38 +1,79 @@
 import { useKanbanStore } from '../stores/kanban'
+import type { Task, BoardEvent, Label, CustomField } from '../stores/kanban'
+import { wsService } from '~/utils/websocket'
 
 export const useKanban = () => {
     const store = useKanbanStore()
     const { $fetch } = useNuxtApp()
 
-    const fetchBoard = async (boardId: string) => {
+    const fetchBoard = async (boardId: number) => {
         store.loading = true
+        store.boardId = boardId
 
-        const data = await $fetch<{ columns: any[] }>(
+        const data = await $fetch<{ revision: number, columns: any[], labels: Label[], fields: CustomField[] }>(
             `/api/boards/${boardId}/tasks`
         )
 
-
+        store.revision = data.revision
         store.setColumns(data.columns)
+        store.labels = data.labels
+        store.fields = data.fields
         store.loading = false
     }
 
-    const updateTaskStatus = async (taskId: number, status: string) => {
-    const previous = JSON.parse(JSON.stringify(store.columns))
+    // subscribe applies the board's live events to the store and reloads the
+    // board whenever an event was missed. It returns the unsubscribe function.
+    const subscribe = (boardId: number) => {
+        if (!wsService.isConnected(boardId)) {
+            wsService.connect(boardId, localStorage.getItem('token') || '')
+        }
 
-    try {
-        await $fetch(`/api/tasks/${taskId}`, {
-        method: 'PATCH',
-        body: { status },
+        return wsService.onMessage((data: BoardEvent | { type: 'chat.message' }) => {
+            if (data.type === 'chat.message' || data.board_id !== store.boardId) return
+            if (!store.applyEvent(data)) {
+                fetchBoard(boardId)
+            }
         })
-    } catch (e) {
-        store.setColumns(previous)
-        throw e
     }
+
+    // persistMove saves a task that is already at index in columnId locally.
+    // The server only needs its neighbours and the version the move is based
+    // on. When someone else changed the task first the server answers 412 with
+    // its current state, which replaces the local copy; any other failure
+    // reloads the board.
+    const persistMove = async (taskId: number, columnId: number, index: number) => {
+        const column = store.columns.find(c => c.id === columnId)
+        if (!column) return
+
+        const moved = column.tasks.find(t => t.id === taskId)
+        const after = column.tasks[index - 1]
+        const before = column.tasks[index + 1]
+
+        try {
+            const task = await $fetch<Task>(`/api/tasks/${taskId}/move`, {
+                method: 'POST',
+                headers: moved ? { 'If-Match': `"${moved.version}"` } : {},
+                body: {
+                    column_id: columnId,
+                    after_id: after?.id ?? null,
+                    before_id: before?.id ?? null,
+                },
+            })
+            store.putTask(task)
+        } catch (e: any) {
+            if (e?.status === 412 && e.data?.current) {
+                store.putTask(e.data.current)
+            } else if (store.boardId !== null) {
+                await fetchBoard(store.boardId)
+            }
+            throw e
+        }
     }
 
     return {
         store,
         fetchBoard,
-        updateTaskStatus,
+        subscribe,
+        persistMove,
     }
 }
@@ -2,22 +2,75 @@ import { defineStore } from 'pinia'
 
 export interface Task {
   id: number
+  column_id: number
   title: string
   description?: string
   status: string
+  position: string
+  version: number
+  priority: 'none' | 'low' | 'medium' | 'high' | 'urgent'
+  label_ids: number[]
+  assignee_ids: number[]
+  attachment_ids: number[]
+  parent_id: number | null
+  // Whether the task sits in a done column.
+  done: boolean
+  blocked_by: number[]
+  progress: TaskProgress
+  // Custom field values by field id.
+  fields: Record<number, string | number | string[]>
   assignee?: string
   tags?: string[]
 }
 
+export interface TaskProgress {
+  checklist_done: number
+  checklist_total: number
+  subtasks_done: number
+  subtasks_total: number
+}
+
+export interface Label {
+  id: number
+  name: string
+  color: string
+}
+
+export interface CustomField {
+  id: number
+  name: string
+  type: 'text' | 'number' | 'date' | 'select' | 'multi_select' | 'user'
+  options: string[]
+}
+
 export interface Column {
-  id: string
+  id: number
   title: string
+  position: string
+  done: boolean
   tasks: Task[]
 }
 
+export interface BoardEvent {
+  type: 'task.created' | 'task.updated' | 'task.moved' | 'task.deleted' | 'column.created'
+    | 'comment.created' | 'comment.updated' | 'comment.deleted'
+    | 'label.created' | 'label.updated' | 'label.deleted'
+    | 'field.created' | 'field.updated' | 'field.deleted'
+    | 'checklist_item.created' | 'checklist_item.updated' | 'checklist_item.moved'
+    | 'checklist_item.deleted'
+  board_id: number
+  revision: number
+  actor_id: number
+  data: any
+}
+
 export let useKanbanStore = defineStore('kanban', {
   state: () => ({
+    boardId: null as number | null,
+    revision: 0,
     columns: [] as Column[],
+    labels: [] as Label[],
+    fields: [] as CustomField[],
     loading: false,
   }),
 
@@ -26,15 +79,115 @@ export let useKanbanStore = defineStore('kanban', {
       this.columns = columns
     },
 
-    moveTask(taskId: number, from: string, to: string) {
+    // applyEvent patches the board with a server event. It returns false when
+    // events were missed and the board has to be reloaded.
+    applyEvent(event: BoardEvent): boolean {
+      if (event.revision <= this.revision) return true
+      if (event.revision > this.revision + 1) return false
+      this.revision = event.revision
+
+      switch (event.type) {
+        case 'column.created':
+          if (!this.columns.some(c => c.id === event.data.id)) {
+            this.columns.push({ ...event.data, tasks: [] })
+            this.columns.sort(byPosition)
+          }
+          break
+        case 'task.created':
+        case 'task.updated':
+          this.putTask(event.data)
+          break
+        case 'task.moved':
+          this.putTask(event.data.task)
+          break
+        case 'task.deleted':
+          this.removeTask(event.data.id)
+          break
+        case 'label.created':
+        case 'label.updated':
+          this.labels = [...this.labels.filter(l => l.id !== event.data.id), event.data]
+          break
+        case 'label.deleted':
+          this.labels = this.labels.filter(l => l.id !== event.data.id)
+          for (const column of this.columns) {
+            for (const task of column.tasks) {
+              task.label_ids = task.label_ids.filter(id => id !== event.data.id)
+            }
+          }
+          break
+        case 'field.created':
+        case 'field.updated':
+          this.fields = [...this.fields.filter(f => f.id !== event.data.id), event.data]
+          break
+        case 'field.deleted':
+          this.fields = this.fields.filter(f => f.id !== event.data.id)
+          for (const column of this.columns) {
+            for (const task of column.tasks) delete task.fields[event.data.id]
+          }
+          break
+        case 'checklist_item.created':
+        case 'checklist_item.updated':
+        case 'checklist_item.moved':
+        case 'checklist_item.deleted':
+          for (const column of this.columns) {
+            let task = column.tasks.find(t => t.id === event.data.item.task_id)
+            if (task) task.progress = event.data.progress
+          }
+          break
+      }
+      return true
+    },
+
+    // putTask replaces the task wherever it is and keeps its column ordered.
+    // A copy older than the one on the board is ignored.
+    putTask(task: Task) {
+      let column = this.columns.find(c => c.id === task.column_id)
+      if (!column) return
+      let current = this.findTask(task.id)
+      if (current && current.version > task.version) return
+      this.removeTask(task.id)
+      column.tasks.push(task)
+      column.tasks.sort(byPosition)
+    },
+
+    findTask(taskId: number): Task | undefined {
+      for (const column of this.columns) {
+        let task = column.tasks.find(t => t.id === taskId)
+        if (task) return task
+      }
+      return undefined
+    },
+
+    removeTask(taskId: number) {
+      for (const column of this.columns) {
+        let idx = column.tasks.findIndex(t => t.id === taskId)
+        if (idx !== -1) column.tasks.splice(idx, 1)
+      }
+    },
+
+    // moveTask places the task at index in the target column, or at its end
+    // when no index is given.
+    moveTask(taskId: number, from: number, to: number, index?: number) {
       let fromCol = this.columns.find(c => c.id === from)
       let toCol = this.columns.find(c => c.id === to)
       if (!fromCol || !toCol) return
 
       let idx = fromCol.tasks.findIndex(t => t.id === taskId)
+      if (idx === -1) return
       let task = fromCol.tasks.splice(idx, 1)[0]
-      task.status = to
-      toCol.tasks.push(task)
+      task.column_id = to
+      task.status = toCol.title
+
+      if (index === undefined || index > toCol.tasks.length) {
+        toCol.tasks.push(task)
+      } else {
+        toCol.tasks.splice(index, 0, task)
+      }
     },
   },
 })
+
+// Positions are lexorank keys and compare as plain strings.
+function byPosition(a: { position: string }, b: { position: string }) {
+  return a.position < b.position ? -1 : a.position > b.position ? 1 : 0
+}
@@ -1,15 +1,28 @@
 export interface Message {
+  type: 'chat.message'
   id: string
   board_id: number
   user_id: number
   username: string
   content: string
+  attachments?: AttachmentRef[]
   created_at: string
 }
 
+// AttachmentRef names a file on a message; download links come from
+// GET /api/attachments/:id.
+export interface AttachmentRef {
+  id: number
+  filename: string
+  content_type: string
+  size: number
+}
+
 export interface MessageRequest {
   board_id: number
   content: string
+  // Files uploaded beforehand with POST /api/boards/:id/attachments.
+  attachment_ids?: number[]
 }
 
 export interface Participant {
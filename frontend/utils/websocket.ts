@@ -17,12 +17,16 @@ class WebSocketService {
       this.reconnectAttempts = 0
     }
     
+    // The server batches queued messages into one frame, one per line.
     this.socket.onmessage = (event) => {
-      try {
-        const data = JSON.parse(event.data)
-        this.messageHandlers.forEach(handler => handler(data))
-      } catch (error) {
-        console.error('Error parsing message:', error)
+      for (const line of String(event.data).split('\n')) {
+        if (!line) continue
+        try {
+          const data = JSON.parse(line)
+          this.messageHandlers.forEach(handler => handler(data))
+        } catch (error) {
+          console.error('Error parsing message:', error)
+        }
       }
     }
     
@@ -46,8 +50,16 @@ class WebSocketService {
     }
   }
 
+  isConnected(boardId: number) {
+    return this.socket !== null && this.boardId === boardId
+  }
+
+  // onMessage returns a function that removes the handler again.
   onMessage(handler: (data: any) => void) {
     this.messageHandlers.push(handler)
+    return () => {
+      this.messageHandlers = this.messageHandlers.filter(h => h !== handler)
+    }
   }
 
   disconnect() {